@@ -1,18 +1,33 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os/signal"
 	_ "subs-service/docs"
+	apiGraphQL "subs-service/internal/api/graphql"
+	apiGRPC "subs-service/internal/api/grpc"
 	apiHTTP "subs-service/internal/api/http"
 	"subs-service/internal/config"
+	"subs-service/internal/domain"
 	repo "subs-service/internal/repository/postgres"
 	"subs-service/internal/usecases/service"
 	pkgConfig "subs-service/pkg/config"
 	"subs-service/pkg/database/postgres"
+	"subs-service/pkg/grpc/interceptors"
+	grpcServer "subs-service/pkg/grpc/server"
 	"subs-service/pkg/http/handlers"
+	"subs-service/pkg/http/middleware"
 	"subs-service/pkg/http/server"
+	"subs-service/pkg/notify"
+	"subs-service/pkg/ratelimit"
+	"subs-service/pkg/worker"
+	"syscall"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // @title Subscriptions Service API
@@ -27,31 +42,207 @@ func main() {
 
 	log.Printf("[INFO] Subscriptions Service is starting")
 
-	pool, err := postgres.NewPostgresPool(cfg.PostgresCfg)
+	cluster, err := postgres.NewCluster(cfg.PostgresCfg)
 	if err != nil {
 		log.Fatalf("[ERROR] Failed to connect PostgreSQL: %s", err.Error())
 	}
 
-	log.Printf("[INFO] Connected to PostgreSQL successfully")
+	log.Printf("[INFO] Connected to PostgreSQL successfully (%d read replicas)", len(cfg.PostgresCfg.Replicas))
 
-	subsRepo := repo.NewSubsRepo(pool)
-	subService := service.NewSubService(subsRepo)
+	subsRepo := repo.NewSubsRepo(cluster)
+	subService := service.NewCachedSubService(
+		service.NewSubService(subsRepo, cfg.DuplicatesCfg), cfg.CacheCfg, postgres.UsesPrimary,
+	)
 	subHandler := apiHTTP.NewSubHandler(subService, cfg.PathCfg, cfg.SvcCfg, cfg.DataCfg)
 
+	graphqlHandler, err := apiGraphQL.NewHandler(subService, cfg.PathCfg, cfg.SvcCfg, cfg.DataCfg, cfg.GraphQLCfg)
+	if err != nil {
+		log.Fatalf("[ERROR] Failed to create GraphQL schema: %s", err.Error())
+	}
+
+	auditRepo := repo.NewAuditRepo(cluster)
+	auditService := service.NewAuditService(auditRepo)
+	auditHandler := apiHTTP.NewAuditHandler(auditService, cfg.PathCfg, cfg.SvcCfg, cfg.DataCfg)
+
+	webhookService := service.NewWebhookService(repo.NewWebhooksRepo(cluster))
+	webhookHandler := apiHTTP.NewWebhookHandler(webhookService, cfg.PathCfg, cfg.SvcCfg, cfg.DataCfg)
+
+	streamService := service.NewStreamService(
+		repo.NewSubEventsRepo(cluster, cfg.StreamCfg.ReconnectBackoff), cfg.StreamCfg,
+	)
+	streamHandler := apiHTTP.NewStreamHandler(streamService, cfg.PathCfg, cfg.SvcCfg, cfg.StreamCfg)
+
+	catalogHandler := apiHTTP.NewCatalogHandler(
+		service.NewCatalogService(repo.NewCatalogRepo(cluster), subService.InvalidateAll), cfg.PathCfg, cfg.SvcCfg, cfg.DataCfg,
+	)
+
+	budgetsRepo := repo.NewBudgetsRepo(cluster)
+	budgetHandler := apiHTTP.NewBudgetHandler(
+		service.NewBudgetService(budgetsRepo, cfg.BudgetsCfg), cfg.PathCfg, cfg.SvcCfg, cfg.DataCfg,
+	)
+
+	calendarService := service.NewCalendarService(repo.NewCalendarRepo(cluster), cfg.CalendarCfg)
+	calendarHandler := apiHTTP.NewCalendarHandler(calendarService, cfg.PathCfg, cfg.SvcCfg)
+
+	userDataHandler := apiHTTP.NewUserDataHandler(
+		service.NewUserDataService(repo.NewUserDataRepo(cluster), subService.InvalidateUser), cfg.PathCfg, cfg.SvcCfg,
+	)
+
+	jobHandler := apiHTTP.NewJobHandler(service.NewJobService(repo.NewJobsRepo(cluster)), cfg.PathCfg, cfg.SvcCfg)
+
+	cacheHandler := apiHTTP.NewCacheHandler(subService, cfg.PathCfg)
+
+	limiter := newRateLimiter(cfg, cluster.Primary())
+
+	notifier, err := notify.NewNotifier(cfg.ReminderCfg.Notifier)
+	if err != nil {
+		log.Fatalf("[ERROR] Failed to create notifier: %s", err.Error())
+	}
+
 	log.Printf("[INFO] All services were created successfully")
 
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+
+	if cfg.PurgeCfg.Enabled {
+		purger := service.NewPurger(subsRepo, cfg.PurgeCfg)
+		go worker.RunPeriodically(ctx, "purge", cfg.PurgeCfg.Interval, purger.Purge)
+	}
+
+	if cfg.ReminderCfg.Enabled {
+		reminder := service.NewReminder(
+			repo.NewRemindersRepo(cluster), repo.NewNotificationsRepo(cluster), notifier, cfg.ReminderCfg,
+		)
+		go worker.RunPeriodically(ctx, "reminders", cfg.ReminderCfg.Interval, reminder.Remind)
+	}
+
+	if cfg.WebhooksCfg.Enabled {
+		dispatcher := service.NewWebhookDispatcher(repo.NewOutboxRepo(cluster), cfg.WebhooksCfg)
+		go worker.RunPeriodically(ctx, "webhooks", cfg.WebhooksCfg.Interval, dispatcher.Dispatch)
+	}
+
+	if cfg.BudgetsCfg.Enabled {
+		checker := service.NewBudgetChecker(
+			budgetsRepo, repo.NewSubEventsRepo(cluster, cfg.StreamCfg.ReconnectBackoff), notifier, cfg.BudgetsCfg,
+		)
+		go checker.Run(ctx)
+		go worker.RunPeriodically(ctx, "budgets", cfg.BudgetsCfg.Interval, checker.Check)
+	}
+
+	if cfg.JobsCfg.Enabled {
+		runner := service.NewJobRunner(repo.NewJobQueueRepo(cluster), cfg.JobsCfg)
+		runner.Register(domain.JobDataExport, service.NewExporter(repo.NewExportsRepo(cluster)).Export)
+		go runner.Run(ctx)
+	}
+
+	// Streams are closed once ctx is done, so that they do not delay the server's shutdown.
+	go streamService.Run(ctx)
+
+	if cfg.GRPCCfg.Enabled {
+		grpcOpts, optsErr := newGRPCOptions(cfg, limiter)
+		if optsErr != nil {
+			log.Fatalf("[ERROR] Failed to create gRPC server: %s", optsErr.Error())
+		}
+
+		s := apiGRPC.NewServer(
+			apiGRPC.NewSubServer(subService, cfg.SvcCfg, cfg.DataCfg), cfg.AuthCfg, cfg.TenantCfg, grpcOpts...,
+		)
+
+		go func() {
+			log.Printf("[INFO] Starting gRPC server at %s...", cfg.GRPCCfg.Address)
+
+			if serveErr := grpcServer.CreateServer(ctx, s, cfg.GRPCCfg); serveErr != nil {
+				log.Printf("[ERROR] Failed to start gRPC server: %s", serveErr.Error())
+				stop()
+			}
+		}()
+	}
+
 	r := chi.NewRouter()
 	handlers.RouteHandlers(r, cfg.PathCfg.API,
 		handlers.WithLogger(),
 		handlers.WithRecovery(),
+		handlers.WithRequestID(),
 		handlers.WithSwagger(),
 		handlers.WithHealthHandler(),
-		subHandler.WithSubHandlers(),
+		handlers.WithGroup(
+			handlers.WithAuth(cfg.AuthCfg),
+			apiHTTP.WithTenant(cfg.TenantCfg),
+			handlers.WithRateLimiter(limiter),
+			handlers.WithReadYourWrites(cfg.ReadYourWritesCfg, apiHTTP.RateLimitGroup(cfg.PathCfg), postgres.WithPrimary),
+			apiHTTP.WithAuditMeta(),
+			subHandler.WithSubHandlers(),
+			streamHandler.WithStreamHandlers(),
+			graphqlHandler.WithGraphQLHandlers(),
+			auditHandler.WithAuditHandlers(),
+			webhookHandler.WithWebhookHandlers(),
+			budgetHandler.WithBudgetHandlers(),
+			calendarHandler.WithCalendarTokenHandlers(),
+			userDataHandler.WithUserDataHandlers(),
+			jobHandler.WithJobHandlers(),
+			cacheHandler.WithCacheHandlers(),
+		),
+		handlers.WithGroup(
+			handlers.WithAuth(cfg.AuthCfg),
+			handlers.WithRateLimiter(limiter),
+			handlers.WithReadYourWrites(cfg.ReadYourWritesCfg, apiHTTP.RateLimitGroup(cfg.PathCfg), postgres.WithPrimary),
+			catalogHandler.WithCatalogHandlers(),
+		),
+		handlers.WithGroup(
+			handlers.WithRateLimiter(limiter),
+			calendarHandler.WithCalendarFeedHandlers(),
+		),
 	)
 
 	log.Printf("[INFO] Starting HTTP server at %s...", cfg.HTTPCfg.Address)
 
-	if err = server.CreateServer(r, cfg.HTTPCfg); err != nil {
+	if err = server.CreateServer(ctx, r, cfg.HTTPCfg); err != nil {
 		log.Fatalf("[ERROR] Failed to start server: %s", err.Error())
 	}
+
+	stop()
+	cluster.Close()
+
+	log.Printf("[INFO] Subscriptions Service stopped")
+}
+
+func newRateLimiter(cfg config.Config, pool *pgxpool.Pool) *middleware.RateLimiter {
+	if !cfg.RateLimitCfg.Enabled {
+		return nil
+	}
+
+	var store ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.RateLimitCfg.Store == middleware.StorePostgres {
+		store = ratelimit.NewPostgresStore(pool)
+	}
+
+	log.Printf("[INFO] Rate limiting is enabled (%s store)", cfg.RateLimitCfg.Store)
+
+	return middleware.NewRateLimiter(store, cfg.RateLimitCfg, apiHTTP.RateLimitGroup(cfg.PathCfg))
+}
+
+// newGRPCOptions secures the gRPC API the same way as the HTTP API: with its TLS config, rate limits
+// (sharing buckets with HTTP requests) and read-your-writes pinning to the primary.
+func newGRPCOptions(cfg config.Config, limiter *middleware.RateLimiter) ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+
+	if cfg.HTTPCfg.TLS.Enabled {
+		tlsCfg, err := server.NewTLSConfig(cfg.HTTPCfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+	}
+
+	if limiter != nil {
+		opts = append(opts, grpc.ChainUnaryInterceptor(interceptors.RateLimit(limiter, apiGRPC.MethodGroup)))
+	}
+
+	if cfg.ReadYourWritesCfg.Enabled {
+		opts = append(opts, grpc.ChainUnaryInterceptor(
+			interceptors.ReadYourWrites(cfg.ReadYourWritesCfg, apiGRPC.MethodGroup, postgres.WithPrimary),
+		))
+	}
+
+	return opts, nil
 }
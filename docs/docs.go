@@ -15,28 +15,45 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/subs": {
+        "/audit": {
             "get": {
-                "description": "Параметр user_id обязателен для получения списка подписок. Опционально поддерживается фильтрация по названию сервиса.\nТакже поддерживается keyset пагинация - опционально можно указать размер страницы (по умолчанию 20) и токен для получения следующей страницы (поле next_page_token в теле предыдущего запроса).",
+                "description": "Все параметры опциональны. Границы периода (from включительно, to не включительно) задаются в формате RFC 3339.\nПоддерживается keyset пагинация аналогично списку подписок.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "list"
+                    "audit"
                 ],
-                "summary": "Get user's subscriptions list",
+                "summary": "Query audit log (admin only)",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Period start (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period end (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "User's id",
                         "name": "user_id",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Service name",
-                        "name": "service_name",
+                        "description": "Subscription's id",
+                        "name": "sub_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Actor",
+                        "name": "actor",
                         "in": "query"
                     },
                     {
@@ -54,9 +71,58 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully got subs list",
+                        "description": "Successfully got audit entries",
                         "schema": {
-                            "$ref": "#/definitions/types.ListSubsResponse"
+                            "$ref": "#/definitions/types.ListAuditResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/budgets": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "Get user's budgets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got budgets",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Budget"
+                            }
                         }
                     },
                     "400": {
@@ -74,7 +140,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Поле end_date опционально.\nДля параметров подписки по умолчанию установлены следующие ограничения:\n- имя сервиса должно быть непустым и не длиннее 50 символов;\n- стоимость подписки должна быть положительной, но не более 100.000.",
+                "description": "Бюджет ограничивает расходы пользователя за месяц на все подписки или, если указаны service_name и (или) category,\nна подписки сервиса и (или) категории.\nПороги thresholds задаются в процентах от суммы бюджета (по умолчанию берутся из конфигурации, например 80 и 100).\nПри достижении порога расходами за текущий месяц пользователю отправляется оповещение (один раз в месяц).",
                 "consumes": [
                     "application/json"
                 ],
@@ -82,25 +148,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "subs"
+                    "budgets"
                 ],
-                "summary": "Create new subscription",
+                "summary": "Create monthly budget",
                 "parameters": [
                     {
-                        "description": "Sub details",
-                        "name": "sub",
+                        "description": "Budget details",
+                        "name": "budget",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Sub"
+                            "$ref": "#/definitions/domain.Budget"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Successfully created sub",
+                        "description": "Successfully created budget",
                         "schema": {
-                            "$ref": "#/definitions/domain.Sub"
+                            "$ref": "#/definitions/domain.Budget"
                         }
                     },
                     "400": {
@@ -118,36 +184,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/subs/summary": {
+        "/budgets/{id}": {
             "get": {
-                "description": "Параметр user_id обязателен для получения суммарной стоимости подписок. Опционально поддерживается фильтрация по названию сервиса.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "summary"
+                    "budgets"
                 ],
-                "summary": "Get summary of user's subscriptions (e.g. total price)",
+                "summary": "Get budget by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User's id",
-                        "name": "user_id",
-                        "in": "query",
+                        "description": "Budget's id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Service name",
-                        "name": "service_name",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully got summary",
+                        "description": "Successfully got budget",
                         "schema": {
-                            "$ref": "#/definitions/domain.Summary"
+                            "$ref": "#/definitions/domain.Budget"
                         }
                     },
                     "400": {
@@ -156,6 +215,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal error",
                         "schema": {
@@ -163,31 +228,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/subs/{id}": {
-            "get": {
+            },
+            "put": {
+                "description": "Пользователь бюджета не изменяется.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subs"
+                    "budgets"
                 ],
-                "summary": "Get subscription by id",
+                "summary": "Update budget by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Subcription's id",
+                        "description": "Budget's id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Budget details",
+                        "name": "budget",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Budget"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully got sub",
+                        "description": "Successfully updated budget",
                         "schema": {
-                            "$ref": "#/definitions/domain.Sub"
+                            "$ref": "#/definitions/domain.Budget"
                         }
                     },
                     "400": {
@@ -210,41 +286,188 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Требования к телу запроса такие же, как и у post запроса на создание подписки.",
-                "consumes": [
+            "delete": {
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "Delete budget by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Budget's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted budget",
+                        "schema": {
+                            "$ref": "#/definitions/types.DeleteBudgetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/budgets/{id}/status": {
+            "get": {
+                "description": "Расходы за текущий месяц считаются так же, как суммарная стоимость за период: по цене, действующей в этом месяце,\nс учетом пробного периода и приостановок. utilization - процент использования бюджета, remaining - остаток\n(отрицательный при превышении), reached_thresholds - достигнутые пороги.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subs"
+                    "budgets"
                 ],
-                "summary": "Update subscription's data by id",
+                "summary": "Get budget's utilisation at the current month",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sub's id",
+                        "description": "Budget's id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got budget's status",
+                        "schema": {
+                            "$ref": "#/definitions/domain.BudgetStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/cache/stats": {
+            "get": {
+                "description": "Статистика кэша списков и сводок подписок этой реплики сервиса с момента ее запуска:\nчисло результатов в кэше, попадания, промахи и вытеснения. Доступно только администраторам.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cache"
+                ],
+                "summary": "Get stats of the cache of lists and summaries",
+                "responses": {
+                    "200": {
+                        "description": "Successfully got stats",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CacheStats"
+                        }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "description": "Запросы: sub(id), subs(userId, filter, first, after) - постраничный список (connection) с keyset пагинацией,\nsummary(userId, serviceName, from, to). Мутации: createSub, updateSub, deleteSub.\nНесколько сводок (например, по сервисам или по месяцам) можно получить за один запрос с помощью алиасов.\nГлубина и сложность запроса ограничены (graphql.max_depth, graphql.max_complexity), поля списков\nучитываются столько раз, сколько элементов они могут вернуть. Схема доступна через интроспекцию.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "GraphQL endpoint",
+                "parameters": [
                     {
-                        "description": "Sub details",
-                        "name": "sub",
+                        "description": "GraphQL request: query, operationName, variables",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Sub"
+                            "type": "object"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated sub",
+                        "description": "Result: data and errors",
                         "schema": {
-                            "$ref": "#/definitions/domain.Sub"
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query or limits exceeded",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "description": "Статус фоновой задачи: pending (ожидает запуска, в т.ч. повторного после ошибки), running,\ncompleted (результат в поле result), failed (после исчерпания попыток, причина в поле error)\nили cancelled. Для выполняющихся задач указывается прогресс в процентах.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Get background job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got job",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Job"
                         }
                     },
                     "400": {
@@ -268,17 +491,18 @@ const docTemplate = `{
                 }
             },
             "delete": {
+                "description": "Отменяет ожидающую или выполняющуюся задачу. Выполнение задачи останавливается\nв течение трети срока аренды (jobs.lease). Завершенные задачи отменить нельзя.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subs"
+                    "jobs"
                 ],
-                "summary": "Delete subscription by id",
+                "summary": "Cancel background job",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sub's id",
+                        "description": "Job's id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -286,9 +510,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully deleted sub",
+                        "description": "Successfully cancelled job",
                         "schema": {
-                            "$ref": "#/definitions/domain.Sub"
+                            "$ref": "#/definitions/domain.Job"
                         }
                     },
                     "400": {
@@ -303,6 +527,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "409": {
+                        "description": "Job is already finished",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal error",
                         "schema": {
@@ -311,24 +541,2738 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Sub": {
-            "type": "object",
-            "properties": {
-                "end_date": {
+        },
+        "/services": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "Get catalog services (operators only)",
+                "responses": {
+                    "200": {
+                        "description": "Successfully got services",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Service"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Подписки связываются с сервисом каталога при создании и изменении: название сервиса подписки сравнивается\nс каноническим названием и псевдонимами без учета регистра и пробелов по краям, после чего заменяется\nканоническим названием. Фильтрация и суммарная стоимость по названию сервиса учитывают все его псевдонимы.\nПсевдонимы не могут повторяться у разных сервисов. Валюта по умолчанию - RUB.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "Add service to the catalog (operators only)",
+                "parameters": [
+                    {
+                        "description": "Service details",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Service"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully added service",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Service"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Name or alias is already used",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "Get catalog service by id (operators only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got service",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Service"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Псевдонимы заменяются переданными. Уже связанные подписки сохраняют связь с сервисом,\nнесвязанные подписки связываются при следующем изменении.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "Update catalog service by id (operators only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Service details",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Service"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated service",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Service"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Name or alias is already used",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Подписки сервиса отвязываются от каталога и сохраняют его каноническое название.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "Delete catalog service by id (operators only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted service",
+                        "schema": {
+                            "$ref": "#/definitions/types.DeleteServiceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs": {
+            "get": {
+                "description": "Параметр user_id обязателен для получения списка подписок. Опционально поддерживается фильтрация по названию сервиса.\nТакже поддерживается keyset пагинация - опционально можно указать размер страницы (по умолчанию 20)\nи токен для получения следующей страницы (поле next_page_token в теле предыдущего запроса).\nПараметр trial_ends_within позволяет выбрать подписки, пробный период которых заканчивается в течение указанного числа дней.\nФильтр по тегам (через запятую) выбирает подписки с любым из тегов или, если tags_match=all, со всеми тегами.\nОтвет содержит ETag, при совпадении If-None-Match с текущим ETag возвращается 304 без тела.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "list"
+                ],
+                "summary": "Get user's subscriptions list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service name",
+                        "name": "service_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page token (for keyset pagination)",
+                        "name": "page_token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days until the end of trial",
+                        "name": "trial_ends_within",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Match any (default) or all of the tags",
+                        "name": "tags_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include deleted subscriptions (admins only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got subs list",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListSubsResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Поля end_date, trial_months, promo_price, category и tags опциональны.\nЕсли сервис есть в каталоге, а категория не указана, подписка получает категорию сервиса из каталога.\nПервые trial_months месяцев подписки - пробный период, который оплачивается по цене promo_price (по умолчанию бесплатно).\nДля параметров подписки по умолчанию установлены следующие ограничения:\n- имя сервиса должно быть непустым и не длиннее 50 символов;\n- стоимость подписки (в т.ч. промо-цена) должна быть положительной, но не более 100.000;\n- пробный период не длиннее 12 месяцев, промо-цена указывается только вместе с пробным периодом;\n- категория и каждый тег не длиннее 50 символов, не более 20 тегов (повторяющиеся теги удаляются).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subs"
+                ],
+                "summary": "Create new subscription",
+                "parameters": [
+                    {
+                        "description": "Sub details",
+                        "name": "sub",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Sub"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created sub",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Sub"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Overlapping subscription exists (strict mode)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/duplicates": {
+            "get": {
+                "description": "Пары подписок пользователя на один и тот же сервис (по каталогу или по названию без учета регистра\nи пробелов по краям), периоды которых [start_date, end_date) пересекаются. wasted_spend - лишние расходы\nза месяцы пересечения до текущего включительно: за каждый месяц учитывается меньшее из двух списаний\n(с учетом истории цен, пробных периодов и приостановок). В строгом режиме (duplicates.strict)\nсоздание пересекающейся подписки отклоняется с кодом 409.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summary"
+                ],
+                "summary": "Find user's duplicate subscriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully found duplicates",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DuplicatesReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/forecast": {
+            "get": {
+                "description": "Прогноз расходов пользователя на months месяцев, начиная со следующего (текущий месяц уже оплачен).\nДля каждого месяца возвращается общая сумма и суммы по сервисам. Учитываются даты окончания подписок,\nзапланированные изменения цен, пробные периоды и приостановки (бессрочная приостановка действует\nдо конца прогноза). Если months не указан, используется значение по умолчанию из конфигурации.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summary"
+                ],
+                "summary": "Get forecast of user's spend for the next months",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of months",
+                        "name": "months",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got forecast",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Forecast"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/settlement": {
+            "get": {
+                "description": "Для каждого месяца периода (from и to включительно, по умолчанию - текущий месяц) возвращаются долги\nмежду пользователем и владельцами или участниками общих с ним подписок. Доли считаются так же,\nкак в суммарной стоимости: по цене, действовавшей в месяце, с учетом пробного периода и приостановок.\nВзаимные долги каждой пары пользователей за месяц взаимозачитываются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summary"
+                ],
+                "summary": "Get settlement of user's shared subscriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period start (MM-YYYY)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period end (MM-YYYY)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got settlement",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Settlement"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/stream": {
+            "get": {
+                "description": "Поток событий sub.created, sub.updated, sub.deleted, sub.restored по подпискам пользователя.\nДанные события: id (номер изменения в журнале), event, user_id, sub_id и data (подписка после изменения).\nПри переподключении поток продолжается после Last-Event-ID (заголовок или параметр last_event_id).\nЕсли часть событий уже недоступна, сначала отправляется событие reset - клиенту нужно перечитать подписки.\nПериодически отправляются комментарии (heartbeat).",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "subs"
+                ],
+                "summary": "Stream changes of user's subscriptions (Server-Sent Events)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of the last received event",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of the last received event",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of events",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/summary": {
+            "get": {
+                "description": "Параметр user_id обязателен для получения суммарной стоимости подписок. Опционально поддерживается фильтрация по названию сервиса.\nБез периода возвращается сумма текущих цен подписок. Если указан период (from и to включительно, в формате MM-YYYY),\nза каждый месяц периода, в который подписка действует, учитывается цена, действовавшая в этом месяце.\nМесяцы пробного периода учитываются по промо-цене (бесплатный пробный период - по нулевой цене).\nФильтрация по категории и тегам такая же, как у списка подписок. Если указан group_by, дополнительно\nвозвращаются суммы по сервисам, категориям или тегам (подписки без категории или тегов - с пустым ключом,\nподписка с несколькими тегами учитывается в каждой из групп).\nОбщие подписки, владельцем или участником которых является пользователь, учитываются по его доле.\nОтвет содержит ETag, при совпадении If-None-Match с текущим ETag возвращается 304 без тела.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summary"
+                ],
+                "summary": "Get summary of user's subscriptions (e.g. total price)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service name",
+                        "name": "service_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period start (MM-YYYY)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period end (MM-YYYY)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Match any (default) or all of the tags",
+                        "name": "tags_match",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "service",
+                            "category",
+                            "tag"
+                        ],
+                        "type": "string",
+                        "description": "Dimension of groups",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include deleted subscriptions (admins only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got summary",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Summary"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/{id}": {
+            "get": {
+                "description": "Удаленные подписки доступны только администраторам с параметром include_deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subs"
+                ],
+                "summary": "Get subscription by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subcription's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include deleted subscription (admins only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got sub",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Sub"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Требования к телу запроса такие же, как и у post запроса на создание подписки.\nПо умолчанию новая цена действует на весь период подписки (история цен перезаписывается).\nЕсли указан параметр price_effective_from, новая цена записывается как изменение цены, действующее с указанного месяца.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subs"
+                ],
+                "summary": "Update subscription's data by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sub's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Month since which the new price is in effect (MM-YYYY)",
+                        "name": "price_effective_from",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Sub details",
+                        "name": "sub",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Sub"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated sub",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Sub"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Подписка помечается удаленной и может быть восстановлена, пока не истечет срок хранения удаленных подписок.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subs"
+                ],
+                "summary": "Delete subscription by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sub's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted sub",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Sub"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/{id}/history": {
+            "get": {
+                "description": "Возвращает все изменения подписки (создание, обновление, удаление) в хронологическом порядке.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Get subscription's change history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subcription's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got history",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/{id}/members": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "List members of shared subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sub's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got members",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Member"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/{id}/members/{user_id}": {
+            "put": {
+                "description": "Владелец подписки (user_id подписки) оплачивает ее и делит стоимость с участниками. Участник платит\nлибо фиксированную сумму amount (не более максимальной цены), либо долю по весу weight (от 1 до 100).\nКаждый месяц сначала оплачиваются фиксированные суммы (пропорционально уменьшаются, если превышают\nсписание, например, в пробный период), остаток делится по весам, вес владельца - 1.\nВладелец оплачивает остаток, в т.ч. образовавшийся при округлении долей. Владелец не может быть участником.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Share subscription with user or change their share",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sub's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member's user id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member's share",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Member"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully put member",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Member"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "members"
+                ],
+                "summary": "Remove member of shared subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sub's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member's user id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/{id}/pause": {
+            "post": {
+                "description": "Месяцы from и to (в формате MM-YYYY) включаются в паузу, поле to опционально:\nпауза без to длится до возобновления подписки.\nПауза должна начинаться в период подписки и не пересекаться с другими паузами.\nПриостановленные месяцы не учитываются при подсчете суммарной стоимости.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pauses"
+                ],
+                "summary": "Pause subscription's billing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sub's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pause months",
+                        "name": "pause",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Pause"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully paused sub",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Pause"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Pause overlaps with another one",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/{id}/pauses": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pauses"
+                ],
+                "summary": "Get subscription's pauses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sub's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got pauses",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Pause"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/{id}/prices": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Get subscription's price history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sub's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got price history",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.PriceChange"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Месяц effective_from (в формате MM-YYYY) должен попадать в период подписки.\nЦена в поле price подписки - последняя установленная цена.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Change subscription's price starting from the given month",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sub's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Price change",
+                        "name": "change",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PriceChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully changed price",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Sub"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/{id}/restore": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subs"
+                ],
+                "summary": "Restore deleted subscription by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sub's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully restored sub",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Sub"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subs/{id}/resume": {
+            "post": {
+                "description": "Оплата возобновляется с текущего месяца: пауза, включающая текущий месяц, сокращается или удаляется.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pauses"
+                ],
+                "summary": "Resume subscription's billing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sub's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully resumed sub",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Sub"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Sub is not paused",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}": {
+            "delete": {
+                "description": "Удаляет в одной транзакции все данные пользователя: подписки (вместе с ценами, приостановками\nи участниками), участие в чужих подписках, теги, бюджеты, токен календаря, выгрузки,\nотправленные напоминания и события вебхуков. Записи журнала изменений обезличиваются\n(удаляются снимки подписок), удаление фиксируется записью с операцией erase.\nВебхуки и поток изменений об удалении не оповещаются. Доступно только администраторам.",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Erase all user's data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/calendar.ics": {
+            "get": {
+                "description": "Календарь в формате iCalendar (RFC 5545) для подписки в календарных приложениях.\nДля каждой действующей подписки создается повторяющееся ежемесячное событие списания\n(RRULE с UNTIL по дате окончания, месяцы приостановки исключаются через EXDATE)\nи событие окончания подписки. В описании событий указывается стоимость.\nДоступ предоставляется по токену календаря (параметр token) вместо API-ключа.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Get user's subscriptions calendar (iCalendar)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calendar feed token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got calendar",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/calendar/token": {
+            "post": {
+                "description": "Создает секретный токен для подписки на календарь пользователя (iCalendar) в календарных приложениях,\nкоторые не могут передавать заголовки аутентификации. Предыдущий токен пользователя отзывается.\nТокен возвращается только в ответе на этот запрос, url содержит путь календаря с токеном.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Create user's calendar feed token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created token",
+                        "schema": {
+                            "$ref": "#/definitions/types.FeedTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Revoke user's calendar feed token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/data-export": {
+            "get": {
+                "description": "ZIP-архив последней завершенной выгрузки данных пользователя. Каждый набор данных (subs, history,\nbudgets, notifications) представлен в форматах JSON и CSV.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Download user's data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got archive",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Запускает выгрузку всех данных пользователя: подписок (включая удаленные), истории их изменений,\nбюджетов и отправленных уведомлений. Архив формируется фоновой задачей (job_id), статус выгрузки\nдоступен по адресу из заголовка Location. Если у пользователя уже есть незавершенная выгрузка,\nвозвращается она.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Request export of all user's data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Successfully requested export",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DataExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/data-export/{export_id}": {
+            "get": {
+                "description": "Статус выгрузки совпадает со статусом ее задачи: pending, running, completed, failed\n(в поле error указывается причина) или cancelled. Выгрузки удаляются вместе с задачами\nчерез jobs.retention после завершения.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get status of user's data export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export's id",
+                        "name": "export_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got export",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DataExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/tags": {
+            "get": {
+                "description": "Теги пользователя с количеством подписок (без удаленных), отсортированные по названию.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "List user's tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got tags",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Tag"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/tags/merge": {
+            "post": {
+                "description": "Подписки пользователя с любым из тегов sources получают тег target (создается при необходимости),\nпосле чего теги sources удаляются. Изменения подписок записываются в журнал аудита.\nЕсли ни одного из тегов sources не существует, возвращается 404.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Merge user's tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merged tags and target tag",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.MergeTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully merged tags",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/tags/rename": {
+            "post": {
+                "description": "Тег переименовывается во всех подписках пользователя, изменения подписок записываются в журнал аудита.\nЕсли тег с новым названием уже существует, возвращается 409 - такие теги необходимо объединить.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Rename user's tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Current and new names",
+                        "name": "rename",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RenameTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully renamed tag",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Tag already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhooks list (admin only)",
+                "responses": {
+                    "200": {
+                        "description": "Successfully got webhooks",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Webhook"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Вебхук получает POST запросы с событиями подписок: sub.created, sub.updated, sub.deleted, sub.restored, sub.ended.\nЕсли список events пуст, вебхук получает все события.\nТело запроса подписывается HMAC-SHA256 с секретом вебхука: заголовок X-Webhook-Signature содержит\nsha256=hex(HMAC(secret, timestamp + \".\" + body)), где timestamp - значение заголовка X-Webhook-Timestamp.\nСекрет не возвращается в ответах.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Register webhook (admin only)",
+                "parameters": [
+                    {
+                        "description": "Webhook details",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Webhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully registered webhook",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook by id (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got webhook",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Если секрет не указан, сохраняется прежний.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update webhook by id (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook details",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Webhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated webhook",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Недоставленные события вебхука удаляются вместе с ним.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete webhook by id (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted webhook",
+                        "schema": {
+                            "$ref": "#/definitions/types.DeleteWebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Опционально поддерживается фильтрация по статусу доставки (pending, delivered, dead)\nи keyset пагинация аналогично списку подписок.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook's deliveries (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page token (for keyset pagination)",
+                        "name": "page_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully got deliveries",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListDeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "description": "Доставка (в т.ч. dead) ставится в очередь на немедленную отправку с обнуленным счетчиком попыток.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Redeliver webhook's delivery (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery's id",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully scheduled redelivery",
+                        "schema": {
+                            "$ref": "#/definitions/domain.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Object not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "operation": {
+                    "$ref": "#/definitions/domain.AuditOperation"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "sub_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AuditOperation": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete",
+                "restore",
+                "purge",
+                "pause",
+                "resume",
+                "erase"
+            ],
+            "x-enum-varnames": [
+                "AuditCreate",
+                "AuditUpdate",
+                "AuditDelete",
+                "AuditRestore",
+                "AuditPurge",
+                "AuditPause",
+                "AuditResume",
+                "AuditErase"
+            ]
+        },
+        "domain.Budget": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "service_name": {
+                    "type": "string"
+                },
+                "thresholds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BudgetStatus": {
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "$ref": "#/definitions/domain.Budget"
+                },
+                "month": {
+                    "type": "string"
+                },
+                "reached_thresholds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "remaining": {
+                    "description": "Remaining is negative if the budget is exceeded.",
+                    "type": "integer"
+                },
+                "spend": {
+                    "type": "integer"
+                },
+                "utilization": {
+                    "description": "Utilization is the percent of the amount spent.",
+                    "type": "number"
+                }
+            }
+        },
+        "domain.CacheStats": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "evictions": {
+                    "type": "integer"
+                },
+                "hit_ratio": {
+                    "type": "number"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.DataExport": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.JobStatus"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Debt": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "creditor": {
+                    "type": "string"
+                },
+                "debtor": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "delivered",
+                "dead"
+            ],
+            "x-enum-varnames": [
+                "DeliveryPending",
+                "DeliveryDelivered",
+                "DeliveryDead"
+            ]
+        },
+        "domain.Duplicate": {
+            "type": "object",
+            "properties": {
+                "overlap_from": {
+                    "type": "string"
+                },
+                "overlap_to": {
+                    "type": "string"
+                },
+                "service_name": {
+                    "type": "string"
+                },
+                "subs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Sub"
+                    }
+                },
+                "wasted_spend": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.DuplicatesReport": {
+            "type": "object",
+            "properties": {
+                "duplicates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Duplicate"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "wasted_spend": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Forecast": {
+            "type": "object",
+            "properties": {
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MonthForecast"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.GroupBy": {
+            "type": "string",
+            "enum": [
+                "service",
+                "category",
+                "tag"
+            ],
+            "x-enum-varnames": [
+                "GroupByService",
+                "GroupByCategory",
+                "GroupByTag"
+            ]
+        },
+        "domain.Job": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "result": {
+                    "type": "object"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.JobStatus"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.JobStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "JobPending",
+                "JobRunning",
+                "JobCompleted",
+                "JobFailed",
+                "JobCancelled"
+            ]
+        },
+        "domain.Member": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.MonthForecast": {
+            "type": "object",
+            "properties": {
+                "month": {
+                    "type": "string"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ServiceSpend"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.MonthSettlement": {
+            "type": "object",
+            "properties": {
+                "debts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Debt"
+                    }
+                },
+                "month": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Pause": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.PriceChange": {
+            "type": "object",
+            "properties": {
+                "effective_from": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Service": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "category": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "Currency is ISO 4217 code of subscriptions' prices.",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ServiceSpend": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "service_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Settlement": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MonthSettlement"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Sub": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "Category defaults to the category of the catalog entry, tags are user-defined.",
+                    "type": "string"
+                },
+                "end_date": {
                     "type": "string"
                 },
                 "price": {
                     "type": "integer"
                 },
+                "promo_price": {
+                    "type": "integer"
+                },
                 "service_name": {
                     "type": "string"
                 },
                 "start_date": {
                     "type": "string"
                 },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "trial_months": {
+                    "description": "First TrialMonths months are charged by PromoPrice, which is zero for free trials.",
+                    "type": "integer"
+                },
                 "user_id": {
                     "type": "string"
                 }
@@ -337,9 +3281,33 @@ const docTemplate = `{
         "domain.Summary": {
             "type": "object",
             "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "group_by": {
+                    "$ref": "#/definitions/domain.GroupBy"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SummaryGroup"
+                    }
+                },
                 "service_name": {
                     "type": "string"
                 },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
                 "total_price": {
                     "type": "integer"
                 },
@@ -348,6 +3316,159 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.SummaryGroup": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "total_price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Tag": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "subs": {
+                    "description": "Subs is the number of subscriptions labeled with the tag.",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Webhook": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WebhookEvent"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/domain.WebhookEvent"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.DeliveryStatus"
+                },
+                "webhook_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.WebhookEvent": {
+            "type": "string",
+            "enum": [
+                "sub.created",
+                "sub.updated",
+                "sub.deleted",
+                "sub.restored",
+                "sub.ended"
+            ],
+            "x-enum-varnames": [
+                "EventSubCreated",
+                "EventSubUpdated",
+                "EventSubDeleted",
+                "EventSubRestored",
+                "EventSubEnded"
+            ]
+        },
+        "types.DeleteBudgetResponse": {
+            "type": "object",
+            "properties": {
+                "deleted_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.DeleteServiceResponse": {
+            "type": "object",
+            "properties": {
+                "deleted_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.DeleteWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "deleted_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.FeedTokenResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL of the feed relative to the host, it already contains the token.",
+                    "type": "string"
+                }
+            }
+        },
+        "types.ListAuditResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AuditEntry"
+                    }
+                },
+                "next_page_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ListDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WebhookDelivery"
+                    }
+                },
+                "next_page_token": {
+                    "type": "string"
+                }
+            }
+        },
         "types.ListSubsResponse": {
             "type": "object",
             "properties": {
@@ -361,6 +3482,31 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "types.MergeTagsRequest": {
+            "type": "object",
+            "properties": {
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.RenameTagRequest": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
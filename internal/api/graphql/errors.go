@@ -0,0 +1,63 @@
+package graphql
+
+import (
+	"log"
+	"net/http"
+	"subs-service/internal/api/http/response"
+	pkgErrors "subs-service/pkg/errors"
+)
+
+const (
+	codeBadUserInput = "BAD_USER_INPUT"
+)
+
+var (
+	errorCodes = map[int]string{
+		http.StatusBadRequest:          codeBadUserInput,
+		http.StatusForbidden:           "FORBIDDEN",
+		http.StatusNotFound:            "NOT_FOUND",
+		http.StatusConflict:            "CONFLICT",
+		http.StatusInternalServerError: "INTERNAL_SERVER_ERROR",
+	}
+)
+
+// Error is reported in the errors of the response with its code in extensions.
+type Error struct {
+	message string
+	code    string
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+// requestError reports invalid arguments the same way as the HTTP API does.
+func requestError(err error, debugMode bool) error {
+	log.Print("[ERROR] ", err.Error())
+
+	if !debugMode {
+		err = pkgErrors.UnwrapAll(err)
+	}
+
+	return &Error{message: err.Error(), code: codeBadUserInput}
+}
+
+// serviceError maps domain errors to the codes of their HTTP statuses, unknown errors are internal ones.
+func serviceError(err error, debugMode bool) error {
+	log.Print("[ERROR] ", err.Error())
+
+	if !debugMode {
+		err = pkgErrors.UnwrapAll(err)
+	}
+
+	status, ok := response.ErrorCode(err)
+	if !ok && !debugMode {
+		err = response.ErrInternal
+	}
+
+	return &Error{message: err.Error(), code: errorCodes[status]}
+}
@@ -0,0 +1,112 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"subs-service/internal/api/http/response"
+	"subs-service/internal/config"
+	"subs-service/internal/usecases"
+	"subs-service/pkg/http/handlers"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+type Handler struct {
+	schema     graphql.Schema
+	pathCfg    config.PathConfig
+	svcCfg     config.ServiceConfig
+	dataCfg    config.DataConfig
+	graphqlCfg config.GraphQLConfig
+}
+
+func NewHandler(
+	subSvc usecases.SubService,
+	pathCfg config.PathConfig,
+	svcCfg config.ServiceConfig,
+	dataCfg config.DataConfig,
+	graphqlCfg config.GraphQLConfig,
+) (*Handler, error) {
+	const op = "graphql.NewHandler"
+
+	schema, err := NewSchema(NewResolver(subSvc, svcCfg, dataCfg))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Handler{
+		schema:     schema,
+		pathCfg:    pathCfg,
+		svcCfg:     svcCfg,
+		dataCfg:    dataCfg,
+		graphqlCfg: graphqlCfg,
+	}, nil
+}
+
+func (h *Handler) WithGraphQLHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Post(h.pathCfg.GraphQL, h.graphqlHandler)
+	}
+}
+
+type graphqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// @Summary 	GraphQL endpoint
+// @Description Запросы: sub(id), subs(userId, filter, first, after) - постраничный список (connection) с keyset пагинацией,
+// @Description summary(userId, serviceName, from, to). Мутации: createSub, updateSub, deleteSub.
+// @Description Несколько сводок (например, по сервисам или по месяцам) можно получить за один запрос с помощью алиасов.
+// @Description Глубина и сложность запроса ограничены (graphql.max_depth, graphql.max_complexity), поля списков
+// @Description учитываются столько раз, сколько элементов они могут вернуть. Схема доступна через интроспекцию.
+// @Tags 		graphql
+// @Accept 		json
+// @Produce 	json
+// @Param 		request 	body 	object true "GraphQL request: query, operationName, variables"
+// @Success 	200 {object} 		object "Result: data and errors"
+// @Failure 	400 {object} 		object "Invalid query or limits exceeded"
+// @Router		/graphql			[post]
+func (h *Handler) graphqlHandler(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		response.WriteResponse(w, &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, http.StatusBadRequest)
+		return
+	}
+
+	if res := graphql.ValidateDocument(&h.schema, doc, nil); !res.IsValid {
+		response.WriteResponse(w, &graphql.Result{Errors: res.Errors}, http.StatusBadRequest)
+		return
+	}
+
+	if err = checkLimits(doc, req.OperationName, req.Variables, h.graphqlCfg, h.dataCfg.ForTenant(r.Context()).DefaultPageSize); err != nil {
+		response.WriteResponse(w, &graphql.Result{Errors: []gqlerrors.FormattedError{{
+			Message:    err.Error(),
+			Locations:  []location.SourceLocation{},
+			Extensions: (&Error{code: codeBadUserInput}).Extensions(),
+		}}}, http.StatusBadRequest)
+
+		return
+	}
+
+	res := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       r.Context(),
+	})
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
@@ -0,0 +1,129 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"subs-service/internal/config"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+var (
+	ErrQueryTooDeep    = errors.New("query is too deep")
+	ErrQueryTooComplex = errors.New("query is too complex")
+)
+
+// queryCost measures depth and complexity of a validated query before it is executed.
+// Every field costs 1, selections of connection fields are counted as many times as many items
+// they return at most (the first argument or the default page size).
+// Introspection fields are bounded by the schema and are not counted.
+type queryCost struct {
+	fragments       map[string]*ast.FragmentDefinition
+	variables       map[string]any
+	defaultPageSize int
+}
+
+func checkLimits(
+	doc *ast.Document, operationName string, variables map[string]any, cfg config.GraphQLConfig, defaultPageSize int,
+) error {
+	c := queryCost{
+		fragments:       make(map[string]*ast.FragmentDefinition),
+		variables:       variables,
+		defaultPageSize: defaultPageSize,
+	}
+
+	var operations []*ast.OperationDefinition
+
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			c.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if len(operationName) == 0 || (def.Name != nil && def.Name.Value == operationName) {
+				operations = append(operations, def)
+			}
+		}
+	}
+
+	for _, operation := range operations {
+		depth, complexity := c.selectionSet(operation.SelectionSet)
+
+		if depth > cfg.MaxDepth {
+			return fmt.Errorf("%w: depth %d exceeds %d", ErrQueryTooDeep, depth, cfg.MaxDepth)
+		}
+
+		if complexity > cfg.MaxComplexity {
+			return fmt.Errorf("%w: complexity %d exceeds %d", ErrQueryTooComplex, complexity, cfg.MaxComplexity)
+		}
+	}
+
+	return nil
+}
+
+// selectionSet returns depth and complexity of the selections. Fragment cycles are rejected by validation.
+func (c *queryCost) selectionSet(set *ast.SelectionSet) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, cost int
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			d, cost = c.field(selection)
+		case *ast.InlineFragment:
+			d, cost = c.selectionSet(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if fragment, ok := c.fragments[selection.Name.Value]; ok {
+				d, cost = c.selectionSet(fragment.SelectionSet)
+			}
+		}
+
+		depth = max(depth, d)
+		complexity += cost
+	}
+
+	return depth, complexity
+}
+
+func (c *queryCost) field(field *ast.Field) (depth, complexity int) {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, 0
+	}
+
+	depth, complexity = c.selectionSet(field.SelectionSet)
+
+	return depth + 1, 1 + complexity*c.multiplier(field)
+}
+
+// multiplier is the number of items a connection field returns at most.
+func (c *queryCost) multiplier(field *ast.Field) int {
+	if !connectionFields[field.Name.Value] {
+		return 1
+	}
+
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "first" {
+			continue
+		}
+
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			// Variables are decoded from JSON, so numbers are float64.
+			if n, ok := c.variables[value.Name.Value].(float64); ok && n > 0 {
+				return int(n)
+			}
+		}
+
+		break
+	}
+
+	return c.defaultPageSize
+}
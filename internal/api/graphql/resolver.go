@@ -0,0 +1,283 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"subs-service/internal/api/http/types"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/usecases"
+	"subs-service/pkg/http/middleware"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+var (
+	ErrBadFirst = errors.New("bad first, must be positive and not greater than max page size")
+)
+
+// Resolver resolves queries and mutations by the same service layer and validation as the HTTP API.
+type Resolver struct {
+	subSvc  usecases.SubService
+	svcCfg  config.ServiceConfig
+	dataCfg config.DataConfig
+}
+
+func NewResolver(subSvc usecases.SubService, svcCfg config.ServiceConfig, dataCfg config.DataConfig) *Resolver {
+	return &Resolver{
+		subSvc:  subSvc,
+		svcCfg:  svcCfg,
+		dataCfg: dataCfg,
+	}
+}
+
+func parseMonth(value any) (time.Time, error) {
+	month, _ := value.(string)
+	if len(month) == 0 {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(domain.TimeLayout, month)
+}
+
+func subFromInput(input map[string]any) (*domain.Sub, error) {
+	const op = "subFromInput"
+
+	sub := domain.Sub{}
+	sub.ServiceName, _ = input["serviceName"].(string)
+
+	price, _ := input["price"].(int)
+	sub.Price = int64(price)
+
+	sub.TrialMonths, _ = input["trialMonths"].(int)
+
+	promoPrice, _ := input["promoPrice"].(int)
+	sub.PromoPrice = int64(promoPrice)
+
+	sub.Category, _ = input["category"].(string)
+
+	tags, _ := input["tags"].([]any)
+	for _, tag := range tags {
+		if name, ok := tag.(string); ok {
+			sub.Tags = append(sub.Tags, name)
+		}
+	}
+
+	var err error
+
+	userID, _ := input["userId"].(string)
+	if sub.UserID, err = uuid.Parse(userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	startDate, _ := input["startDate"].(string)
+	if sub.StartDate, err = time.Parse(domain.TimeLayout, startDate); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if sub.EndDate, err = parseMonth(input["endDate"]); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &sub, nil
+}
+
+func optionalString(value string) any {
+	if len(value) == 0 {
+		return nil
+	}
+
+	return value
+}
+
+func parseID(value any) (uuid.UUID, error) {
+	id, _ := value.(string)
+	return uuid.Parse(id)
+}
+
+// includeDeleted shows deleted subscriptions to admins only.
+func includeDeleted(p graphql.ResolveParams, flag any) bool {
+	include, _ := flag.(bool)
+	return include && middleware.IsAdmin(p.Context)
+}
+
+func (r *Resolver) sub(p graphql.ResolveParams) (any, error) {
+	const op = "Resolver.sub"
+
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), r.svcCfg.DebugMode)
+	}
+
+	sub, err := r.subSvc.GetSub(p.Context, id, domain.GetOpts{IncludeDeleted: includeDeleted(p, p.Args["includeDeleted"])})
+	if err != nil {
+		return nil, serviceError(err, r.svcCfg.DebugMode)
+	}
+
+	return sub, nil
+}
+
+// subs requests one subscription more than the page size to find out whether the next page exists.
+func (r *Resolver) subs(p graphql.ResolveParams) (any, error) {
+	const op = "Resolver.subs"
+
+	dataCfg := r.dataCfg.ForTenant(p.Context)
+
+	opts := domain.FilterOpts{PageSize: dataCfg.DefaultPageSize}
+
+	var err error
+
+	if opts.UserID, err = parseID(p.Args["userId"]); err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), r.svcCfg.DebugMode)
+	}
+
+	if filter, ok := p.Args["filter"].(map[string]any); ok {
+		opts.ServiceName, _ = filter["serviceName"].(string)
+		opts.TrialEndsWithin, _ = filter["trialEndsWithin"].(int)
+		opts.IncludeDeleted = includeDeleted(p, filter["includeDeleted"])
+	}
+
+	if first, ok := p.Args["first"].(int); ok {
+		if first <= 0 || !types.CheckPageSize(first, dataCfg) {
+			return nil, requestError(fmt.Errorf("%s: %w", op, ErrBadFirst), r.svcCfg.DebugMode)
+		}
+
+		opts.PageSize = first
+	}
+
+	if after, ok := p.Args["after"].(string); ok && len(after) != 0 {
+		if opts.PageToken, err = uuid.Parse(after); err != nil {
+			return nil, requestError(fmt.Errorf("%s: %w", op, err), r.svcCfg.DebugMode)
+		}
+	}
+
+	pageSize := opts.PageSize
+	opts.PageSize++
+
+	subs, err := r.subSvc.ListSubs(p.Context, opts)
+	if err != nil {
+		return nil, serviceError(err, r.svcCfg.DebugMode)
+	}
+
+	hasNextPage := len(subs) > pageSize
+	subs = subs[:min(len(subs), pageSize)]
+
+	var endCursor any
+	if len(subs) != 0 {
+		endCursor = subs[len(subs)-1].ID.String()
+	}
+
+	return map[string]any{
+		"edges": subs,
+		"pageInfo": map[string]any{
+			"hasNextPage": hasNextPage,
+			"endCursor":   endCursor,
+		},
+	}, nil
+}
+
+func (r *Resolver) summary(p graphql.ResolveParams) (any, error) {
+	const op = "Resolver.summary"
+
+	var opts domain.FilterOpts
+
+	var err error
+
+	if opts.UserID, err = parseID(p.Args["userId"]); err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), r.svcCfg.DebugMode)
+	}
+
+	opts.ServiceName, _ = p.Args["serviceName"].(string)
+
+	from, _ := p.Args["from"].(string)
+	to, _ := p.Args["to"].(string)
+
+	if opts.From, opts.To, err = types.ParsePeriod(from, to); err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), r.svcCfg.DebugMode)
+	}
+
+	sum, err := r.subSvc.GetSummary(p.Context, opts)
+	if err != nil {
+		return nil, serviceError(err, r.svcCfg.DebugMode)
+	}
+
+	return map[string]any{
+		"userId":      sum.UserID.String(),
+		"serviceName": optionalString(sum.ServiceName),
+		"from":        optionalString(sum.From),
+		"to":          optionalString(sum.To),
+		"totalPrice":  sum.TotalPrice,
+	}, nil
+}
+
+func (r *Resolver) createSub(p graphql.ResolveParams) (any, error) {
+	const op = "Resolver.createSub"
+
+	input, _ := p.Args["input"].(map[string]any)
+
+	sub, err := subFromInput(input)
+	if err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), r.svcCfg.DebugMode)
+	}
+
+	if err = types.CheckSub(sub, r.dataCfg.ForTenant(p.Context)); err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), r.svcCfg.DebugMode)
+	}
+
+	created, err := r.subSvc.PostSub(middleware.MarkWrite(p.Context), sub)
+	if err != nil {
+		return nil, serviceError(err, r.svcCfg.DebugMode)
+	}
+
+	return created, nil
+}
+
+func (r *Resolver) updateSub(p graphql.ResolveParams) (any, error) {
+	const op = "Resolver.updateSub"
+
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), r.svcCfg.DebugMode)
+	}
+
+	var opts domain.PutOpts
+	if opts.PriceEffectiveFrom, err = parseMonth(p.Args["priceEffectiveFrom"]); err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), r.svcCfg.DebugMode)
+	}
+
+	input, _ := p.Args["input"].(map[string]any)
+
+	sub, err := subFromInput(input)
+	if err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), r.svcCfg.DebugMode)
+	}
+
+	if err = types.CheckSub(sub, r.dataCfg.ForTenant(p.Context)); err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), r.svcCfg.DebugMode)
+	}
+
+	updated, err := r.subSvc.PutSub(middleware.MarkWrite(p.Context), id, sub, opts)
+	if err != nil {
+		return nil, serviceError(err, r.svcCfg.DebugMode)
+	}
+
+	return updated, nil
+}
+
+func (r *Resolver) deleteSub(p graphql.ResolveParams) (any, error) {
+	const op = "Resolver.deleteSub"
+
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), r.svcCfg.DebugMode)
+	}
+
+	deleted, err := r.subSvc.DeleteSub(middleware.MarkWrite(p.Context), id)
+	if err != nil {
+		return nil, serviceError(err, r.svcCfg.DebugMode)
+	}
+
+	return deleted.String(), nil
+}
@@ -0,0 +1,226 @@
+package graphql
+
+import (
+	"subs-service/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+var (
+	// connectionFields return pages of items, they are counted accordingly in the query complexity.
+	connectionFields = map[string]bool{
+		"subs": true,
+	}
+)
+
+// subField resolves a field of the Sub type, zero values of optional fields are resolved as null.
+func subField(typ graphql.Output, fn func(sub *domain.Sub) any) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			sub, _ := p.Source.(*domain.Sub)
+			if sub == nil {
+				return nil, nil
+			}
+
+			return fn(sub), nil
+		},
+	}
+}
+
+func optionalMonth(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t.Format(domain.TimeLayout)
+}
+
+var subType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Sub",
+	Description: "Subscription, months are formatted as MM-YYYY and end date is exclusive.",
+	Fields: graphql.Fields{
+		"id":          subField(graphql.NewNonNull(graphql.ID), func(s *domain.Sub) any { return s.ID.String() }),
+		"userId":      subField(graphql.NewNonNull(graphql.ID), func(s *domain.Sub) any { return s.UserID.String() }),
+		"serviceName": subField(graphql.NewNonNull(graphql.String), func(s *domain.Sub) any { return s.ServiceName }),
+		"serviceId": subField(graphql.ID, func(s *domain.Sub) any {
+			if s.ServiceID == uuid.Nil {
+				return nil
+			}
+
+			return s.ServiceID.String()
+		}),
+		"price": subField(graphql.NewNonNull(graphql.Int), func(s *domain.Sub) any { return s.Price }),
+		"startDate": subField(graphql.NewNonNull(graphql.String), func(s *domain.Sub) any {
+			return s.StartDate.Format(domain.TimeLayout)
+		}),
+		"endDate":  subField(graphql.String, func(s *domain.Sub) any { return optionalMonth(s.EndDate) }),
+		"category": subField(graphql.NewNonNull(graphql.String), func(s *domain.Sub) any { return s.Category }),
+		"tags": subField(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), func(s *domain.Sub) any {
+			if s.Tags == nil {
+				return []string{}
+			}
+
+			return s.Tags
+		}),
+		"trialMonths": subField(graphql.NewNonNull(graphql.Int), func(s *domain.Sub) any { return s.TrialMonths }),
+		"promoPrice":  subField(graphql.NewNonNull(graphql.Int), func(s *domain.Sub) any { return s.PromoPrice }),
+		"trialEnd":    subField(graphql.String, func(s *domain.Sub) any { return optionalMonth(s.TrialEnd()) }),
+		"deletedAt": subField(graphql.String, func(s *domain.Sub) any {
+			if s.DeletedAt.IsZero() {
+				return nil
+			}
+
+			return s.DeletedAt.Format(time.RFC3339)
+		}),
+		"status": subField(graphql.NewNonNull(graphql.String), func(s *domain.Sub) any {
+			return string(s.Status(time.Now()))
+		}),
+	},
+})
+
+var subEdgeType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SubEdge",
+	Fields: graphql.Fields{
+		"cursor": subField(graphql.NewNonNull(graphql.String), func(s *domain.Sub) any { return s.ID.String() }),
+		"node": &graphql.Field{
+			Type:    graphql.NewNonNull(subType),
+			Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source, nil },
+		},
+	},
+})
+
+var pageInfoType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "PageInfo",
+	Fields: graphql.Fields{
+		"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"endCursor":   &graphql.Field{Type: graphql.String},
+	},
+})
+
+var subConnectionType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SubConnection",
+	Fields: graphql.Fields{
+		"edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(subEdgeType)))},
+		"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+	},
+})
+
+var summaryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Summary",
+	Fields: graphql.Fields{
+		"userId":      &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"serviceName": &graphql.Field{Type: graphql.String},
+		"from":        &graphql.Field{Type: graphql.String},
+		"to":          &graphql.Field{Type: graphql.String},
+		"totalPrice":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+	},
+})
+
+var subsFilterType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "SubsFilter",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"serviceName": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"trialEndsWithin": &graphql.InputObjectFieldConfig{
+			Type:        graphql.Int,
+			Description: "Only subscriptions, which trial ends within that many days.",
+		},
+		"includeDeleted": &graphql.InputObjectFieldConfig{
+			Type:        graphql.Boolean,
+			Description: "Admins only.",
+		},
+	},
+})
+
+var subInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "SubInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"userId":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+		"serviceName": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"price":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+		"startDate":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"endDate":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"trialMonths": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"promoPrice":  &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"category":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"tags":        &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+	},
+})
+
+// NewSchema creates the schema, which fields are resolved by the resolver.
+func NewSchema(r *Resolver) (graphql.Schema, error) {
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"sub": &graphql.Field{
+				Type: subType,
+				Args: graphql.FieldConfigArgument{
+					"id":             &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"includeDeleted": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: r.sub,
+			},
+			"subs": &graphql.Field{
+				Type:        graphql.NewNonNull(subConnectionType),
+				Description: "User's subscriptions, after is the end cursor of the previous page.",
+				Args: graphql.FieldConfigArgument{
+					"userId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"filter": &graphql.ArgumentConfig{Type: subsFilterType},
+					"first":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"after":  &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.subs,
+			},
+			"summary": &graphql.Field{
+				Type:        graphql.NewNonNull(summaryType),
+				Description: "Total price of user's subscriptions, optionally for the period of months (both inclusive).",
+				Args: graphql.FieldConfigArgument{
+					"userId":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"serviceName": &graphql.ArgumentConfig{Type: graphql.String},
+					"from":        &graphql.ArgumentConfig{Type: graphql.String},
+					"to":          &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.summary,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createSub": &graphql.Field{
+				Type: graphql.NewNonNull(subType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(subInputType)},
+				},
+				Resolve: r.createSub,
+			},
+			"updateSub": &graphql.Field{
+				Type: graphql.NewNonNull(subType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(subInputType)},
+					"priceEffectiveFrom": &graphql.ArgumentConfig{
+						Type:        graphql.String,
+						Description: "Records the new price as a price change starting from that month.",
+					},
+				},
+				Resolve: r.updateSub,
+			},
+			"deleteSub": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.deleteSub,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"fmt"
+	"subs-service/internal/domain"
+	subsv1 "subs-service/pkg/api/subs/v1"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func subToProto(sub *domain.Sub) *subsv1.Sub {
+	res := &subsv1.Sub{
+		Id:          sub.ID.String(),
+		UserId:      sub.UserID.String(),
+		ServiceName: sub.ServiceName,
+		Price:       sub.Price,
+		StartDate:   sub.StartDate.Format(domain.TimeLayout),
+		TrialMonths: int32(sub.TrialMonths),
+		PromoPrice:  sub.PromoPrice,
+		Status:      string(sub.Status(time.Now())),
+		Category:    sub.Category,
+		Tags:        sub.Tags,
+	}
+
+	if !sub.EndDate.IsZero() {
+		res.EndDate = sub.EndDate.Format(domain.TimeLayout)
+	}
+
+	if trialEnd := sub.TrialEnd(); !trialEnd.IsZero() {
+		res.TrialEnd = trialEnd.Format(domain.TimeLayout)
+	}
+
+	if !sub.DeletedAt.IsZero() {
+		res.DeletedAt = sub.DeletedAt.Format(time.RFC3339)
+	}
+
+	return res
+}
+
+func subFromProto(sub *subsv1.Sub) (*domain.Sub, error) {
+	const op = "subFromProto"
+
+	if sub == nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrNoSub)
+	}
+
+	res := domain.Sub{
+		ServiceName: sub.GetServiceName(),
+		Price:       sub.GetPrice(),
+		TrialMonths: int(sub.GetTrialMonths()),
+		PromoPrice:  sub.GetPromoPrice(),
+		Category:    sub.GetCategory(),
+		Tags:        sub.GetTags(),
+	}
+
+	var err error
+
+	if res.UserID, err = uuid.Parse(sub.GetUserId()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.StartDate, err = time.Parse(domain.TimeLayout, sub.GetStartDate()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.EndDate, err = parseOptionalMonth(sub.GetEndDate()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &res, nil
+}
+
+func parseOptionalMonth(value string) (time.Time, error) {
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(domain.TimeLayout, value)
+}
+
+func summaryToProto(sum *domain.Summary) *subsv1.Summary {
+	return &subsv1.Summary{
+		UserId:      sum.UserID.String(),
+		ServiceName: sum.ServiceName,
+		From:        sum.From,
+		To:          sum.To,
+		TotalPrice:  int64(sum.TotalPrice),
+	}
+}
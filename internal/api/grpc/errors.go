@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"errors"
+	"log"
+	"subs-service/internal/repository"
+	pkgErrors "subs-service/pkg/errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInternal = errors.New("internal server error")
+	ErrNoSub    = errors.New("subscription is required")
+
+	errorCodes = map[error]codes.Code{
+		repository.ErrInvalidSubData:       codes.InvalidArgument,
+		repository.ErrNoSubIDExists:        codes.NotFound,
+		repository.ErrNoDeletedSubIDExists: codes.NotFound,
+		repository.ErrInvalidPriceChange:   codes.InvalidArgument,
+		repository.ErrInvalidPause:         codes.InvalidArgument,
+		repository.ErrPauseOverlap:         codes.AlreadyExists,
+		repository.ErrSubNotPaused:         codes.FailedPrecondition,
+		repository.ErrNoWebhookIDExists:    codes.NotFound,
+		repository.ErrNoDeliveryIDExists:   codes.NotFound,
+		repository.ErrNoFeedTokenExists:    codes.NotFound,
+		repository.ErrNoBudgetIDExists:     codes.NotFound,
+		repository.ErrNoServiceIDExists:    codes.NotFound,
+		repository.ErrServiceAliasExists:   codes.AlreadyExists,
+		repository.ErrSubOverlap:           codes.AlreadyExists,
+		repository.ErrMemberIsOwner:        codes.InvalidArgument,
+		repository.ErrNoMemberExists:       codes.NotFound,
+		repository.ErrNoTenant:             codes.InvalidArgument,
+	}
+)
+
+// requestError reports invalid request the same way as the HTTP API does.
+func requestError(err error, debugMode bool) error {
+	log.Print("[ERROR] ", err.Error())
+
+	if !debugMode {
+		err = pkgErrors.UnwrapAll(err)
+	}
+
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// serviceError maps domain errors to status codes, unknown errors are internal ones.
+func serviceError(err error, debugMode bool) error {
+	log.Print("[ERROR] ", err.Error())
+
+	if !debugMode {
+		err = pkgErrors.UnwrapAll(err)
+	}
+
+	code := codes.Internal
+
+	if docCode, ok := errorCodes[pkgErrors.UnwrapAll(err)]; ok {
+		code = docCode
+	} else if !debugMode {
+		err = ErrInternal
+	}
+
+	return status.Error(code, err.Error())
+}
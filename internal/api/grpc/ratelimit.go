@@ -0,0 +1,19 @@
+package grpc
+
+import (
+	subsv1 "subs-service/pkg/api/subs/v1"
+	"subs-service/pkg/http/middleware"
+)
+
+// MethodGroup maps methods to the groups of the HTTP API, so that calls share limits with the HTTP requests.
+// The summary is much heavier for the database, so it is limited on its own.
+func MethodGroup(fullMethod string) string {
+	switch fullMethod {
+	case subsv1.SubsService_GetSummary_FullMethodName:
+		return middleware.GroupSummary
+	case subsv1.SubsService_GetSub_FullMethodName, subsv1.SubsService_ListSubs_FullMethodName:
+		return middleware.GroupRead
+	default:
+		return middleware.GroupWrite
+	}
+}
@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"subs-service/internal/domain"
+	subsv1 "subs-service/pkg/api/subs/v1"
+	"subs-service/pkg/grpc/interceptors"
+	"subs-service/pkg/http/middleware"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer creates gRPC server of the service, reflection is enabled for tools like grpcurl.
+// Interceptors added by opts run after authentication and resolution of the tenant.
+func NewServer(
+	subServer *SubServer,
+	authCfg middleware.AuthConfig,
+	tenantCfg middleware.TenantConfig,
+	opts ...grpc.ServerOption,
+) *grpc.Server {
+	opts = append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(
+		interceptors.Logger,
+		interceptors.Recovery,
+		interceptors.RequestID,
+		interceptors.Auth(authCfg),
+		tenant(tenantCfg),
+		auditMeta,
+	)}, opts...)
+
+	s := grpc.NewServer(opts...)
+
+	subsv1.RegisterSubsServiceServer(s, subServer)
+	reflection.Register(s)
+
+	return s
+}
+
+// auditMeta passes the authenticated subject and request id down to repositories,
+// which record them in the audit log.
+func auditMeta(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	meta := domain.AuditMeta{RequestID: interceptors.GetReqID(ctx)}
+	meta.Actor, _ = middleware.SubjectFromContext(ctx)
+
+	return handler(domain.WithAuditMeta(ctx, meta), req)
+}
+
+// tenant passes the tenant of authenticated request down to repositories the same way as the HTTP API does,
+// the tenant is passed in the metadata with the name of the tenant header.
+func tenant(cfg middleware.TenantConfig) grpc.UnaryServerInterceptor {
+	key := strings.ToLower(cfg.Header)
+
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var header string
+		if values := metadata.ValueFromIncomingContext(ctx, key); len(values) != 0 {
+			header = values[0]
+		}
+
+		tenant, err := middleware.ResolveTenant(ctx, header, cfg)
+		if err != nil {
+			code := codes.InvalidArgument
+			if errors.Is(err, middleware.ErrTenantMismatch) {
+				code = codes.PermissionDenied
+			}
+
+			return nil, status.Error(code, err.Error())
+		}
+
+		return handler(domain.WithTenant(ctx, tenant), req)
+	}
+}
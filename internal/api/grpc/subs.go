@@ -0,0 +1,190 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"subs-service/internal/api/http/types"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/usecases"
+	subsv1 "subs-service/pkg/api/subs/v1"
+	"subs-service/pkg/http/middleware"
+
+	"github.com/google/uuid"
+)
+
+// SubServer serves subs.v1 API on top of the same service layer and validation as the HTTP API.
+type SubServer struct {
+	subsv1.UnimplementedSubsServiceServer
+
+	subSvc  usecases.SubService
+	svcCfg  config.ServiceConfig
+	dataCfg config.DataConfig
+}
+
+func NewSubServer(subSvc usecases.SubService, svcCfg config.ServiceConfig, dataCfg config.DataConfig) *SubServer {
+	return &SubServer{
+		subSvc:  subSvc,
+		svcCfg:  svcCfg,
+		dataCfg: dataCfg,
+	}
+}
+
+// includeDeleted shows deleted subscriptions to admins only.
+func includeDeleted(ctx context.Context, flag bool) bool {
+	return flag && middleware.IsAdmin(ctx)
+}
+
+func (s *SubServer) GetSub(ctx context.Context, req *subsv1.GetSubRequest) (*subsv1.Sub, error) {
+	const op = "SubServer.GetSub"
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), s.svcCfg.DebugMode)
+	}
+
+	sub, err := s.subSvc.GetSub(ctx, id, domain.GetOpts{IncludeDeleted: includeDeleted(ctx, req.GetIncludeDeleted())})
+	if err != nil {
+		return nil, serviceError(err, s.svcCfg.DebugMode)
+	}
+
+	return subToProto(sub), nil
+}
+
+func (s *SubServer) PostSub(ctx context.Context, req *subsv1.PostSubRequest) (*subsv1.Sub, error) {
+	const op = "SubServer.PostSub"
+
+	sub, err := subFromProto(req.GetSub())
+	if err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), s.svcCfg.DebugMode)
+	}
+
+	if err = types.CheckSub(sub, s.dataCfg.ForTenant(ctx)); err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), s.svcCfg.DebugMode)
+	}
+
+	created, err := s.subSvc.PostSub(ctx, sub)
+	if err != nil {
+		return nil, serviceError(err, s.svcCfg.DebugMode)
+	}
+
+	return subToProto(created), nil
+}
+
+func (s *SubServer) PutSub(ctx context.Context, req *subsv1.PutSubRequest) (*subsv1.Sub, error) {
+	const op = "SubServer.PutSub"
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), s.svcCfg.DebugMode)
+	}
+
+	var opts domain.PutOpts
+	if opts.PriceEffectiveFrom, err = parseOptionalMonth(req.GetPriceEffectiveFrom()); err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), s.svcCfg.DebugMode)
+	}
+
+	sub, err := subFromProto(req.GetSub())
+	if err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), s.svcCfg.DebugMode)
+	}
+
+	if err = types.CheckSub(sub, s.dataCfg.ForTenant(ctx)); err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), s.svcCfg.DebugMode)
+	}
+
+	updated, err := s.subSvc.PutSub(ctx, id, sub, opts)
+	if err != nil {
+		return nil, serviceError(err, s.svcCfg.DebugMode)
+	}
+
+	return subToProto(updated), nil
+}
+
+func (s *SubServer) DeleteSub(ctx context.Context, req *subsv1.DeleteSubRequest) (*subsv1.DeleteSubResponse, error) {
+	const op = "SubServer.DeleteSub"
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), s.svcCfg.DebugMode)
+	}
+
+	deleted, err := s.subSvc.DeleteSub(ctx, id)
+	if err != nil {
+		return nil, serviceError(err, s.svcCfg.DebugMode)
+	}
+
+	return &subsv1.DeleteSubResponse{Id: deleted.String()}, nil
+}
+
+func (s *SubServer) ListSubs(ctx context.Context, req *subsv1.ListSubsRequest) (*subsv1.ListSubsResponse, error) {
+	const op = "SubServer.ListSubs"
+
+	dataCfg := s.dataCfg.ForTenant(ctx)
+
+	opts := domain.FilterOpts{
+		ServiceName:     req.GetServiceName(),
+		PageSize:        dataCfg.DefaultPageSize,
+		TrialEndsWithin: int(req.GetTrialEndsWithin()),
+		IncludeDeleted:  includeDeleted(ctx, req.GetIncludeDeleted()),
+	}
+
+	var err error
+
+	if opts.UserID, err = uuid.Parse(req.GetUserId()); err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), s.svcCfg.DebugMode)
+	}
+
+	if pageSize := int(req.GetPageSize()); pageSize > 0 && types.CheckPageSize(pageSize, dataCfg) {
+		opts.PageSize = pageSize
+	}
+
+	if len(req.GetPageToken()) != 0 {
+		if opts.PageToken, err = uuid.Parse(req.GetPageToken()); err != nil {
+			return nil, requestError(fmt.Errorf("%s: %w", op, err), s.svcCfg.DebugMode)
+		}
+	}
+
+	subs, err := s.subSvc.ListSubs(ctx, opts)
+	if err != nil {
+		return nil, serviceError(err, s.svcCfg.DebugMode)
+	}
+
+	res := &subsv1.ListSubsResponse{Subs: make([]*subsv1.Sub, 0, len(subs))}
+	for _, sub := range subs {
+		res.Subs = append(res.Subs, subToProto(sub))
+	}
+
+	// A full page might be followed by more subscriptions.
+	if len(subs) == opts.PageSize {
+		res.NextPageToken = subs[len(subs)-1].ID.String()
+	}
+
+	return res, nil
+}
+
+func (s *SubServer) GetSummary(ctx context.Context, req *subsv1.GetSummaryRequest) (*subsv1.Summary, error) {
+	const op = "SubServer.GetSummary"
+
+	opts := domain.FilterOpts{
+		ServiceName:    req.GetServiceName(),
+		IncludeDeleted: includeDeleted(ctx, req.GetIncludeDeleted()),
+	}
+
+	var err error
+
+	if opts.UserID, err = uuid.Parse(req.GetUserId()); err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), s.svcCfg.DebugMode)
+	}
+
+	if opts.From, opts.To, err = types.ParsePeriod(req.GetFrom(), req.GetTo()); err != nil {
+		return nil, requestError(fmt.Errorf("%s: %w", op, err), s.svcCfg.DebugMode)
+	}
+
+	sum, err := s.subSvc.GetSummary(ctx, opts)
+	if err != nil {
+		return nil, serviceError(err, s.svcCfg.DebugMode)
+	}
+
+	return summaryToProto(sum), nil
+}
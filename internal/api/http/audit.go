@@ -0,0 +1,117 @@
+package http
+
+import (
+	"net/http"
+	"subs-service/internal/api/http/response"
+	"subs-service/internal/api/http/types"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/usecases"
+	"subs-service/pkg/http/handlers"
+	pkgMiddleware "subs-service/pkg/http/middleware"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// WithAuditMeta passes the authenticated subject and request id down to repositories,
+// which record them in the audit log.
+func WithAuditMeta() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				meta := domain.AuditMeta{RequestID: middleware.GetReqID(r.Context())}
+				meta.Actor, _ = pkgMiddleware.SubjectFromContext(r.Context())
+
+				next.ServeHTTP(w, r.WithContext(domain.WithAuditMeta(r.Context(), meta)))
+			})
+		})
+	}
+}
+
+type AuditHandler struct {
+	auditSvc usecases.AuditService
+	pathCfg  config.PathConfig
+	svcCfg   config.ServiceConfig
+	dataCfg  config.DataConfig
+}
+
+func NewAuditHandler(
+	auditSvc usecases.AuditService,
+	pathCfg config.PathConfig,
+	svcCfg config.ServiceConfig,
+	dataCfg config.DataConfig,
+) *AuditHandler {
+	return &AuditHandler{
+		auditSvc: auditSvc,
+		pathCfg:  pathCfg,
+		svcCfg:   svcCfg,
+		dataCfg:  dataCfg,
+	}
+}
+
+func (h *AuditHandler) WithAuditHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Get(h.pathCfg.GetSubHistory, h.getSubHistoryHandler)
+		r.With(pkgMiddleware.RequireAdmin).Get(h.pathCfg.ListAudit, h.listAuditHandler)
+	}
+}
+
+// @Summary 	Get subscription's change history
+// @Description Возвращает все изменения подписки (создание, обновление, удаление) в хронологическом порядке.
+// @Tags 		audit
+// @Produce 	json
+// @Param 		id 		path 	string true "Subcription's id"
+// @Success 	200 {array} 	domain.AuditEntry "Successfully got history"
+// @Failure 	400 {string} 	string "Bad request"
+// @Failure 	404 {string} 	string "Object not found"
+// @Failure 	500 {string} 	string "Internal error"
+// @Router		/subs/{id}/history 		[get]
+func (h *AuditHandler) getSubHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateGetSubHistoryRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.auditSvc.GetSubHistory(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Query audit log (admin only)
+// @Description Все параметры опциональны. Границы периода (from включительно, to не включительно) задаются в формате RFC 3339.
+// @Description Поддерживается keyset пагинация аналогично списку подписок.
+// @Tags 		audit
+// @Produce 	json
+// @Param 		from 			query 	string false "Period start (RFC 3339)"
+// @Param 		to 				query 	string false "Period end (RFC 3339)"
+// @Param 		user_id 		query 	string false "User's id"
+// @Param 		sub_id 			query 	string false "Subscription's id"
+// @Param 		actor 			query 	string false "Actor"
+// @Param 		page_size 		query 	int false "Page size"
+// @Param 		page_token 		query 	string false "Page token (for keyset pagination)"
+// @Success 	200 {object} 			types.ListAuditResponse "Successfully got audit entries"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/audit					[get]
+func (h *AuditHandler) listAuditHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateListAuditRequest(r, h.dataCfg.ForTenant(r.Context()))
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.auditSvc.ListAudit(r.Context(), req.Opts)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, types.CreateListAuditResponse(res), http.StatusOK)
+}
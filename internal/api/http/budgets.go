@@ -0,0 +1,203 @@
+package http
+
+import (
+	"net/http"
+	"subs-service/internal/api/http/response"
+	"subs-service/internal/api/http/types"
+	"subs-service/internal/config"
+	"subs-service/internal/usecases"
+	"subs-service/pkg/http/handlers"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type BudgetHandler struct {
+	budgetSvc usecases.BudgetService
+	pathCfg   config.PathConfig
+	svcCfg    config.ServiceConfig
+	dataCfg   config.DataConfig
+}
+
+func NewBudgetHandler(
+	budgetSvc usecases.BudgetService,
+	pathCfg config.PathConfig,
+	svcCfg config.ServiceConfig,
+	dataCfg config.DataConfig,
+) *BudgetHandler {
+	return &BudgetHandler{
+		budgetSvc: budgetSvc,
+		pathCfg:   pathCfg,
+		svcCfg:    svcCfg,
+		dataCfg:   dataCfg,
+	}
+}
+
+func (h *BudgetHandler) WithBudgetHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Post(h.pathCfg.PostBudget, h.postBudgetHandler)
+		r.Get(h.pathCfg.GetBudget, h.getBudgetHandler)
+		r.Get(h.pathCfg.ListBudgets, h.listBudgetsHandler)
+		r.Put(h.pathCfg.PutBudget, h.putBudgetHandler)
+		r.Delete(h.pathCfg.DeleteBudget, h.deleteBudgetHandler)
+		r.Get(h.pathCfg.GetBudgetStatus, h.getBudgetStatusHandler)
+	}
+}
+
+// @Summary 	Create monthly budget
+// @Description Бюджет ограничивает расходы пользователя за месяц на все подписки или, если указаны service_name и (или) category,
+// @Description на подписки сервиса и (или) категории.
+// @Description Пороги thresholds задаются в процентах от суммы бюджета (по умолчанию берутся из конфигурации, например 80 и 100).
+// @Description При достижении порога расходами за текущий месяц пользователю отправляется оповещение (один раз в месяц).
+// @Tags 		budgets
+// @Accept 		json
+// @Produce 	json
+// @Param 		budget 			body 	domain.Budget true "Budget details"
+// @Success 	201 {object} 			domain.Budget "Successfully created budget"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/budgets				[post]
+func (h *BudgetHandler) postBudgetHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreatePostBudgetRequest(r, h.dataCfg.ForTenant(r.Context()))
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.budgetSvc.PostBudget(r.Context(), &req.Budget)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusCreated)
+}
+
+// @Summary 	Get budget by id
+// @Tags 		budgets
+// @Produce 	json
+// @Param 		id 				path 	string true "Budget's id"
+// @Success 	200 {object} 			domain.Budget "Successfully got budget"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/budgets/{id}			[get]
+func (h *BudgetHandler) getBudgetHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateBudgetIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.budgetSvc.GetBudget(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Get user's budgets
+// @Tags 		budgets
+// @Produce 	json
+// @Param 		user_id 		query 	string true "User's id"
+// @Success 	200 {array} 			domain.Budget "Successfully got budgets"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/budgets				[get]
+func (h *BudgetHandler) listBudgetsHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateListBudgetsRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.budgetSvc.ListBudgets(r.Context(), req.UserID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Update budget by id
+// @Description Пользователь бюджета не изменяется.
+// @Tags 		budgets
+// @Accept 		json
+// @Produce 	json
+// @Param 		id 				path 	string true "Budget's id"
+// @Param 		budget 			body 	domain.Budget true "Budget details"
+// @Success 	200 {object} 			domain.Budget "Successfully updated budget"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/budgets/{id}			[put]
+func (h *BudgetHandler) putBudgetHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreatePutBudgetRequest(r, h.dataCfg.ForTenant(r.Context()))
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.budgetSvc.PutBudget(r.Context(), req.ID, &req.Budget)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Delete budget by id
+// @Tags 		budgets
+// @Produce 	json
+// @Param 		id 				path 	string true "Budget's id"
+// @Success 	200 {object} 			types.DeleteBudgetResponse "Successfully deleted budget"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/budgets/{id}			[delete]
+func (h *BudgetHandler) deleteBudgetHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateBudgetIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.budgetSvc.DeleteBudget(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, types.DeleteBudgetResponse{DeletedID: res.String()}, http.StatusOK)
+}
+
+// @Summary 	Get budget's utilisation at the current month
+// @Description Расходы за текущий месяц считаются так же, как суммарная стоимость за период: по цене, действующей в этом месяце,
+// @Description с учетом пробного периода и приостановок. utilization - процент использования бюджета, remaining - остаток
+// @Description (отрицательный при превышении), reached_thresholds - достигнутые пороги.
+// @Tags 		budgets
+// @Produce 	json
+// @Param 		id 				path 	string true "Budget's id"
+// @Success 	200 {object} 			domain.BudgetStatus "Successfully got budget's status"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/budgets/{id}/status	[get]
+func (h *BudgetHandler) getBudgetStatusHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateBudgetIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.budgetSvc.GetBudgetStatus(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+	"subs-service/internal/api/http/response"
+	"subs-service/internal/config"
+	"subs-service/internal/usecases"
+	"subs-service/pkg/http/handlers"
+	pkgMiddleware "subs-service/pkg/http/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CacheHandler struct {
+	cacheSvc usecases.CacheService
+	pathCfg  config.PathConfig
+}
+
+func NewCacheHandler(cacheSvc usecases.CacheService, pathCfg config.PathConfig) *CacheHandler {
+	return &CacheHandler{
+		cacheSvc: cacheSvc,
+		pathCfg:  pathCfg,
+	}
+}
+
+// WithCacheHandlers registers stats of the cache, which are allowed to admins only.
+func (h *CacheHandler) WithCacheHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.With(pkgMiddleware.RequireAdmin).Get(h.pathCfg.GetCacheStats, h.getCacheStatsHandler)
+	}
+}
+
+// @Summary 	Get stats of the cache of lists and summaries
+// @Description Статистика кэша списков и сводок подписок этой реплики сервиса с момента ее запуска:
+// @Description число результатов в кэше, попадания, промахи и вытеснения. Доступно только администраторам.
+// @Tags 		cache
+// @Produce 	json
+// @Success 	200 {object} 	domain.CacheStats "Successfully got stats"
+// @Failure 	403 {string} 	string "Forbidden"
+// @Router		/cache/stats 	[get]
+func (h *CacheHandler) getCacheStatsHandler(w http.ResponseWriter, r *http.Request) {
+	response.WriteResponse(w, h.cacheSvc.GetCacheStats(r.Context()), http.StatusOK)
+}
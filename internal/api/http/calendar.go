@@ -0,0 +1,136 @@
+package http
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"subs-service/internal/api/http/response"
+	"subs-service/internal/api/http/types"
+	"subs-service/internal/config"
+	"subs-service/internal/usecases"
+	"subs-service/pkg/http/handlers"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CalendarHandler struct {
+	calendarSvc usecases.CalendarService
+	pathCfg     config.PathConfig
+	svcCfg      config.ServiceConfig
+}
+
+func NewCalendarHandler(
+	calendarSvc usecases.CalendarService,
+	pathCfg config.PathConfig,
+	svcCfg config.ServiceConfig,
+) *CalendarHandler {
+	return &CalendarHandler{
+		calendarSvc: calendarSvc,
+		pathCfg:     pathCfg,
+		svcCfg:      svcCfg,
+	}
+}
+
+// WithCalendarTokenHandlers registers feed token management, which requires authentication.
+func (h *CalendarHandler) WithCalendarTokenHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Post(h.pathCfg.CreateCalendarToken, h.createCalendarTokenHandler)
+		r.Delete(h.pathCfg.RevokeCalendarToken, h.revokeCalendarTokenHandler)
+	}
+}
+
+// WithCalendarFeedHandlers registers the feed itself, it is protected by the feed token instead of
+// API keys, so it has to be registered outside of the authenticated group.
+func (h *CalendarHandler) WithCalendarFeedHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Get(h.pathCfg.GetCalendar, h.getCalendarHandler)
+	}
+}
+
+// @Summary 	Create user's calendar feed token
+// @Description Создает секретный токен для подписки на календарь пользователя (iCalendar) в календарных приложениях,
+// @Description которые не могут передавать заголовки аутентификации. Предыдущий токен пользователя отзывается.
+// @Description Токен возвращается только в ответе на этот запрос, url содержит путь календаря с токеном.
+// @Tags 		calendar
+// @Produce 	json
+// @Param 		user_id 		path 	string true "User's id"
+// @Success 	201 {object} 			types.FeedTokenResponse "Successfully created token"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/users/{user_id}/calendar/token	[post]
+func (h *CalendarHandler) createCalendarTokenHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateUserIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	token, err := h.calendarSvc.CreateFeedToken(r.Context(), req.UserID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res := types.CreateFeedTokenResponse(h.pathCfg.API, h.pathCfg.GetCalendar, req.UserID, token)
+	response.WriteResponse(w, res, http.StatusCreated)
+}
+
+// @Summary 	Revoke user's calendar feed token
+// @Tags 		calendar
+// @Param 		user_id 		path 	string true "User's id"
+// @Success 	204
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/users/{user_id}/calendar/token	[delete]
+func (h *CalendarHandler) revokeCalendarTokenHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateUserIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	if err = h.calendarSvc.RevokeFeedToken(r.Context(), req.UserID); err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary 	Get user's subscriptions calendar (iCalendar)
+// @Description Календарь в формате iCalendar (RFC 5545) для подписки в календарных приложениях.
+// @Description Для каждой действующей подписки создается повторяющееся ежемесячное событие списания
+// @Description (RRULE с UNTIL по дате окончания, месяцы приостановки исключаются через EXDATE)
+// @Description и событие окончания подписки. В описании событий указывается стоимость.
+// @Description Доступ предоставляется по токену календаря (параметр token) вместо API-ключа.
+// @Tags 		calendar
+// @Produce 	text/calendar
+// @Param 		user_id 		path 	string true "User's id"
+// @Param 		token 			query 	string true "Calendar feed token"
+// @Success 	200 {string} 			string "Successfully got calendar"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/users/{user_id}/calendar.ics	[get]
+func (h *CalendarHandler) getCalendarHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateGetCalendarRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.calendarSvc.GetCalendar(r.Context(), req.UserID, req.Token)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="subs-%s.ics"`, req.UserID))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err = w.Write(res); err != nil {
+		log.Print("[ERROR] ", err.Error())
+	}
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"net/http"
+	"subs-service/internal/api/http/response"
+	"subs-service/internal/api/http/types"
+	"subs-service/internal/config"
+	"subs-service/internal/usecases"
+	"subs-service/pkg/http/handlers"
+	pkgMiddleware "subs-service/pkg/http/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CatalogHandler struct {
+	catalogSvc usecases.CatalogService
+	pathCfg    config.PathConfig
+	svcCfg     config.ServiceConfig
+	dataCfg    config.DataConfig
+}
+
+func NewCatalogHandler(
+	catalogSvc usecases.CatalogService,
+	pathCfg config.PathConfig,
+	svcCfg config.ServiceConfig,
+	dataCfg config.DataConfig,
+) *CatalogHandler {
+	return &CatalogHandler{
+		catalogSvc: catalogSvc,
+		pathCfg:    pathCfg,
+		svcCfg:     svcCfg,
+		dataCfg:    dataCfg,
+	}
+}
+
+// WithCatalogHandlers registers catalog management, which is available to operators only, as the catalog
+// is shared by all tenants. So the handlers are registered outside of the tenant's routes.
+func (h *CatalogHandler) WithCatalogHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(pkgMiddleware.RequireOperator)
+
+			r.Post(h.pathCfg.PostService, h.postServiceHandler)
+			r.Get(h.pathCfg.GetService, h.getServiceHandler)
+			r.Get(h.pathCfg.ListServices, h.listServicesHandler)
+			r.Put(h.pathCfg.PutService, h.putServiceHandler)
+			r.Delete(h.pathCfg.DeleteService, h.deleteServiceHandler)
+		})
+	}
+}
+
+// @Summary 	Add service to the catalog (operators only)
+// @Description Подписки связываются с сервисом каталога при создании и изменении: название сервиса подписки сравнивается
+// @Description с каноническим названием и псевдонимами без учета регистра и пробелов по краям, после чего заменяется
+// @Description каноническим названием. Фильтрация и суммарная стоимость по названию сервиса учитывают все его псевдонимы.
+// @Description Псевдонимы не могут повторяться у разных сервисов. Валюта по умолчанию - RUB.
+// @Tags 		catalog
+// @Accept 		json
+// @Produce 	json
+// @Param 		service 		body 	domain.Service true "Service details"
+// @Success 	201 {object} 			domain.Service "Successfully added service"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	409 {string} 			string "Name or alias is already used"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/services				[post]
+func (h *CatalogHandler) postServiceHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreatePostServiceRequest(r, h.dataCfg)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.catalogSvc.PostService(r.Context(), &req.Service)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusCreated)
+}
+
+// @Summary 	Get catalog service by id (operators only)
+// @Tags 		catalog
+// @Produce 	json
+// @Param 		id 				path 	string true "Service's id"
+// @Success 	200 {object} 			domain.Service "Successfully got service"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/services/{id}			[get]
+func (h *CatalogHandler) getServiceHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateServiceIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.catalogSvc.GetService(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Get catalog services (operators only)
+// @Tags 		catalog
+// @Produce 	json
+// @Success 	200 {array} 			domain.Service "Successfully got services"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/services				[get]
+func (h *CatalogHandler) listServicesHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := h.catalogSvc.ListServices(r.Context())
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Update catalog service by id (operators only)
+// @Description Псевдонимы заменяются переданными. Уже связанные подписки сохраняют связь с сервисом,
+// @Description несвязанные подписки связываются при следующем изменении.
+// @Tags 		catalog
+// @Accept 		json
+// @Produce 	json
+// @Param 		id 				path 	string true "Service's id"
+// @Param 		service 		body 	domain.Service true "Service details"
+// @Success 	200 {object} 			domain.Service "Successfully updated service"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	409 {string} 			string "Name or alias is already used"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/services/{id}			[put]
+func (h *CatalogHandler) putServiceHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreatePutServiceRequest(r, h.dataCfg)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.catalogSvc.PutService(r.Context(), req.ID, &req.Service)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Delete catalog service by id (operators only)
+// @Description Подписки сервиса отвязываются от каталога и сохраняют его каноническое название.
+// @Tags 		catalog
+// @Produce 	json
+// @Param 		id 				path 	string true "Service's id"
+// @Success 	200 {object} 			types.DeleteServiceResponse "Successfully deleted service"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/services/{id}			[delete]
+func (h *CatalogHandler) deleteServiceHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateServiceIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.catalogSvc.DeleteService(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, types.DeleteServiceResponse{DeletedID: res.String()}, http.StatusOK)
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"net/http"
+	"subs-service/internal/api/http/response"
+	"subs-service/internal/api/http/types"
+	"subs-service/internal/config"
+	"subs-service/internal/usecases"
+	"subs-service/pkg/http/handlers"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type JobHandler struct {
+	jobSvc  usecases.JobService
+	pathCfg config.PathConfig
+	svcCfg  config.ServiceConfig
+}
+
+func NewJobHandler(jobSvc usecases.JobService, pathCfg config.PathConfig, svcCfg config.ServiceConfig) *JobHandler {
+	return &JobHandler{
+		jobSvc:  jobSvc,
+		pathCfg: pathCfg,
+		svcCfg:  svcCfg,
+	}
+}
+
+func (h *JobHandler) WithJobHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Get(h.pathCfg.GetJob, h.getJobHandler)
+		r.Delete(h.pathCfg.CancelJob, h.cancelJobHandler)
+	}
+}
+
+// @Summary 	Get background job
+// @Description Статус фоновой задачи: pending (ожидает запуска, в т.ч. повторного после ошибки), running,
+// @Description completed (результат в поле result), failed (после исчерпания попыток, причина в поле error)
+// @Description или cancelled. Для выполняющихся задач указывается прогресс в процентах.
+// @Tags 		jobs
+// @Produce 	json
+// @Param 		id 		path 	string true "Job's id"
+// @Success 	200 {object} 	domain.Job "Successfully got job"
+// @Failure 	400 {string} 	string "Bad request"
+// @Failure 	404 {string} 	string "Object not found"
+// @Failure 	500 {string} 	string "Internal error"
+// @Router		/jobs/{id} 		[get]
+func (h *JobHandler) getJobHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateJobIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.jobSvc.GetJob(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Cancel background job
+// @Description Отменяет ожидающую или выполняющуюся задачу. Выполнение задачи останавливается
+// @Description в течение трети срока аренды (jobs.lease). Завершенные задачи отменить нельзя.
+// @Tags 		jobs
+// @Produce 	json
+// @Param 		id 		path 	string true "Job's id"
+// @Success 	200 {object} 	domain.Job "Successfully cancelled job"
+// @Failure 	400 {string} 	string "Bad request"
+// @Failure 	404 {string} 	string "Object not found"
+// @Failure 	409 {string} 	string "Job is already finished"
+// @Failure 	500 {string} 	string "Internal error"
+// @Router		/jobs/{id} 		[delete]
+func (h *JobHandler) cancelJobHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateJobIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.jobSvc.CancelJob(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
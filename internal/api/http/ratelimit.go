@@ -0,0 +1,30 @@
+package http
+
+import (
+	"net/http"
+	"path"
+	"subs-service/internal/config"
+	"subs-service/pkg/http/middleware"
+)
+
+// RateLimitGroup separates summary endpoints (including the forecast) from the rest of reads, since they are
+// much heavier for the database and are limited on their own. GraphQL queries may request
+// several summaries at once, so they are limited as summaries too. The groups also tell read-your-writes
+// writes from reads, GraphQL requests aren't writes there, so its mutations pin clients on their own.
+func RateLimitGroup(pathCfg config.PathConfig) middleware.GroupFunc {
+	summaryPaths := map[string]struct{}{
+		path.Join(pathCfg.API, pathCfg.GetSummary):     {},
+		path.Join(pathCfg.API, pathCfg.GetForecast):    {},
+		path.Join(pathCfg.API, pathCfg.ListDuplicates): {},
+		path.Join(pathCfg.API, pathCfg.GetSettlement):  {},
+		path.Join(pathCfg.API, pathCfg.GraphQL):        {},
+	}
+
+	return func(r *http.Request) string {
+		if _, ok := summaryPaths[r.URL.Path]; ok {
+			return middleware.GroupSummary
+		}
+
+		return middleware.MethodGroup(r)
+	}
+}
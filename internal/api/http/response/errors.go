@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 	"subs-service/internal/repository"
+	"subs-service/internal/usecases"
 	pkgErrors "subs-service/pkg/errors"
 )
 
@@ -12,8 +13,30 @@ var (
 	ErrInternal = errors.New("internal server error")
 
 	errorCodes = map[error]int{
-		repository.ErrInvalidSubData: http.StatusBadRequest,
-		repository.ErrNoSubIDExists: http.StatusNotFound,
+		repository.ErrInvalidSubData:       http.StatusBadRequest,
+		repository.ErrNoSubIDExists:        http.StatusNotFound,
+		repository.ErrNoDeletedSubIDExists: http.StatusNotFound,
+		repository.ErrInvalidPriceChange:   http.StatusBadRequest,
+		repository.ErrInvalidPause:         http.StatusBadRequest,
+		repository.ErrPauseOverlap:         http.StatusConflict,
+		repository.ErrSubNotPaused:         http.StatusConflict,
+		repository.ErrNoWebhookIDExists:    http.StatusNotFound,
+		repository.ErrNoDeliveryIDExists:   http.StatusNotFound,
+		repository.ErrNoFeedTokenExists:    http.StatusNotFound,
+		repository.ErrNoBudgetIDExists:     http.StatusNotFound,
+		repository.ErrNoServiceIDExists:    http.StatusNotFound,
+		repository.ErrServiceAliasExists:   http.StatusConflict,
+		repository.ErrNoTagExists:          http.StatusNotFound,
+		repository.ErrTagExists:            http.StatusConflict,
+		repository.ErrSubOverlap:           http.StatusConflict,
+		repository.ErrMemberIsOwner:        http.StatusBadRequest,
+		repository.ErrNoMemberExists:       http.StatusNotFound,
+		repository.ErrNoExportIDExists:     http.StatusNotFound,
+		repository.ErrNoExportCompleted:    http.StatusNotFound,
+		repository.ErrNoJobIDExists:        http.StatusNotFound,
+		repository.ErrJobFinished:          http.StatusConflict,
+		repository.ErrNoTenant:             http.StatusBadRequest,
+		usecases.ErrInvalidFeedToken:       http.StatusForbidden,
 	}
 )
 
@@ -34,13 +57,19 @@ func ProcessError(w http.ResponseWriter, err error, debugMode bool) {
 		err = pkgErrors.UnwrapAll(err)
 	}
 
-	code := http.StatusInternalServerError
-
-	if docCode, ok := errorCodes[pkgErrors.UnwrapAll(err)]; ok {
-		code = docCode
-	} else if !debugMode {
+	code, ok := ErrorCode(err)
+	if !ok && !debugMode {
 		err = ErrInternal
 	}
 
 	http.Error(w, err.Error(), code)
 }
+
+// ErrorCode returns status code of the documented error, other errors are internal ones.
+func ErrorCode(err error) (int, bool) {
+	if code, ok := errorCodes[pkgErrors.UnwrapAll(err)]; ok {
+		return code, true
+	}
+
+	return http.StatusInternalServerError, false
+}
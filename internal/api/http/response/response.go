@@ -1,10 +1,16 @@
 package response
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
+	"strings"
 )
 
+// cacheControl lets clients cache responses, but makes them revalidate the responses on each use.
+const cacheControl = "private, no-cache"
+
 func WriteResponse(w http.ResponseWriter, resp any, code int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
@@ -13,3 +19,44 @@ func WriteResponse(w http.ResponseWriter, resp any, code int) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
+
+// WriteCachedResponse writes the response with ETag of its body, the body is omitted (304 Not Modified),
+// if the request's If-None-Match matches the ETag.
+func WriteCachedResponse(w http.ResponseWriter, r *http.Request, resp any) {
+	body, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	body = append(body, '\n')
+
+	hash := sha256.Sum256(body)
+	etag := `"` + base64.RawURLEncoding.EncodeToString(hash[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", cacheControl)
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = w.Write(body)
+}
+
+// etagMatches compares tags weakly, as If-None-Match requires.
+func etagMatches(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
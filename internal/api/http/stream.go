@@ -0,0 +1,130 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"subs-service/internal/api/http/response"
+	"subs-service/internal/api/http/types"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/usecases"
+	"subs-service/pkg/http/handlers"
+	"subs-service/pkg/http/sse"
+	"subs-service/pkg/pubsub"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// resetEvent tells the client that some events were missed, so its state has to be reloaded.
+	resetEvent = "reset"
+)
+
+type StreamHandler struct {
+	streamSvc usecases.StreamService
+	pathCfg   config.PathConfig
+	svcCfg    config.ServiceConfig
+	streamCfg config.StreamConfig
+}
+
+func NewStreamHandler(
+	streamSvc usecases.StreamService,
+	pathCfg config.PathConfig,
+	svcCfg config.ServiceConfig,
+	streamCfg config.StreamConfig,
+) *StreamHandler {
+	return &StreamHandler{
+		streamSvc: streamSvc,
+		pathCfg:   pathCfg,
+		svcCfg:    svcCfg,
+		streamCfg: streamCfg,
+	}
+}
+
+func (h *StreamHandler) WithStreamHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Get(h.pathCfg.StreamSubs, h.streamSubsHandler)
+	}
+}
+
+// @Summary 	Stream changes of user's subscriptions (Server-Sent Events)
+// @Description Поток событий sub.created, sub.updated, sub.deleted, sub.restored по подпискам пользователя.
+// @Description Данные события: id (номер изменения в журнале), event, user_id, sub_id и data (подписка после изменения).
+// @Description При переподключении поток продолжается после Last-Event-ID (заголовок или параметр last_event_id).
+// @Description Если часть событий уже недоступна, сначала отправляется событие reset - клиенту нужно перечитать подписки.
+// @Description Периодически отправляются комментарии (heartbeat).
+// @Tags 		subs
+// @Produce 	text/event-stream
+// @Param 		user_id 		query 	string true "User's id"
+// @Param 		last_event_id 	query 	int false "Id of the last received event"
+// @Param 		Last-Event-ID 	header 	int false "Id of the last received event"
+// @Success 	200 {string} 			string "Stream of events"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/subs/stream			[get]
+func (h *StreamHandler) streamSubsHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateStreamSubsRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	// Client is subscribed before the response starts, so that no events are missed after it.
+	sub, replay, complete := h.streamSvc.SubscribeSubEvents(domain.TenantFromContext(r.Context()), req.UserID, req.LastEventID)
+	defer sub.Close()
+
+	stream, err := sse.NewWriter(w)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	if err = h.stream(r, stream, sub, replay, complete); err != nil {
+		log.Printf("[ERROR] Subscriptions stream of %s closed: %s", req.UserID, err.Error())
+	}
+}
+
+func (h *StreamHandler) stream(
+	r *http.Request, stream *sse.Writer, sub *pubsub.Subscription, replay []pubsub.Event, complete bool,
+) error {
+	if err := stream.Retry(h.streamCfg.RetryInterval); err != nil {
+		return err
+	}
+
+	if !complete {
+		if err := stream.Event("", resetEvent, nil); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range replay {
+		if err := stream.Event(strconv.FormatInt(event.ID, 10), event.Type, event.Data); err != nil {
+			return err
+		}
+	}
+
+	heartbeat := time.NewTicker(h.streamCfg.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return nil
+		case <-heartbeat.C:
+			if err := stream.Comment("heartbeat"); err != nil {
+				return err
+			}
+		case event, ok := <-sub.C:
+			// The subscription is closed on shutdown or if the client is too slow, so it reconnects.
+			if !ok {
+				return nil
+			}
+
+			if err := stream.Event(strconv.FormatInt(event.ID, 10), event.Type, event.Data); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -38,16 +38,37 @@ func (h *SubHandler) WithSubHandlers() handlers.RouterOption {
 		r.Post(h.pathCfg.PostSub, h.postSubHandler)
 		r.Put(h.pathCfg.PutSub, h.putSubHandler)
 		r.Delete(h.pathCfg.DeleteSub, h.deleteSubHandler)
+		r.Post(h.pathCfg.RestoreSub, h.restoreSubHandler)
+
+		r.Get(h.pathCfg.ListPriceChanges, h.listPriceChangesHandler)
+		r.Post(h.pathCfg.AddPriceChange, h.addPriceChangeHandler)
+
+		r.Get(h.pathCfg.ListPauses, h.listPausesHandler)
+		r.Post(h.pathCfg.PauseSub, h.pauseSubHandler)
+		r.Post(h.pathCfg.ResumeSub, h.resumeSubHandler)
 
 		r.Get(h.pathCfg.ListSubs, h.listSubsHandler)
 		r.Get(h.pathCfg.GetSummary, h.getSummaryHandler)
+		r.Get(h.pathCfg.GetForecast, h.getForecastHandler)
+		r.Get(h.pathCfg.ListDuplicates, h.listDuplicatesHandler)
+
+		r.Get(h.pathCfg.ListTags, h.listTagsHandler)
+		r.Post(h.pathCfg.RenameTag, h.renameTagHandler)
+		r.Post(h.pathCfg.MergeTags, h.mergeTagsHandler)
+
+		r.Get(h.pathCfg.ListMembers, h.listMembersHandler)
+		r.Put(h.pathCfg.PutMember, h.putMemberHandler)
+		r.Delete(h.pathCfg.DeleteMember, h.deleteMemberHandler)
+		r.Get(h.pathCfg.GetSettlement, h.getSettlementHandler)
 	}
 }
 
 // @Summary 	Get subscription by id
 // @Tags 		subs
 // @Produce 	json
+// @Description Удаленные подписки доступны только администраторам с параметром include_deleted.
 // @Param 		id 		path 	string true "Subcription's id"
+// @Param 		include_deleted query bool false "Include deleted subscription (admins only)"
 // @Success 	200 {object} 	domain.Sub "Successfully got sub"
 // @Failure 	400 {string} 	string "Bad request"
 // @Failure 	404 {string} 	string "Object not found"
@@ -60,7 +81,7 @@ func (h *SubHandler) getSubHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := h.subSvc.GetSub(r.Context(), req.ID)
+	res, err := h.subSvc.GetSub(r.Context(), req.ID, req.Opts)
 	if err != nil {
 		response.ProcessError(w, err, h.svcCfg.DebugMode)
 		return
@@ -70,20 +91,25 @@ func (h *SubHandler) getSubHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary 	Create new subscription
-// @Description Поле end_date опционально.
+// @Description Поля end_date, trial_months, promo_price, category и tags опциональны.
+// @Description Если сервис есть в каталоге, а категория не указана, подписка получает категорию сервиса из каталога.
+// @Description Первые trial_months месяцев подписки - пробный период, который оплачивается по цене promo_price (по умолчанию бесплатно).
 // @Description Для параметров подписки по умолчанию установлены следующие ограничения:
 // @Description - имя сервиса должно быть непустым и не длиннее 50 символов;
-// @Description - стоимость подписки должна быть положительной, но не более 100.000.
+// @Description - стоимость подписки (в т.ч. промо-цена) должна быть положительной, но не более 100.000;
+// @Description - пробный период не длиннее 12 месяцев, промо-цена указывается только вместе с пробным периодом;
+// @Description - категория и каждый тег не длиннее 50 символов, не более 20 тегов (повторяющиеся теги удаляются).
 // @Tags 		subs
 // @Accept  	json
 // @Produce 	json
 // @Param 		sub 	body 	domain.Sub true "Sub details"
 // @Success 	201 {object} 	domain.Sub "Successfully created sub"
 // @Failure 	400 {string} 	string "Bad request"
+// @Failure 	409 {string} 	string "Overlapping subscription exists (strict mode)"
 // @Failure 	500 {string} 	string "Internal error"
 // @Router		/subs 			[post]
 func (h *SubHandler) postSubHandler(w http.ResponseWriter, r *http.Request) {
-	req, err := types.CreatePostSubRequest(r, h.dataCfg)
+	req, err := types.CreatePostSubRequest(r, h.dataCfg.ForTenant(r.Context()))
 	if err != nil {
 		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
 		return
@@ -100,10 +126,13 @@ func (h *SubHandler) postSubHandler(w http.ResponseWriter, r *http.Request) {
 
 // @Summary 	Update subscription's data by id
 // @Description Требования к телу запроса такие же, как и у post запроса на создание подписки.
+// @Description По умолчанию новая цена действует на весь период подписки (история цен перезаписывается).
+// @Description Если указан параметр price_effective_from, новая цена записывается как изменение цены, действующее с указанного месяца.
 // @Tags 		subs
 // @Accept 		json
 // @Produce 	json
 // @Param 		id 				path 	string true "Sub's id"
+// @Param 		price_effective_from query string false "Month since which the new price is in effect (MM-YYYY)"
 // @Param 		sub 			body 	domain.Sub true "Sub details"
 // @Success 	200 {object} 			domain.Sub "Successfully updated sub"
 // @Failure 	400 {string} 			string "Bad request"
@@ -111,13 +140,13 @@ func (h *SubHandler) postSubHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure 	500 {string} 			string "Internal error"
 // @Router		/subs/{id}				[put]
 func (h *SubHandler) putSubHandler(w http.ResponseWriter, r *http.Request) {
-	req, err := types.CreatePutSubRequest(r, h.dataCfg)
+	req, err := types.CreatePutSubRequest(r, h.dataCfg.ForTenant(r.Context()))
 	if err != nil {
 		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
 		return
 	}
 
-	res, err := h.subSvc.PutSub(r.Context(), req.ID, &req.Sub)
+	res, err := h.subSvc.PutSub(r.Context(), req.ID, &req.Sub, req.Opts)
 	if err != nil {
 		response.ProcessError(w, err, h.svcCfg.DebugMode)
 		return
@@ -127,6 +156,7 @@ func (h *SubHandler) putSubHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary 	Delete subscription by id
+// @Description Подписка помечается удаленной и может быть восстановлена, пока не истечет срок хранения удаленных подписок.
 // @Tags 		subs
 // @Produce 	json
 // @Param 		id 				path 	string true "Sub's id"
@@ -151,22 +181,195 @@ func (h *SubHandler) deleteSubHandler(w http.ResponseWriter, r *http.Request) {
 	response.WriteResponse(w, types.DeleteSubResponse{DeletedID: res.String()}, http.StatusOK)
 }
 
+// @Summary 	Restore deleted subscription by id
+// @Tags 		subs
+// @Produce 	json
+// @Param 		id 				path 	string true "Sub's id"
+// @Success 	200 {object} 			domain.Sub "Successfully restored sub"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/subs/{id}/restore		[post]
+func (h *SubHandler) restoreSubHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateRestoreSubRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.RestoreSub(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Get subscription's price history
+// @Tags 		prices
+// @Produce 	json
+// @Param 		id 				path 	string true "Sub's id"
+// @Success 	200 {array} 			domain.PriceChange "Successfully got price history"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/subs/{id}/prices		[get]
+func (h *SubHandler) listPriceChangesHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateListPriceChangesRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.ListPriceChanges(r.Context(), req.SubID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Change subscription's price starting from the given month
+// @Description Месяц effective_from (в формате MM-YYYY) должен попадать в период подписки.
+// @Description Цена в поле price подписки - последняя установленная цена.
+// @Tags 		prices
+// @Accept 		json
+// @Produce 	json
+// @Param 		id 				path 	string true "Sub's id"
+// @Param 		change 			body 	domain.PriceChange true "Price change"
+// @Success 	201 {object} 			domain.Sub "Successfully changed price"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/subs/{id}/prices		[post]
+func (h *SubHandler) addPriceChangeHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateAddPriceChangeRequest(r, h.dataCfg.ForTenant(r.Context()))
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.AddPriceChange(r.Context(), &req.Change)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusCreated)
+}
+
+// @Summary 	Get subscription's pauses
+// @Tags 		pauses
+// @Produce 	json
+// @Param 		id 				path 	string true "Sub's id"
+// @Success 	200 {array} 			domain.Pause "Successfully got pauses"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/subs/{id}/pauses		[get]
+func (h *SubHandler) listPausesHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateListPausesRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.ListPauses(r.Context(), req.SubID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Pause subscription's billing
+// @Description Месяцы from и to (в формате MM-YYYY) включаются в паузу, поле to опционально:
+// @Description пауза без to длится до возобновления подписки.
+// @Description Пауза должна начинаться в период подписки и не пересекаться с другими паузами.
+// @Description Приостановленные месяцы не учитываются при подсчете суммарной стоимости.
+// @Tags 		pauses
+// @Accept 		json
+// @Produce 	json
+// @Param 		id 				path 	string true "Sub's id"
+// @Param 		pause 			body 	domain.Pause true "Pause months"
+// @Success 	201 {object} 			domain.Pause "Successfully paused sub"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	409 {string} 			string "Pause overlaps with another one"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/subs/{id}/pause		[post]
+func (h *SubHandler) pauseSubHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreatePauseSubRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.PauseSub(r.Context(), &req.Pause)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusCreated)
+}
+
+// @Summary 	Resume subscription's billing
+// @Description Оплата возобновляется с текущего месяца: пауза, включающая текущий месяц, сокращается или удаляется.
+// @Tags 		pauses
+// @Produce 	json
+// @Param 		id 				path 	string true "Sub's id"
+// @Success 	200 {object} 			domain.Sub "Successfully resumed sub"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	409 {string} 			string "Sub is not paused"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/subs/{id}/resume		[post]
+func (h *SubHandler) resumeSubHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateResumeSubRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.ResumeSub(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
 // @Summary 	Get user's subscriptions list
 // @Description Параметр user_id обязателен для получения списка подписок. Опционально поддерживается фильтрация по названию сервиса.
 // @Description Также поддерживается keyset пагинация - опционально можно указать размер страницы (по умолчанию 20)
 // @Description и токен для получения следующей страницы (поле next_page_token в теле предыдущего запроса).
+// @Description Параметр trial_ends_within позволяет выбрать подписки, пробный период которых заканчивается в течение указанного числа дней.
+// @Description Фильтр по тегам (через запятую) выбирает подписки с любым из тегов или, если tags_match=all, со всеми тегами.
+// @Description Ответ содержит ETag, при совпадении If-None-Match с текущим ETag возвращается 304 без тела.
 // @Tags 		list
 // @Produce 	json
 // @Param 		user_id 		query 	string true "User's id"
 // @Param 		service_name 	query 	string false "Service name"
 // @Param 		page_size 		query 	int false "Page size"
 // @Param 		page_token 		query 	string false "Page token (for keyset pagination)"
+// @Param 		trial_ends_within query int false "Days until the end of trial"
+// @Param 		category 		query 	string false "Category"
+// @Param 		tags 			query 	string false "Comma separated tags"
+// @Param 		tags_match 		query 	string false "Match any (default) or all of the tags" Enums(any, all)
+// @Param 		include_deleted query 	bool false "Include deleted subscriptions (admins only)"
+// @Param 		If-None-Match 	header 	string false "ETag of the cached response"
 // @Success 	200 {object} 			types.ListSubsResponse "Successfully got subs list"
+// @Success 	304 					"Not modified"
 // @Failure 	400 {string} 			string "Bad request"
 // @Failure 	500 {string} 			string "Internal error"
 // @Router		/subs					[get]
 func (h *SubHandler) listSubsHandler(w http.ResponseWriter, r *http.Request) {
-	req, err := types.CreateListSubsRequest(r, h.dataCfg)
+	req, err := types.CreateListSubsRequest(r, h.dataCfg.ForTenant(r.Context()))
 	if err != nil {
 		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
 		return
@@ -178,21 +381,38 @@ func (h *SubHandler) listSubsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.WriteResponse(w, types.CreateListSubsResponse(res), http.StatusOK)
+	response.WriteCachedResponse(w, r, types.CreateListSubsResponse(res))
 }
 
 // @Summary 	Get summary of user's subscriptions (e.g. total price)
 // @Description Параметр user_id обязателен для получения суммарной стоимости подписок. Опционально поддерживается фильтрация по названию сервиса.
+// @Description Без периода возвращается сумма текущих цен подписок. Если указан период (from и to включительно, в формате MM-YYYY),
+// @Description за каждый месяц периода, в который подписка действует, учитывается цена, действовавшая в этом месяце.
+// @Description Месяцы пробного периода учитываются по промо-цене (бесплатный пробный период - по нулевой цене).
+// @Description Фильтрация по категории и тегам такая же, как у списка подписок. Если указан group_by, дополнительно
+// @Description возвращаются суммы по сервисам, категориям или тегам (подписки без категории или тегов - с пустым ключом,
+// @Description подписка с несколькими тегами учитывается в каждой из групп).
+// @Description Общие подписки, владельцем или участником которых является пользователь, учитываются по его доле.
+// @Description Ответ содержит ETag, при совпадении If-None-Match с текущим ETag возвращается 304 без тела.
 // @Tags 		summary
 // @Produce 	json
 // @Param 		user_id 		query 	string true "User's id"
 // @Param 		service_name 	query 	string false "Service name"
+// @Param 		from 			query 	string false "Period start (MM-YYYY)"
+// @Param 		to 				query 	string false "Period end (MM-YYYY)"
+// @Param 		category 		query 	string false "Category"
+// @Param 		tags 			query 	string false "Comma separated tags"
+// @Param 		tags_match 		query 	string false "Match any (default) or all of the tags" Enums(any, all)
+// @Param 		group_by 		query 	string false "Dimension of groups" Enums(service, category, tag)
+// @Param 		include_deleted query 	bool false "Include deleted subscriptions (admins only)"
+// @Param 		If-None-Match 	header 	string false "ETag of the cached response"
 // @Success 	200 {object} 			domain.Summary "Successfully got summary"
+// @Success 	304 					"Not modified"
 // @Failure 	400 {string} 			string "Bad request"
 // @Failure 	500 {string} 			string "Internal error"
 // @Router 		/subs/summary 			[get]
 func (h *SubHandler) getSummaryHandler(w http.ResponseWriter, r *http.Request) {
-	req, err := types.CreateGetSummaryRequest(r, h.dataCfg)
+	req, err := types.CreateGetSummaryRequest(r, h.dataCfg.ForTenant(r.Context()))
 	if err != nil {
 		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
 		return
@@ -204,5 +424,260 @@ func (h *SubHandler) getSummaryHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	response.WriteCachedResponse(w, r, res)
+}
+
+// @Summary 	Get forecast of user's spend for the next months
+// @Description Прогноз расходов пользователя на months месяцев, начиная со следующего (текущий месяц уже оплачен).
+// @Description Для каждого месяца возвращается общая сумма и суммы по сервисам. Учитываются даты окончания подписок,
+// @Description запланированные изменения цен, пробные периоды и приостановки (бессрочная приостановка действует
+// @Description до конца прогноза). Если months не указан, используется значение по умолчанию из конфигурации.
+// @Tags 		summary
+// @Produce 	json
+// @Param 		user_id 		query 	string true "User's id"
+// @Param 		months 			query 	int false "Number of months"
+// @Success 	200 {object} 			domain.Forecast "Successfully got forecast"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router 		/subs/forecast 			[get]
+func (h *SubHandler) getForecastHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateGetForecastRequest(r, h.dataCfg.ForTenant(r.Context()))
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.GetForecast(r.Context(), req.UserID, req.Months)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Find user's duplicate subscriptions
+// @Description Пары подписок пользователя на один и тот же сервис (по каталогу или по названию без учета регистра
+// @Description и пробелов по краям), периоды которых [start_date, end_date) пересекаются. wasted_spend - лишние расходы
+// @Description за месяцы пересечения до текущего включительно: за каждый месяц учитывается меньшее из двух списаний
+// @Description (с учетом истории цен, пробных периодов и приостановок). В строгом режиме (duplicates.strict)
+// @Description создание пересекающейся подписки отклоняется с кодом 409.
+// @Tags 		summary
+// @Produce 	json
+// @Param 		user_id 		query 	string true "User's id"
+// @Success 	200 {object} 			domain.DuplicatesReport "Successfully found duplicates"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router 		/subs/duplicates 		[get]
+func (h *SubHandler) listDuplicatesHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateListDuplicatesRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.ListDuplicates(r.Context(), req.UserID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	List user's tags
+// @Description Теги пользователя с количеством подписок (без удаленных), отсортированные по названию.
+// @Tags 		tags
+// @Produce 	json
+// @Param 		user_id 		path 	string true "User's id"
+// @Success 	200 {array} 			domain.Tag "Successfully got tags"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router 		/users/{user_id}/tags 	[get]
+func (h *SubHandler) listTagsHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateUserIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.ListTags(r.Context(), req.UserID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Rename user's tag
+// @Description Тег переименовывается во всех подписках пользователя, изменения подписок записываются в журнал аудита.
+// @Description Если тег с новым названием уже существует, возвращается 409 - такие теги необходимо объединить.
+// @Tags 		tags
+// @Accept 		json
+// @Produce 	json
+// @Param 		user_id 		path 	string true "User's id"
+// @Param 		rename 			body 	types.RenameTagRequest true "Current and new names"
+// @Success 	200 {object} 			domain.Tag "Successfully renamed tag"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	409 {string} 			string "Tag already exists"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router 		/users/{user_id}/tags/rename [post]
+func (h *SubHandler) renameTagHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateRenameTagRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.RenameTag(r.Context(), req.UserID, req.From, req.To)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Merge user's tags
+// @Description Подписки пользователя с любым из тегов sources получают тег target (создается при необходимости),
+// @Description после чего теги sources удаляются. Изменения подписок записываются в журнал аудита.
+// @Description Если ни одного из тегов sources не существует, возвращается 404.
+// @Tags 		tags
+// @Accept 		json
+// @Produce 	json
+// @Param 		user_id 		path 	string true "User's id"
+// @Param 		merge 			body 	types.MergeTagsRequest true "Merged tags and target tag"
+// @Success 	200 {object} 			domain.Tag "Successfully merged tags"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router 		/users/{user_id}/tags/merge [post]
+func (h *SubHandler) mergeTagsHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateMergeTagsRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.MergeTags(r.Context(), req.UserID, req.Sources, req.Target)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	List members of shared subscription
+// @Tags 		members
+// @Produce 	json
+// @Param 		id 				path 	string true "Sub's id"
+// @Success 	200 {array} 			domain.Member "Successfully got members"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/subs/{id}/members		[get]
+func (h *SubHandler) listMembersHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateListMembersRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.ListMembers(r.Context(), req.SubID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Share subscription with user or change their share
+// @Description Владелец подписки (user_id подписки) оплачивает ее и делит стоимость с участниками. Участник платит
+// @Description либо фиксированную сумму amount (не более максимальной цены), либо долю по весу weight (от 1 до 100).
+// @Description Каждый месяц сначала оплачиваются фиксированные суммы (пропорционально уменьшаются, если превышают
+// @Description списание, например, в пробный период), остаток делится по весам, вес владельца - 1.
+// @Description Владелец оплачивает остаток, в т.ч. образовавшийся при округлении долей. Владелец не может быть участником.
+// @Tags 		members
+// @Accept 		json
+// @Produce 	json
+// @Param 		id 				path 	string true "Sub's id"
+// @Param 		user_id 		path 	string true "Member's user id"
+// @Param 		member 			body 	domain.Member true "Member's share"
+// @Success 	200 {object} 			domain.Member "Successfully put member"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/subs/{id}/members/{user_id} [put]
+func (h *SubHandler) putMemberHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreatePutMemberRequest(r, h.dataCfg.ForTenant(r.Context()))
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.PutMember(r.Context(), &req.Member)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Remove member of shared subscription
+// @Tags 		members
+// @Param 		id 				path 	string true "Sub's id"
+// @Param 		user_id 		path 	string true "Member's user id"
+// @Success 	204
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/subs/{id}/members/{user_id} [delete]
+func (h *SubHandler) deleteMemberHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateMemberRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	if err = h.subSvc.DeleteMember(r.Context(), req.SubID, req.UserID); err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary 	Get settlement of user's shared subscriptions
+// @Description Для каждого месяца периода (from и to включительно, по умолчанию - текущий месяц) возвращаются долги
+// @Description между пользователем и владельцами или участниками общих с ним подписок. Доли считаются так же,
+// @Description как в суммарной стоимости: по цене, действовавшей в месяце, с учетом пробного периода и приостановок.
+// @Description Взаимные долги каждой пары пользователей за месяц взаимозачитываются.
+// @Tags 		summary
+// @Produce 	json
+// @Param 		user_id 		query 	string true "User's id"
+// @Param 		from 			query 	string false "Period start (MM-YYYY)"
+// @Param 		to 				query 	string false "Period end (MM-YYYY)"
+// @Success 	200 {object} 			domain.Settlement "Successfully got settlement"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router 		/subs/settlement 		[get]
+func (h *SubHandler) getSettlementHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateGetSettlementRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.subSvc.GetSettlement(r.Context(), req.UserID, req.From, req.To)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
 	response.WriteResponse(w, res, http.StatusOK)
 }
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"subs-service/internal/domain"
+	"subs-service/pkg/http/handlers"
+	pkgMiddleware "subs-service/pkg/http/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// WithTenant passes the tenant of authenticated requests down to repositories,
+// which let requests access data of their tenant only.
+func WithTenant(cfg pkgMiddleware.TenantConfig) handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tenant, err := pkgMiddleware.ResolveTenant(r.Context(), r.Header.Get(cfg.Header), cfg)
+				if err != nil {
+					code := http.StatusBadRequest
+					if errors.Is(err, pkgMiddleware.ErrTenantMismatch) {
+						code = http.StatusForbidden
+					}
+
+					http.Error(w, err.Error(), code)
+
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(domain.WithTenant(r.Context(), tenant)))
+			})
+		})
+	}
+}
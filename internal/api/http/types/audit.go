@@ -0,0 +1,111 @@
+package types
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// Requests ----------------------------------------------------------------------
+
+type GetSubHistoryRequest struct {
+	ID uuid.UUID
+}
+
+func CreateGetSubHistoryRequest(r *http.Request) (*GetSubHistoryRequest, error) {
+	const op = "CreateGetSubHistoryRequest"
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &GetSubHistoryRequest{ID: id}, nil
+}
+
+type ListAuditRequest struct {
+	Opts domain.AuditFilterOpts
+}
+
+func parseOptionalTime(value string) (time.Time, error) {
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+func CreateListAuditRequest(r *http.Request, cfg config.DataConfig) (*ListAuditRequest, error) {
+	const op = "CreateListAuditRequest"
+
+	query := r.URL.Query()
+	req := ListAuditRequest{
+		Opts: domain.AuditFilterOpts{
+			Actor:    query.Get("actor"),
+			PageSize: cfg.DefaultPageSize,
+		},
+	}
+
+	var err error
+
+	if req.Opts.From, err = parseOptionalTime(query.Get("from")); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if req.Opts.To, err = parseOptionalTime(query.Get("to")); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !req.Opts.From.IsZero() && !req.Opts.To.IsZero() && !req.Opts.From.Before(req.Opts.To) {
+		return nil, fmt.Errorf("%s: %w", op, ErrBadTimeRange)
+	}
+
+	if userID := query.Get("user_id"); len(userID) != 0 {
+		if req.Opts.UserID, err = uuid.Parse(userID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if subID := query.Get("sub_id"); len(subID) != 0 {
+		if req.Opts.SubID, err = uuid.Parse(subID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	var pageSize int
+	if pageSize, err = strconv.Atoi(query.Get("page_size")); err == nil && pageSize > 0 && CheckPageSize(pageSize, cfg) {
+		req.Opts.PageSize = pageSize
+	}
+
+	var pageToken int64
+	if pageToken, err = strconv.ParseInt(query.Get("page_token"), 10, 64); err == nil {
+		req.Opts.PageToken = pageToken
+	}
+
+	return &req, nil
+}
+
+// Responses ---------------------------------------------------------------------
+
+type ListAuditResponse struct {
+	Entries       []*domain.AuditEntry `json:"entries"`
+	NextPageToken string               `json:"next_page_token,omitempty"`
+}
+
+func CreateListAuditResponse(entries []*domain.AuditEntry) *ListAuditResponse {
+	var token string
+	if len(entries) != 0 {
+		token = strconv.FormatInt(entries[len(entries)-1].ID, 10)
+	}
+
+	return &ListAuditResponse{
+		Entries:       entries,
+		NextPageToken: token,
+	}
+}
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	maxBudgetThreshold = 1000
+)
+
+func checkBudget(budget *domain.Budget, cfg config.DataConfig) error {
+	if budget.Amount <= 0 {
+		return ErrBadBudgetAmount
+	}
+
+	if len(budget.ServiceName) != 0 && !checkServiceName(budget.ServiceName, cfg) {
+		return ErrBadServiceNameLength
+	}
+
+	if len(budget.Category) > maxCategoryLength {
+		return ErrBadCategoryLength
+	}
+
+	for _, threshold := range budget.Thresholds {
+		if threshold <= 0 || threshold > maxBudgetThreshold {
+			return ErrBadBudgetThreshold
+		}
+	}
+
+	return nil
+}
+
+// Requests ----------------------------------------------------------------------
+
+type PostBudgetRequest struct {
+	Budget domain.Budget
+}
+
+func CreatePostBudgetRequest(r *http.Request, cfg config.DataConfig) (*PostBudgetRequest, error) {
+	const op = "CreatePostBudgetRequest"
+
+	var req PostBudgetRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Budget); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if req.Budget.UserID == uuid.Nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrNoUserID)
+	}
+
+	if err := checkBudget(&req.Budget, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+type BudgetIDRequest struct {
+	ID uuid.UUID
+}
+
+func CreateBudgetIDRequest(r *http.Request) (*BudgetIDRequest, error) {
+	const op = "CreateBudgetIDRequest"
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &BudgetIDRequest{ID: id}, nil
+}
+
+type ListBudgetsRequest struct {
+	UserID uuid.UUID
+}
+
+func CreateListBudgetsRequest(r *http.Request) (*ListBudgetsRequest, error) {
+	const op = "CreateListBudgetsRequest"
+
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &ListBudgetsRequest{UserID: userID}, nil
+}
+
+type PutBudgetRequest struct {
+	ID     uuid.UUID
+	Budget domain.Budget
+}
+
+func CreatePutBudgetRequest(r *http.Request, cfg config.DataConfig) (*PutBudgetRequest, error) {
+	const op = "CreatePutBudgetRequest"
+
+	var req PutBudgetRequest
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.ID = id
+
+	if err = json.NewDecoder(r.Body).Decode(&req.Budget); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = checkBudget(&req.Budget, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+// Responses ---------------------------------------------------------------------
+
+type DeleteBudgetResponse struct {
+	DeletedID string `json:"deleted_id"`
+}
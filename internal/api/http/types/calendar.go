@@ -0,0 +1,67 @@
+package types
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// Requests ----------------------------------------------------------------------
+
+type UserIDRequest struct {
+	UserID uuid.UUID
+}
+
+func CreateUserIDRequest(r *http.Request) (*UserIDRequest, error) {
+	const op = "CreateUserIDRequest"
+
+	userID, err := uuid.Parse(chi.URLParam(r, "user_id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &UserIDRequest{UserID: userID}, nil
+}
+
+type GetCalendarRequest struct {
+	UserID uuid.UUID
+	Token  string
+}
+
+// CreateGetCalendarRequest takes the feed token from the query, since calendar clients
+// subscribe to the plain URL and can not send auth headers.
+func CreateGetCalendarRequest(r *http.Request) (*GetCalendarRequest, error) {
+	const op = "CreateGetCalendarRequest"
+
+	userID, err := uuid.Parse(chi.URLParam(r, "user_id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &GetCalendarRequest{
+		UserID: userID,
+		Token:  r.URL.Query().Get("token"),
+	}, nil
+}
+
+// Responses ---------------------------------------------------------------------
+
+type FeedTokenResponse struct {
+	Token string `json:"token"`
+	// URL of the feed relative to the host, it already contains the token.
+	URL string `json:"url"`
+}
+
+func CreateFeedTokenResponse(apiPath, feedPath string, userID uuid.UUID, token string) FeedTokenResponse {
+	feedPath = strings.Replace(feedPath, "{user_id}", userID.String(), 1)
+
+	return FeedTokenResponse{
+		Token: token,
+		URL:   path.Join(apiPath, feedPath) + "?" + url.Values{"token": {token}}.Encode(),
+	}
+}
@@ -0,0 +1,129 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	maxCategoryLength = 50
+	maxTagLength      = 50
+)
+
+var currencyRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// checkService sets the default currency, if it is empty.
+func checkService(service *domain.Service, cfg config.DataConfig) error {
+	if !checkServiceName(strings.TrimSpace(service.Name), cfg) {
+		return ErrBadServiceNameLength
+	}
+
+	for _, alias := range service.Aliases {
+		if !checkServiceName(strings.TrimSpace(alias), cfg) {
+			return fmt.Errorf("%w: %q", ErrBadServiceAlias, alias)
+		}
+	}
+
+	if service.Aliases == nil {
+		service.Aliases = []string{}
+	}
+
+	if len(service.Category) > maxCategoryLength {
+		return ErrBadCategoryLength
+	}
+
+	if len(service.Currency) == 0 {
+		service.Currency = domain.DefaultCurrency
+	} else if !currencyRegexp.MatchString(service.Currency) {
+		return ErrBadCurrency
+	}
+
+	if len(service.Website) != 0 {
+		u, err := url.Parse(service.Website)
+		if err != nil || !u.IsAbs() || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return ErrBadWebsiteURL
+		}
+	}
+
+	return nil
+}
+
+// Requests ----------------------------------------------------------------------
+
+type PostServiceRequest struct {
+	Service domain.Service
+}
+
+func CreatePostServiceRequest(r *http.Request, cfg config.DataConfig) (*PostServiceRequest, error) {
+	const op = "CreatePostServiceRequest"
+
+	var req PostServiceRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Service); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := checkService(&req.Service, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+type ServiceIDRequest struct {
+	ID uuid.UUID
+}
+
+func CreateServiceIDRequest(r *http.Request) (*ServiceIDRequest, error) {
+	const op = "CreateServiceIDRequest"
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &ServiceIDRequest{ID: id}, nil
+}
+
+type PutServiceRequest struct {
+	ID      uuid.UUID
+	Service domain.Service
+}
+
+func CreatePutServiceRequest(r *http.Request, cfg config.DataConfig) (*PutServiceRequest, error) {
+	const op = "CreatePutServiceRequest"
+
+	var req PutServiceRequest
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.ID = id
+
+	if err = json.NewDecoder(r.Body).Decode(&req.Service); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = checkService(&req.Service, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+// Responses ---------------------------------------------------------------------
+
+type DeleteServiceResponse struct {
+	DeletedID string `json:"deleted_id"`
+}
@@ -3,6 +3,26 @@ package types
 import "errors"
 
 var (
-	ErrBadPriceValue = errors.New("bad price value, must be positive and less than max")
+	ErrBadPriceValue        = errors.New("bad price value, must be positive and less than max")
 	ErrBadServiceNameLength = errors.New("bad service name length (must be non zero and less than max)")
+	ErrBadTimeRange         = errors.New("bad time range, start must be before end")
+	ErrBadTrial             = errors.New("bad trial, length must be non negative and less than max, promo price requires trial")
+	ErrBadWebhookURL        = errors.New("bad webhook url, must be absolute http(s) url")
+	ErrBadWebhookSecret     = errors.New("bad webhook secret, must be non empty")
+	ErrBadWebhookEvent      = errors.New("bad webhook event")
+	ErrBadDeliveryStatus    = errors.New("bad delivery status")
+	ErrBadForecastMonths    = errors.New("bad forecast months, must be positive and not greater than max")
+	ErrNoUserID             = errors.New("user id is required")
+	ErrBadBudgetAmount      = errors.New("bad budget amount, must be positive")
+	ErrBadBudgetThreshold   = errors.New("bad budget threshold, must be positive percent not greater than 1000")
+	ErrBadServiceAlias      = errors.New("bad service alias length (must be non zero and less than max)")
+	ErrBadCategoryLength    = errors.New("bad category length, must be less than max")
+	ErrBadCurrency          = errors.New("bad currency, must be ISO 4217 code")
+	ErrBadWebsiteURL        = errors.New("bad website url, must be absolute http(s) url")
+	ErrBadTagLength         = errors.New("bad tag length (must be non zero and less than max)")
+	ErrTooManyTags          = errors.New("too many tags, must be not greater than max")
+	ErrBadTagsMatch         = errors.New("bad tags match, must be any or all")
+	ErrBadGroupBy           = errors.New("bad group by, must be service, category or tag")
+	ErrNoMergedTags         = errors.New("tags to merge are required")
+	ErrBadMemberShare       = errors.New("bad member share, either weight (not greater than 100) or amount (not greater than max price) must be positive")
 )
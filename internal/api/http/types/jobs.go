@@ -0,0 +1,26 @@
+package types
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// Requests ----------------------------------------------------------------------
+
+type JobIDRequest struct {
+	ID uuid.UUID
+}
+
+func CreateJobIDRequest(r *http.Request) (*JobIDRequest, error) {
+	const op = "CreateJobIDRequest"
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &JobIDRequest{ID: id}, nil
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	maxMemberWeight = 100
+)
+
+// checkMemberShare requires either weight or fixed amount of the member's share.
+func checkMemberShare(member *domain.Member, cfg config.DataConfig) bool {
+	if member.Weight != 0 {
+		return member.Amount == 0 && member.Weight > 0 && member.Weight <= maxMemberWeight
+	}
+
+	return checkPrice(member.Amount, cfg)
+}
+
+// Requests ----------------------------------------------------------------------
+
+type ListMembersRequest struct {
+	SubID uuid.UUID
+}
+
+func CreateListMembersRequest(r *http.Request) (*ListMembersRequest, error) {
+	const op = "CreateListMembersRequest"
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &ListMembersRequest{SubID: id}, nil
+}
+
+type MemberRequest struct {
+	SubID  uuid.UUID
+	UserID uuid.UUID
+}
+
+func CreateMemberRequest(r *http.Request) (*MemberRequest, error) {
+	const op = "CreateMemberRequest"
+
+	var req MemberRequest
+
+	var err error
+
+	req.SubID, err = uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.UserID, err = uuid.Parse(chi.URLParam(r, "user_id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+type PutMemberRequest struct {
+	Member domain.Member
+}
+
+func CreatePutMemberRequest(r *http.Request, cfg config.DataConfig) (*PutMemberRequest, error) {
+	const op = "CreatePutMemberRequest"
+
+	var req PutMemberRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Member); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ids, err := CreateMemberRequest(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.Member.SubID, req.Member.UserID = ids.SubID, ids.UserID
+
+	if !checkMemberShare(&req.Member, cfg) {
+		return nil, fmt.Errorf("%s: %w", op, ErrBadMemberShare)
+	}
+
+	return &req, nil
+}
+
+type GetSettlementRequest struct {
+	UserID uuid.UUID
+	From   time.Time
+	To     time.Time
+}
+
+// CreateGetSettlementRequest defaults the period to the current month.
+func CreateGetSettlementRequest(r *http.Request) (*GetSettlementRequest, error) {
+	const op = "CreateGetSettlementRequest"
+
+	var req GetSettlementRequest
+
+	var err error
+
+	req.UserID, err = uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if req.From, req.To, err = ParsePeriod(r.URL.Query().Get("from"), r.URL.Query().Get("to")); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if req.From.IsZero() {
+		req.From = domain.StartOfMonth(time.Now())
+		req.To = req.From
+	}
+
+	return &req, nil
+}
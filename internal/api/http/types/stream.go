@@ -0,0 +1,45 @@
+package types
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// Requests ----------------------------------------------------------------------
+
+type StreamSubsRequest struct {
+	UserID      uuid.UUID
+	LastEventID int64
+}
+
+// CreateStreamSubsRequest takes the last event id from the Last-Event-ID header sent by reconnecting
+// EventSource or from the last_event_id query parameter, which is used by clients unable to set headers.
+func CreateStreamSubsRequest(r *http.Request) (*StreamSubsRequest, error) {
+	const op = "CreateStreamSubsRequest"
+
+	var (
+		req StreamSubsRequest
+		err error
+	)
+
+	req.UserID, err = uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if len(lastEventID) == 0 {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	if len(lastEventID) != 0 {
+		if req.LastEventID, err = strconv.ParseInt(lastEventID, 10, 64); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return &req, nil
+}
@@ -5,10 +5,15 @@ import (
 	"fmt"
 	"net/http"
 	"path"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 	"subs-service/internal/config"
 	"subs-service/internal/domain"
+	"subs-service/pkg/http/middleware"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
@@ -20,14 +25,136 @@ func checkServiceName(name string, cfg config.DataConfig) bool {
 	return len(name) > 0 && len(name) <= cfg.MaxServiceNameLength
 }
 
-func checkPageSize(size int, cfg config.DataConfig) bool {
+// checkTrial allows promotional price only within the trial.
+func checkTrial(sub *domain.Sub, cfg config.DataConfig) bool {
+	if sub.TrialMonths < 0 || sub.TrialMonths > cfg.MaxTrialMonths {
+		return false
+	}
+
+	return sub.PromoPrice == 0 || (sub.TrialMonths > 0 && checkPrice(sub.PromoPrice, cfg))
+}
+
+// normalizeTags trims tags, sorts them the same way they are stored and drops duplicates.
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		normalized = append(normalized, strings.TrimSpace(tag))
+	}
+
+	slices.Sort(normalized)
+
+	return slices.Compact(normalized)
+}
+
+func checkTag(tag string) bool {
+	return len(tag) > 0 && len(tag) <= maxTagLength
+}
+
+// checkTags normalizes tags before the check.
+func checkTags(tags []string, cfg config.DataConfig) ([]string, error) {
+	tags = normalizeTags(tags)
+
+	if len(tags) > cfg.MaxTags {
+		return nil, ErrTooManyTags
+	}
+
+	for _, tag := range tags {
+		if !checkTag(tag) {
+			return nil, fmt.Errorf("%w: %q", ErrBadTagLength, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+// CheckSub validates subscription's data, it is shared by all APIs.
+func CheckSub(sub *domain.Sub, cfg config.DataConfig) error {
+	if !checkPrice(sub.Price, cfg) {
+		return ErrBadPriceValue
+	} else if !checkServiceName(sub.ServiceName, cfg) {
+		return ErrBadServiceNameLength
+	} else if !checkTrial(sub, cfg) {
+		return ErrBadTrial
+	}
+
+	sub.Category = strings.TrimSpace(sub.Category)
+	if len(sub.Category) > maxCategoryLength {
+		return ErrBadCategoryLength
+	}
+
+	tags, err := checkTags(sub.Tags, cfg)
+	if err != nil {
+		return err
+	}
+
+	sub.Tags = tags
+
+	return nil
+}
+
+func CheckPageSize(size int, cfg config.DataConfig) bool {
 	return size >= 0 && size <= cfg.MaxPageSize
 }
 
+// includeDeleted shows deleted subscriptions to admins only.
+func includeDeleted(r *http.Request) bool {
+	flag, err := strconv.ParseBool(r.URL.Query().Get("include_deleted"))
+	return err == nil && flag && middleware.IsAdmin(r.Context())
+}
+
+// ParsePeriod parses optional months period, both bounds must be set together.
+func ParsePeriod(fromStr, toStr string) (time.Time, time.Time, error) {
+	if len(fromStr) == 0 && len(toStr) == 0 {
+		return time.Time{}, time.Time{}, nil
+	}
+
+	from, err := time.Parse(domain.TimeLayout, fromStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	to, err := time.Parse(domain.TimeLayout, toStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, ErrBadTimeRange
+	}
+
+	return from, to, nil
+}
+
+// parseSubsFilter parses filters shared by subscriptions' list and summary:
+// category and comma separated tags, which are matched by any of them by default.
+func parseSubsFilter(r *http.Request, opts *domain.FilterOpts, cfg config.DataConfig) error {
+	opts.Category = strings.TrimSpace(r.URL.Query().Get("category"))
+
+	if tags := r.URL.Query().Get("tags"); len(tags) != 0 {
+		var err error
+		if opts.Tags, err = checkTags(strings.Split(tags, ","), cfg); err != nil {
+			return err
+		}
+	}
+
+	switch match := domain.TagsMatch(r.URL.Query().Get("tags_match")); match {
+	case "":
+		opts.TagsMatch = domain.TagsMatchAny
+	case domain.TagsMatchAny, domain.TagsMatchAll:
+		opts.TagsMatch = match
+	default:
+		return ErrBadTagsMatch
+	}
+
+	return nil
+}
+
 // Requests ----------------------------------------------------------------------
 
 type GetSubRequest struct {
-	ID uuid.UUID
+	ID   uuid.UUID
+	Opts domain.GetOpts
 }
 
 func CreateGetSubRequest(r *http.Request) (*GetSubRequest, error) {
@@ -38,7 +165,10 @@ func CreateGetSubRequest(r *http.Request) (*GetSubRequest, error) {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &GetSubRequest{ID: id}, nil
+	return &GetSubRequest{
+		ID:   id,
+		Opts: domain.GetOpts{IncludeDeleted: includeDeleted(r)},
+	}, nil
 }
 
 type PostSubRequest struct {
@@ -54,18 +184,17 @@ func CreatePostSubRequest(r *http.Request, cfg config.DataConfig) (*PostSubReque
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if !checkPrice(req.Sub.Price, cfg) {
-		return nil, fmt.Errorf("%s: %w", op, ErrBadPriceValue)
-	} else if !checkServiceName(req.Sub.ServiceName, cfg) {
-		return nil, fmt.Errorf("%s: %w", op, ErrBadServiceNameLength)
+	if err := CheckSub(&req.Sub, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return &req, nil
 }
 
 type PutSubRequest struct {
-	ID  uuid.UUID
-	Sub domain.Sub
+	ID   uuid.UUID
+	Sub  domain.Sub
+	Opts domain.PutOpts
 }
 
 func CreatePutSubRequest(r *http.Request, cfg config.DataConfig) (*PutSubRequest, error) {
@@ -80,14 +209,18 @@ func CreatePutSubRequest(r *http.Request, cfg config.DataConfig) (*PutSubRequest
 
 	req.ID = id
 
+	if effectiveFrom := r.URL.Query().Get("price_effective_from"); len(effectiveFrom) != 0 {
+		if req.Opts.PriceEffectiveFrom, err = time.Parse(domain.TimeLayout, effectiveFrom); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
 	if err = json.NewDecoder(r.Body).Decode(&req.Sub); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if !checkPrice(req.Sub.Price, cfg) {
-		return nil, fmt.Errorf("%s: %w", op, ErrBadPriceValue)
-	} else if !checkServiceName(req.Sub.ServiceName, cfg) {
-		return nil, fmt.Errorf("%s: %w", op, ErrBadServiceNameLength)
+	if err = CheckSub(&req.Sub, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return &req, nil
@@ -108,6 +241,120 @@ func CreateDeleteSubRequest(r *http.Request) (*DeleteSubRequest, error) {
 	return &DeleteSubRequest{ID: id}, nil
 }
 
+type RestoreSubRequest struct {
+	ID uuid.UUID
+}
+
+func CreateRestoreSubRequest(r *http.Request) (*RestoreSubRequest, error) {
+	const op = "CreateRestoreSubRequest"
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &RestoreSubRequest{ID: id}, nil
+}
+
+type AddPriceChangeRequest struct {
+	Change domain.PriceChange
+}
+
+func CreateAddPriceChangeRequest(r *http.Request, cfg config.DataConfig) (*AddPriceChangeRequest, error) {
+	const op = "CreateAddPriceChangeRequest"
+
+	var req AddPriceChangeRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Change); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var err error
+
+	req.Change.SubID, err = uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !checkPrice(req.Change.Price, cfg) {
+		return nil, fmt.Errorf("%s: %w", op, ErrBadPriceValue)
+	}
+
+	return &req, nil
+}
+
+type ListPriceChangesRequest struct {
+	SubID uuid.UUID
+}
+
+func CreateListPriceChangesRequest(r *http.Request) (*ListPriceChangesRequest, error) {
+	const op = "CreateListPriceChangesRequest"
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &ListPriceChangesRequest{SubID: id}, nil
+}
+
+type PauseSubRequest struct {
+	Pause domain.Pause
+}
+
+func CreatePauseSubRequest(r *http.Request) (*PauseSubRequest, error) {
+	const op = "CreatePauseSubRequest"
+
+	var req PauseSubRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Pause); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var err error
+
+	req.Pause.SubID, err = uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !req.Pause.To.IsZero() && req.Pause.To.Before(req.Pause.From) {
+		return nil, fmt.Errorf("%s: %w", op, ErrBadTimeRange)
+	}
+
+	return &req, nil
+}
+
+type ResumeSubRequest struct {
+	ID uuid.UUID
+}
+
+func CreateResumeSubRequest(r *http.Request) (*ResumeSubRequest, error) {
+	const op = "CreateResumeSubRequest"
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &ResumeSubRequest{ID: id}, nil
+}
+
+type ListPausesRequest struct {
+	SubID uuid.UUID
+}
+
+func CreateListPausesRequest(r *http.Request) (*ListPausesRequest, error) {
+	const op = "CreateListPausesRequest"
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &ListPausesRequest{SubID: id}, nil
+}
+
 type ListSubsRequest struct {
 	Opts domain.FilterOpts
 }
@@ -117,8 +364,9 @@ func CreateListSubsRequest(r *http.Request, cfg config.DataConfig) (*ListSubsReq
 
 	req := ListSubsRequest{
 		Opts: domain.FilterOpts{
-			PageSize:  cfg.DefaultPageSize,
-			PageToken: uuid.Nil,
+			PageSize:       cfg.DefaultPageSize,
+			PageToken:      uuid.Nil,
+			IncludeDeleted: includeDeleted(r),
 		},
 	}
 
@@ -134,7 +382,7 @@ func CreateListSubsRequest(r *http.Request, cfg config.DataConfig) (*ListSubsReq
 	}
 
 	var pageSize int
-	if pageSize, err = strconv.Atoi(r.URL.Query().Get("page_size")); err == nil && checkPageSize(pageSize, cfg) {
+	if pageSize, err = strconv.Atoi(r.URL.Query().Get("page_size")); err == nil && CheckPageSize(pageSize, cfg) {
 		req.Opts.PageSize = pageSize
 	}
 
@@ -143,6 +391,16 @@ func CreateListSubsRequest(r *http.Request, cfg config.DataConfig) (*ListSubsReq
 		req.Opts.PageToken = pageToken
 	}
 
+	if trialEndsWithin := r.URL.Query().Get("trial_ends_within"); len(trialEndsWithin) != 0 {
+		if req.Opts.TrialEndsWithin, err = strconv.Atoi(trialEndsWithin); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = parseSubsFilter(r, &req.Opts, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return &req, nil
 }
 
@@ -153,7 +411,12 @@ type GetSummaryRequest struct {
 func CreateGetSummaryRequest(r *http.Request, cfg config.DataConfig) (*GetSummaryRequest, error) {
 	const op = "CreateGetSummaryRequest"
 
-	var req GetSummaryRequest
+	req := GetSummaryRequest{
+		Opts: domain.FilterOpts{
+			IncludeDeleted: includeDeleted(r),
+		},
+	}
+
 	var err error
 
 	req.Opts.UserID, err = uuid.Parse(r.URL.Query().Get("user_id"))
@@ -165,9 +428,69 @@ func CreateGetSummaryRequest(r *http.Request, cfg config.DataConfig) (*GetSummar
 		req.Opts.ServiceName = serviceName
 	}
 
+	if req.Opts.From, req.Opts.To, err = ParsePeriod(r.URL.Query().Get("from"), r.URL.Query().Get("to")); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = parseSubsFilter(r, &req.Opts, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch groupBy := domain.GroupBy(r.URL.Query().Get("group_by")); groupBy {
+	case "", domain.GroupByService, domain.GroupByCategory, domain.GroupByTag:
+		req.Opts.GroupBy = groupBy
+	default:
+		return nil, fmt.Errorf("%s: %w", op, ErrBadGroupBy)
+	}
+
+	return &req, nil
+}
+
+type GetForecastRequest struct {
+	UserID uuid.UUID
+	Months int
+}
+
+func CreateGetForecastRequest(r *http.Request, cfg config.DataConfig) (*GetForecastRequest, error) {
+	const op = "CreateGetForecastRequest"
+
+	req := GetForecastRequest{Months: cfg.DefaultForecastMonths}
+
+	var err error
+
+	req.UserID, err = uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if months := r.URL.Query().Get("months"); len(months) != 0 {
+		if req.Months, err = strconv.Atoi(months); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if req.Months <= 0 || req.Months > cfg.MaxForecastMonths {
+		return nil, fmt.Errorf("%s: %w", op, ErrBadForecastMonths)
+	}
+
 	return &req, nil
 }
 
+type ListDuplicatesRequest struct {
+	UserID uuid.UUID
+}
+
+func CreateListDuplicatesRequest(r *http.Request) (*ListDuplicatesRequest, error) {
+	const op = "CreateListDuplicatesRequest"
+
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &ListDuplicatesRequest{UserID: userID}, nil
+}
+
 // Responses ---------------------------------------------------------------------
 
 type ListSubsResponse struct {
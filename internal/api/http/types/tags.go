@@ -0,0 +1,88 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// Requests ----------------------------------------------------------------------
+
+type RenameTagRequest struct {
+	UserID uuid.UUID `json:"-"`
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+}
+
+func CreateRenameTagRequest(r *http.Request) (*RenameTagRequest, error) {
+	const op = "CreateRenameTagRequest"
+
+	var req RenameTagRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var err error
+
+	req.UserID, err = uuid.Parse(chi.URLParam(r, "user_id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.From, req.To = strings.TrimSpace(req.From), strings.TrimSpace(req.To)
+
+	if !checkTag(req.From) || !checkTag(req.To) {
+		return nil, fmt.Errorf("%s: %w", op, ErrBadTagLength)
+	}
+
+	return &req, nil
+}
+
+type MergeTagsRequest struct {
+	UserID  uuid.UUID `json:"-"`
+	Sources []string  `json:"sources"`
+	Target  string    `json:"target"`
+}
+
+// CreateMergeTagsRequest drops the target from the sources, at least one other source is required.
+func CreateMergeTagsRequest(r *http.Request) (*MergeTagsRequest, error) {
+	const op = "CreateMergeTagsRequest"
+
+	var req MergeTagsRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var err error
+
+	req.UserID, err = uuid.Parse(chi.URLParam(r, "user_id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.Target = strings.TrimSpace(req.Target)
+	if !checkTag(req.Target) {
+		return nil, fmt.Errorf("%s: %w", op, ErrBadTagLength)
+	}
+
+	req.Sources = slices.DeleteFunc(normalizeTags(req.Sources), func(tag string) bool { return tag == req.Target })
+
+	if len(req.Sources) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrNoMergedTags)
+	}
+
+	for _, tag := range req.Sources {
+		if !checkTag(tag) {
+			return nil, fmt.Errorf("%s: %w: %q", op, ErrBadTagLength, tag)
+		}
+	}
+
+	return &req, nil
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"subs-service/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// Requests ----------------------------------------------------------------------
+
+type GetDataExportRequest struct {
+	UserID uuid.UUID
+	ID     uuid.UUID
+}
+
+func CreateGetDataExportRequest(r *http.Request) (*GetDataExportRequest, error) {
+	const op = "CreateGetDataExportRequest"
+
+	userID, err := uuid.Parse(chi.URLParam(r, "user_id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "export_id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &GetDataExportRequest{UserID: userID, ID: id}, nil
+}
+
+// Responses ---------------------------------------------------------------------
+
+// DataExportLocation is the path of the export's status relative to the host.
+func DataExportLocation(apiPath, exportPath string, export *domain.DataExport) string {
+	exportPath = strings.Replace(exportPath, "{user_id}", export.UserID.String(), 1)
+	exportPath = strings.Replace(exportPath, "{export_id}", export.ID.String(), 1)
+
+	return path.Join(apiPath, exportPath)
+}
@@ -0,0 +1,189 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// checkWebhook requires secret only on creation, on update the previous one is kept if it is empty.
+func checkWebhook(webhook *domain.Webhook, requireSecret bool) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || !u.IsAbs() || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return ErrBadWebhookURL
+	}
+
+	if requireSecret && len(webhook.Secret) == 0 {
+		return ErrBadWebhookSecret
+	}
+
+	for _, event := range webhook.Events {
+		if !slices.Contains(domain.WebhookEvents, event) {
+			return fmt.Errorf("%w: %s", ErrBadWebhookEvent, event)
+		}
+	}
+
+	if webhook.Events == nil {
+		webhook.Events = []domain.WebhookEvent{}
+	}
+
+	return nil
+}
+
+// Requests ----------------------------------------------------------------------
+
+type PostWebhookRequest struct {
+	Webhook domain.Webhook
+}
+
+func CreatePostWebhookRequest(r *http.Request) (*PostWebhookRequest, error) {
+	const op = "CreatePostWebhookRequest"
+
+	var req PostWebhookRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Webhook); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := checkWebhook(&req.Webhook, true); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+type WebhookIDRequest struct {
+	ID uuid.UUID
+}
+
+func CreateWebhookIDRequest(r *http.Request) (*WebhookIDRequest, error) {
+	const op = "CreateWebhookIDRequest"
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &WebhookIDRequest{ID: id}, nil
+}
+
+type PutWebhookRequest struct {
+	ID      uuid.UUID
+	Webhook domain.Webhook
+}
+
+func CreatePutWebhookRequest(r *http.Request) (*PutWebhookRequest, error) {
+	const op = "CreatePutWebhookRequest"
+
+	var req PutWebhookRequest
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.ID = id
+
+	if err = json.NewDecoder(r.Body).Decode(&req.Webhook); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = checkWebhook(&req.Webhook, false); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+type ListDeliveriesRequest struct {
+	Opts domain.DeliveryFilterOpts
+}
+
+func CreateListDeliveriesRequest(r *http.Request, cfg config.DataConfig) (*ListDeliveriesRequest, error) {
+	const op = "CreateListDeliveriesRequest"
+
+	query := r.URL.Query()
+	req := ListDeliveriesRequest{
+		Opts: domain.DeliveryFilterOpts{
+			Status:   domain.DeliveryStatus(query.Get("status")),
+			PageSize: cfg.DefaultPageSize,
+		},
+	}
+
+	var err error
+
+	if req.Opts.WebhookID, err = uuid.Parse(chi.URLParam(r, "id")); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch req.Opts.Status {
+	case "", domain.DeliveryPending, domain.DeliveryDelivered, domain.DeliveryDead:
+	default:
+		return nil, fmt.Errorf("%s: %w", op, ErrBadDeliveryStatus)
+	}
+
+	var pageSize int
+	if pageSize, err = strconv.Atoi(query.Get("page_size")); err == nil && pageSize > 0 && CheckPageSize(pageSize, cfg) {
+		req.Opts.PageSize = pageSize
+	}
+
+	var pageToken int64
+	if pageToken, err = strconv.ParseInt(query.Get("page_token"), 10, 64); err == nil {
+		req.Opts.PageToken = pageToken
+	}
+
+	return &req, nil
+}
+
+type RedeliverDeliveryRequest struct {
+	WebhookID uuid.UUID
+	ID        int64
+}
+
+func CreateRedeliverDeliveryRequest(r *http.Request) (*RedeliverDeliveryRequest, error) {
+	const op = "CreateRedeliverDeliveryRequest"
+
+	var req RedeliverDeliveryRequest
+	var err error
+
+	if req.WebhookID, err = uuid.Parse(chi.URLParam(r, "id")); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if req.ID, err = strconv.ParseInt(chi.URLParam(r, "delivery_id"), 10, 64); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+// Responses ---------------------------------------------------------------------
+
+type ListDeliveriesResponse struct {
+	Deliveries    []*domain.WebhookDelivery `json:"deliveries"`
+	NextPageToken string                    `json:"next_page_token,omitempty"`
+}
+
+func CreateListDeliveriesResponse(deliveries []*domain.WebhookDelivery) *ListDeliveriesResponse {
+	var token string
+	if len(deliveries) != 0 {
+		token = strconv.FormatInt(deliveries[len(deliveries)-1].ID, 10)
+	}
+
+	return &ListDeliveriesResponse{
+		Deliveries:    deliveries,
+		NextPageToken: token,
+	}
+}
+
+type DeleteWebhookResponse struct {
+	DeletedID string `json:"deleted_id"`
+}
@@ -0,0 +1,162 @@
+package http
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"subs-service/internal/api/http/response"
+	"subs-service/internal/api/http/types"
+	"subs-service/internal/config"
+	"subs-service/internal/usecases"
+	"subs-service/pkg/http/handlers"
+	pkgMiddleware "subs-service/pkg/http/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type UserDataHandler struct {
+	userDataSvc usecases.UserDataService
+	pathCfg     config.PathConfig
+	svcCfg      config.ServiceConfig
+}
+
+func NewUserDataHandler(
+	userDataSvc usecases.UserDataService,
+	pathCfg config.PathConfig,
+	svcCfg config.ServiceConfig,
+) *UserDataHandler {
+	return &UserDataHandler{
+		userDataSvc: userDataSvc,
+		pathCfg:     pathCfg,
+		svcCfg:      svcCfg,
+	}
+}
+
+// WithUserDataHandlers registers exports of users' data, erasure of all user's data is allowed to admins only.
+func (h *UserDataHandler) WithUserDataHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Post(h.pathCfg.RequestDataExport, h.requestDataExportHandler)
+		r.Get(h.pathCfg.GetDataExport, h.getDataExportHandler)
+		r.Get(h.pathCfg.GetDataArchive, h.getDataArchiveHandler)
+		r.With(pkgMiddleware.RequireAdmin).Delete(h.pathCfg.EraseUserData, h.eraseUserDataHandler)
+	}
+}
+
+// @Summary 	Request export of all user's data
+// @Description Запускает выгрузку всех данных пользователя: подписок (включая удаленные), истории их изменений,
+// @Description бюджетов и отправленных уведомлений. Архив формируется фоновой задачей (job_id), статус выгрузки
+// @Description доступен по адресу из заголовка Location. Если у пользователя уже есть незавершенная выгрузка,
+// @Description возвращается она.
+// @Tags 		users
+// @Produce 	json
+// @Param 		user_id 		path 	string true "User's id"
+// @Success 	202 {object} 			domain.DataExport "Successfully requested export"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/users/{user_id}/data-export	[post]
+func (h *UserDataHandler) requestDataExportHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateUserIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.userDataSvc.RequestExport(r.Context(), req.UserID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	w.Header().Set("Location", types.DataExportLocation(h.pathCfg.API, h.pathCfg.GetDataExport, res))
+	response.WriteResponse(w, res, http.StatusAccepted)
+}
+
+// @Summary 	Get status of user's data export
+// @Description Статус выгрузки совпадает со статусом ее задачи: pending, running, completed, failed
+// @Description (в поле error указывается причина) или cancelled. Выгрузки удаляются вместе с задачами
+// @Description через jobs.retention после завершения.
+// @Tags 		users
+// @Produce 	json
+// @Param 		user_id 		path 	string true "User's id"
+// @Param 		export_id 		path 	string true "Export's id"
+// @Success 	200 {object} 			domain.DataExport "Successfully got export"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/users/{user_id}/data-export/{export_id}	[get]
+func (h *UserDataHandler) getDataExportHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateGetDataExportRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.userDataSvc.GetExport(r.Context(), req.UserID, req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Download user's data
+// @Description ZIP-архив последней завершенной выгрузки данных пользователя. Каждый набор данных (subs, history,
+// @Description budgets, notifications) представлен в форматах JSON и CSV.
+// @Tags 		users
+// @Produce 	application/zip
+// @Param 		user_id 		path 	string true "User's id"
+// @Success 	200 {file} 				file "Successfully got archive"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/users/{user_id}/data-export	[get]
+func (h *UserDataHandler) getDataArchiveHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateUserIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	export, archive, err := h.userDataSvc.GetExportArchive(r.Context(), req.UserID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="user-data-%s.zip"`, export.ID))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err = w.Write(archive); err != nil {
+		log.Print("[ERROR] ", err.Error())
+	}
+}
+
+// @Summary 	Erase all user's data
+// @Description Удаляет в одной транзакции все данные пользователя: подписки (вместе с ценами, приостановками
+// @Description и участниками), участие в чужих подписках, теги, бюджеты, токен календаря, выгрузки,
+// @Description отправленные напоминания и события вебхуков. Записи журнала изменений обезличиваются
+// @Description (удаляются снимки подписок), удаление фиксируется записью с операцией erase.
+// @Description Вебхуки и поток изменений об удалении не оповещаются. Доступно только администраторам.
+// @Tags 		users
+// @Param 		user_id 		path 	string true "User's id"
+// @Success 	204
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/users/{user_id}	[delete]
+func (h *UserDataHandler) eraseUserDataHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateUserIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	if err = h.userDataSvc.EraseUserData(r.Context(), req.UserID); err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,241 @@
+package http
+
+import (
+	"net/http"
+	"subs-service/internal/api/http/response"
+	"subs-service/internal/api/http/types"
+	"subs-service/internal/config"
+	"subs-service/internal/usecases"
+	"subs-service/pkg/http/handlers"
+	pkgMiddleware "subs-service/pkg/http/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WebhookHandler struct {
+	webhookSvc usecases.WebhookService
+	pathCfg    config.PathConfig
+	svcCfg     config.ServiceConfig
+	dataCfg    config.DataConfig
+}
+
+func NewWebhookHandler(
+	webhookSvc usecases.WebhookService,
+	pathCfg config.PathConfig,
+	svcCfg config.ServiceConfig,
+	dataCfg config.DataConfig,
+) *WebhookHandler {
+	return &WebhookHandler{
+		webhookSvc: webhookSvc,
+		pathCfg:    pathCfg,
+		svcCfg:     svcCfg,
+		dataCfg:    dataCfg,
+	}
+}
+
+// WithWebhookHandlers registers webhooks management, which is available to admins only.
+func (h *WebhookHandler) WithWebhookHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(pkgMiddleware.RequireAdmin)
+
+			r.Post(h.pathCfg.PostWebhook, h.postWebhookHandler)
+			r.Get(h.pathCfg.GetWebhook, h.getWebhookHandler)
+			r.Get(h.pathCfg.ListWebhooks, h.listWebhooksHandler)
+			r.Put(h.pathCfg.PutWebhook, h.putWebhookHandler)
+			r.Delete(h.pathCfg.DeleteWebhook, h.deleteWebhookHandler)
+
+			r.Get(h.pathCfg.ListDeliveries, h.listDeliveriesHandler)
+			r.Post(h.pathCfg.RedeliverDelivery, h.redeliverDeliveryHandler)
+		})
+	}
+}
+
+// @Summary 	Register webhook (admin only)
+// @Description Вебхук получает POST запросы с событиями подписок: sub.created, sub.updated, sub.deleted, sub.restored, sub.ended.
+// @Description Если список events пуст, вебхук получает все события.
+// @Description Тело запроса подписывается HMAC-SHA256 с секретом вебхука: заголовок X-Webhook-Signature содержит
+// @Description sha256=hex(HMAC(secret, timestamp + "." + body)), где timestamp - значение заголовка X-Webhook-Timestamp.
+// @Description Секрет не возвращается в ответах.
+// @Tags 		webhooks
+// @Accept 		json
+// @Produce 	json
+// @Param 		webhook 		body 	domain.Webhook true "Webhook details"
+// @Success 	201 {object} 			domain.Webhook "Successfully registered webhook"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/webhooks				[post]
+func (h *WebhookHandler) postWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreatePostWebhookRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.webhookSvc.PostWebhook(r.Context(), &req.Webhook)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusCreated)
+}
+
+// @Summary 	Get webhook by id (admin only)
+// @Tags 		webhooks
+// @Produce 	json
+// @Param 		id 				path 	string true "Webhook's id"
+// @Success 	200 {object} 			domain.Webhook "Successfully got webhook"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/webhooks/{id}			[get]
+func (h *WebhookHandler) getWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateWebhookIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.webhookSvc.GetWebhook(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Get webhooks list (admin only)
+// @Tags 		webhooks
+// @Produce 	json
+// @Success 	200 {array} 			domain.Webhook "Successfully got webhooks"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/webhooks				[get]
+func (h *WebhookHandler) listWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := h.webhookSvc.ListWebhooks(r.Context())
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Update webhook by id (admin only)
+// @Description Если секрет не указан, сохраняется прежний.
+// @Tags 		webhooks
+// @Accept 		json
+// @Produce 	json
+// @Param 		id 				path 	string true "Webhook's id"
+// @Param 		webhook 		body 	domain.Webhook true "Webhook details"
+// @Success 	200 {object} 			domain.Webhook "Successfully updated webhook"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/webhooks/{id}			[put]
+func (h *WebhookHandler) putWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreatePutWebhookRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.webhookSvc.PutWebhook(r.Context(), req.ID, &req.Webhook)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
+
+// @Summary 	Delete webhook by id (admin only)
+// @Description Недоставленные события вебхука удаляются вместе с ним.
+// @Tags 		webhooks
+// @Produce 	json
+// @Param 		id 				path 	string true "Webhook's id"
+// @Success 	200 {object} 			types.DeleteWebhookResponse "Successfully deleted webhook"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/webhooks/{id}			[delete]
+func (h *WebhookHandler) deleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateWebhookIDRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.webhookSvc.DeleteWebhook(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, types.DeleteWebhookResponse{DeletedID: res.String()}, http.StatusOK)
+}
+
+// @Summary 	Get webhook's deliveries (admin only)
+// @Description Опционально поддерживается фильтрация по статусу доставки (pending, delivered, dead)
+// @Description и keyset пагинация аналогично списку подписок.
+// @Tags 		webhooks
+// @Produce 	json
+// @Param 		id 				path 	string true "Webhook's id"
+// @Param 		status 			query 	string false "Delivery status"
+// @Param 		page_size 		query 	int false "Page size"
+// @Param 		page_token 		query 	string false "Page token (for keyset pagination)"
+// @Success 	200 {object} 			types.ListDeliveriesResponse "Successfully got deliveries"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/webhooks/{id}/deliveries	[get]
+func (h *WebhookHandler) listDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateListDeliveriesRequest(r, h.dataCfg.ForTenant(r.Context()))
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.webhookSvc.ListDeliveries(r.Context(), req.Opts)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, types.CreateListDeliveriesResponse(res), http.StatusOK)
+}
+
+// @Summary 	Redeliver webhook's delivery (admin only)
+// @Description Доставка (в т.ч. dead) ставится в очередь на немедленную отправку с обнуленным счетчиком попыток.
+// @Tags 		webhooks
+// @Produce 	json
+// @Param 		id 				path 	string true "Webhook's id"
+// @Param 		delivery_id 	path 	int true "Delivery's id"
+// @Success 	200 {object} 			domain.WebhookDelivery "Successfully scheduled redelivery"
+// @Failure 	400 {string} 			string "Bad request"
+// @Failure 	403 {string} 			string "Forbidden"
+// @Failure 	404 {string} 			string "Object not found"
+// @Failure 	500 {string} 			string "Internal error"
+// @Router		/webhooks/{id}/deliveries/{delivery_id}/redeliver	[post]
+func (h *WebhookHandler) redeliverDeliveryHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateRedeliverDeliveryRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	res, err := h.webhookSvc.RedeliverDelivery(r.Context(), req.WebhookID, req.ID)
+	if err != nil {
+		response.ProcessError(w, err, h.svcCfg.DebugMode)
+		return
+	}
+
+	response.WriteResponse(w, res, http.StatusOK)
+}
@@ -1,8 +1,14 @@
 package config
 
 import (
+	"context"
+	"subs-service/internal/domain"
 	"subs-service/pkg/database/postgres"
+	grpcServer "subs-service/pkg/grpc/server"
+	"subs-service/pkg/http/middleware"
 	"subs-service/pkg/http/server"
+	"subs-service/pkg/notify"
+	"time"
 )
 
 type ServiceConfig struct {
@@ -10,10 +16,123 @@ type ServiceConfig struct {
 }
 
 type DataConfig struct {
-	MaxPrice             int64 `yaml:"max_price" env-default:"100000"`
-	MaxServiceNameLength int   `yaml:"max_service_name_length" env-default:"50"`
-	DefaultPageSize      int   `yaml:"default_page_size" env-default:"20"`
-	MaxPageSize          int   `yaml:"max_page_size" env-default:"100"`
+	MaxPrice              int64 `yaml:"max_price" env-default:"100000"`
+	MaxServiceNameLength  int   `yaml:"max_service_name_length" env-default:"50"`
+	DefaultPageSize       int   `yaml:"default_page_size" env-default:"20"`
+	MaxPageSize           int   `yaml:"max_page_size" env-default:"100"`
+	MaxTrialMonths        int   `yaml:"max_trial_months" env-default:"12"`
+	DefaultForecastMonths int   `yaml:"default_forecast_months" env-default:"12"`
+	MaxForecastMonths     int   `yaml:"max_forecast_months" env-default:"60"`
+	MaxTags               int   `yaml:"max_tags" env-default:"20"`
+
+	// Tenants override limits for partner apps, zero limits are not overridden.
+	Tenants map[string]DataConfig `yaml:"tenants"`
+}
+
+// ForTenant applies overrides of the tenant of the request.
+func (cfg DataConfig) ForTenant(ctx context.Context) DataConfig {
+	override, ok := cfg.Tenants[domain.TenantFromContext(ctx)]
+	if !ok {
+		return cfg
+	}
+
+	merge := func(value *int, override int) {
+		if override != 0 {
+			*value = override
+		}
+	}
+
+	if override.MaxPrice != 0 {
+		cfg.MaxPrice = override.MaxPrice
+	}
+
+	merge(&cfg.MaxServiceNameLength, override.MaxServiceNameLength)
+	merge(&cfg.DefaultPageSize, override.DefaultPageSize)
+	merge(&cfg.MaxPageSize, override.MaxPageSize)
+	merge(&cfg.MaxTrialMonths, override.MaxTrialMonths)
+	merge(&cfg.DefaultForecastMonths, override.DefaultForecastMonths)
+	merge(&cfg.MaxForecastMonths, override.MaxForecastMonths)
+	merge(&cfg.MaxTags, override.MaxTags)
+
+	return cfg
+}
+
+// DuplicatesConfig in strict mode rejects subscriptions overlapping with another
+// subscription of the user to the same service.
+type DuplicatesConfig struct {
+	Strict bool `yaml:"strict" env:"DUPLICATES_STRICT" env-default:"false"`
+}
+
+type PurgeConfig struct {
+	Enabled   bool          `yaml:"enabled" env:"PURGE_ENABLED" env-default:"true"`
+	Retention time.Duration `yaml:"retention" env:"PURGE_RETENTION" env-default:"720h"`
+	Interval  time.Duration `yaml:"interval" env:"PURGE_INTERVAL" env-default:"1h"`
+}
+
+type ReminderConfig struct {
+	Enabled  bool          `yaml:"enabled" env:"REMINDERS_ENABLED" env-default:"true"`
+	Interval time.Duration `yaml:"interval" env:"REMINDERS_INTERVAL" env-default:"1h"`
+	LeadTime time.Duration `yaml:"lead_time" env:"REMINDERS_LEAD_TIME" env-default:"72h"`
+	Notifier notify.Config `yaml:"notifier"`
+}
+
+type WebhooksConfig struct {
+	Enabled         bool          `yaml:"enabled" env:"WEBHOOKS_ENABLED" env-default:"true"`
+	Interval        time.Duration `yaml:"interval" env:"WEBHOOKS_INTERVAL" env-default:"5s"`
+	BatchSize       int           `yaml:"batch_size" env-default:"50"`
+	Timeout         time.Duration `yaml:"timeout" env-default:"10s"`
+	MaxAttempts     int           `yaml:"max_attempts" env-default:"8"`
+	RetryBackoff    time.Duration `yaml:"retry_backoff" env-default:"30s"`
+	MaxRetryBackoff time.Duration `yaml:"max_retry_backoff" env-default:"6h"`
+	Retention       time.Duration `yaml:"retention" env-default:"168h"`
+}
+
+type BudgetsConfig struct {
+	Enabled           bool          `yaml:"enabled" env:"BUDGETS_ENABLED" env-default:"true"`
+	Interval          time.Duration `yaml:"interval" env:"BUDGETS_INTERVAL" env-default:"24h"`
+	DefaultThresholds []int         `yaml:"default_thresholds" env-default:"80,100"`
+	QueueSize         int           `yaml:"queue_size" env-default:"256"`
+}
+
+// JobsConfig configures the runner of background jobs. Running jobs extend their lease while their handler
+// runs, so that the lease only has to cover the stopped runner.
+type JobsConfig struct {
+	Enabled         bool          `yaml:"enabled" env:"JOBS_ENABLED" env-default:"true"`
+	Workers         int           `yaml:"workers" env:"JOBS_WORKERS" env-default:"4"`
+	Interval        time.Duration `yaml:"interval" env-default:"1s"`
+	Lease           time.Duration `yaml:"lease" env-default:"1m"`
+	Timeout         time.Duration `yaml:"timeout" env-default:"30m"`
+	MaxAttempts     int           `yaml:"max_attempts" env-default:"5"`
+	RetryBackoff    time.Duration `yaml:"retry_backoff" env-default:"10s"`
+	MaxRetryBackoff time.Duration `yaml:"max_retry_backoff" env-default:"10m"`
+	Retention       time.Duration `yaml:"retention" env-default:"24h"`
+	PurgeInterval   time.Duration `yaml:"purge_interval" env-default:"1h"`
+}
+
+// CacheConfig configures the cache of lists and summaries of subscriptions of the instance,
+// changes made through other instances are visible after TTL.
+type CacheConfig struct {
+	Enabled bool          `yaml:"enabled" env:"CACHE_ENABLED" env-default:"true"`
+	Size    int           `yaml:"size" env:"CACHE_SIZE" env-default:"10000"`
+	TTL     time.Duration `yaml:"ttl" env:"CACHE_TTL" env-default:"30s"`
+}
+
+type StreamConfig struct {
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval" env-default:"15s"`
+	RetryInterval     time.Duration `yaml:"retry_interval" env-default:"3s"`
+	BufferSize        int           `yaml:"buffer_size" env-default:"1024"`
+	ClientBufferSize  int           `yaml:"client_buffer_size" env-default:"64"`
+	ReconnectBackoff  time.Duration `yaml:"reconnect_backoff" env-default:"1s"`
+}
+
+type GraphQLConfig struct {
+	MaxDepth      int `yaml:"max_depth" env-default:"8"`
+	MaxComplexity int `yaml:"max_complexity" env-default:"1000"`
+}
+
+type CalendarConfig struct {
+	Name            string        `yaml:"name" env-default:"Subscriptions"`
+	RefreshInterval time.Duration `yaml:"refresh_interval" env-default:"12h"`
 }
 
 type PathConfig struct {
@@ -22,14 +141,85 @@ type PathConfig struct {
 	GetSub     string `yaml:"get_sub" env-required:"true"`
 	PutSub     string `yaml:"put_sub" env-required:"true"`
 	DeleteSub  string `yaml:"delete_sub" env-required:"true"`
-	ListSubs   string `yaml:"list_subs" env-required:"true"`
-	GetSummary string `yaml:"get_summary" env-required:"true"`
+	RestoreSub string `yaml:"restore_sub" env-required:"true"`
+
+	ListPriceChanges string `yaml:"list_price_changes" env-required:"true"`
+	AddPriceChange   string `yaml:"add_price_change" env-required:"true"`
+	PauseSub         string `yaml:"pause_sub" env-required:"true"`
+	ResumeSub        string `yaml:"resume_sub" env-required:"true"`
+	ListPauses       string `yaml:"list_pauses" env-required:"true"`
+	ListSubs         string `yaml:"list_subs" env-required:"true"`
+	GetSummary       string `yaml:"get_summary" env-required:"true"`
+	GetForecast      string `yaml:"get_forecast" env-required:"true"`
+	ListDuplicates   string `yaml:"list_duplicates" env-required:"true"`
+	ListTags         string `yaml:"list_tags" env-required:"true"`
+	RenameTag        string `yaml:"rename_tag" env-required:"true"`
+	MergeTags        string `yaml:"merge_tags" env-required:"true"`
+	ListMembers      string `yaml:"list_members" env-required:"true"`
+	PutMember        string `yaml:"put_member" env-required:"true"`
+	DeleteMember     string `yaml:"delete_member" env-required:"true"`
+	GetSettlement    string `yaml:"get_settlement" env-required:"true"`
+	StreamSubs       string `yaml:"stream_subs" env-required:"true"`
+	GraphQL          string `yaml:"graphql" env-required:"true"`
+
+	PostService   string `yaml:"post_service" env-required:"true"`
+	GetService    string `yaml:"get_service" env-required:"true"`
+	ListServices  string `yaml:"list_services" env-required:"true"`
+	PutService    string `yaml:"put_service" env-required:"true"`
+	DeleteService string `yaml:"delete_service" env-required:"true"`
+
+	PostBudget      string `yaml:"post_budget" env-required:"true"`
+	GetBudget       string `yaml:"get_budget" env-required:"true"`
+	ListBudgets     string `yaml:"list_budgets" env-required:"true"`
+	PutBudget       string `yaml:"put_budget" env-required:"true"`
+	DeleteBudget    string `yaml:"delete_budget" env-required:"true"`
+	GetBudgetStatus string `yaml:"get_budget_status" env-required:"true"`
+
+	GetCalendar         string `yaml:"get_calendar" env-required:"true"`
+	CreateCalendarToken string `yaml:"create_calendar_token" env-required:"true"`
+	RevokeCalendarToken string `yaml:"revoke_calendar_token" env-required:"true"`
+
+	GetSubHistory string `yaml:"get_sub_history" env-required:"true"`
+	ListAudit     string `yaml:"list_audit" env-required:"true"`
+
+	RequestDataExport string `yaml:"request_data_export" env-required:"true"`
+	GetDataExport     string `yaml:"get_data_export" env-required:"true"`
+	GetDataArchive    string `yaml:"get_data_archive" env-required:"true"`
+	EraseUserData     string `yaml:"erase_user_data" env-required:"true"`
+
+	GetJob    string `yaml:"get_job" env-required:"true"`
+	CancelJob string `yaml:"cancel_job" env-required:"true"`
+
+	GetCacheStats string `yaml:"get_cache_stats" env-required:"true"`
+
+	PostWebhook       string `yaml:"post_webhook" env-required:"true"`
+	GetWebhook        string `yaml:"get_webhook" env-required:"true"`
+	ListWebhooks      string `yaml:"list_webhooks" env-required:"true"`
+	PutWebhook        string `yaml:"put_webhook" env-required:"true"`
+	DeleteWebhook     string `yaml:"delete_webhook" env-required:"true"`
+	ListDeliveries    string `yaml:"list_deliveries" env-required:"true"`
+	RedeliverDelivery string `yaml:"redeliver_delivery" env-required:"true"`
 }
 
 type Config struct {
-	HTTPCfg     server.HTTPConfig       `yaml:"http"`
-	PostgresCfg postgres.Config `yaml:"postgres"`
-	SvcCfg      ServiceConfig           `yaml:"service"`
-	DataCfg     DataConfig              `yaml:"data"`
-	PathCfg     PathConfig              `yaml:"paths"`
+	HTTPCfg           server.HTTPConfig               `yaml:"http"`
+	GRPCCfg           grpcServer.GRPCConfig           `yaml:"grpc"`
+	RateLimitCfg      middleware.RateLimitConfig      `yaml:"rate_limit"`
+	PostgresCfg       postgres.Config                 `yaml:"postgres"`
+	ReadYourWritesCfg middleware.ReadYourWritesConfig `yaml:"read_your_writes"`
+	AuthCfg           middleware.AuthConfig           `yaml:"auth"`
+	TenantCfg         middleware.TenantConfig         `yaml:"tenants"`
+	SvcCfg            ServiceConfig                   `yaml:"service"`
+	DataCfg           DataConfig                      `yaml:"data"`
+	DuplicatesCfg     DuplicatesConfig                `yaml:"duplicates"`
+	PurgeCfg          PurgeConfig                     `yaml:"purge"`
+	ReminderCfg       ReminderConfig                  `yaml:"reminders"`
+	WebhooksCfg       WebhooksConfig                  `yaml:"webhooks"`
+	BudgetsCfg        BudgetsConfig                   `yaml:"budgets"`
+	JobsCfg           JobsConfig                      `yaml:"jobs"`
+	CacheCfg          CacheConfig                     `yaml:"cache"`
+	StreamCfg         StreamConfig                    `yaml:"stream"`
+	GraphQLCfg        GraphQLConfig                   `yaml:"graphql"`
+	CalendarCfg       CalendarConfig                  `yaml:"calendar"`
+	PathCfg           PathConfig                      `yaml:"paths"`
 }
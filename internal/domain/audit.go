@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditOperation string
+
+const (
+	AuditCreate  AuditOperation = "create"
+	AuditUpdate  AuditOperation = "update"
+	AuditDelete  AuditOperation = "delete"
+	AuditRestore AuditOperation = "restore"
+	AuditPurge   AuditOperation = "purge"
+	AuditPause   AuditOperation = "pause"
+	AuditResume  AuditOperation = "resume"
+	// AuditErase is recorded without subscription, when all user's data are erased.
+	AuditErase AuditOperation = "erase"
+
+	AnonymousActor = "anonymous"
+	SystemActor    = "system"
+)
+
+type AuditEntry struct {
+	ID        int64           `json:"id"`
+	SubID     uuid.UUID       `json:"sub_id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	Actor     string          `json:"actor"`
+	RequestID string          `json:"request_id,omitempty"`
+	Operation AuditOperation  `json:"operation"`
+	Before    json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After     json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type AuditFilterOpts struct {
+	From      time.Time
+	To        time.Time
+	UserID    uuid.UUID
+	SubID     uuid.UUID
+	Actor     string
+	PageToken int64
+	PageSize  int
+}
+
+// AuditMeta describes who made the change, it is passed to repositories within the context.
+type AuditMeta struct {
+	Actor     string
+	RequestID string
+}
+
+type auditMetaKey struct{}
+
+func WithAuditMeta(ctx context.Context, meta AuditMeta) context.Context {
+	return context.WithValue(ctx, auditMetaKey{}, meta)
+}
+
+func AuditMetaFromContext(ctx context.Context) AuditMeta {
+	meta, ok := ctx.Value(auditMetaKey{}).(AuditMeta)
+	if !ok || len(meta.Actor) == 0 {
+		meta.Actor = AnonymousActor
+	}
+
+	return meta
+}
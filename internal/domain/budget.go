@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Budget limits user's monthly spend on all subscriptions or, if ServiceName or Category is set,
+// on subscriptions of the service or the category. Thresholds are percents of the amount, an alert is sent once a month for each
+// threshold reached by the spend.
+type Budget struct {
+	ID          uuid.UUID `json:"id" swaggerignore:"true"`
+	UserID      uuid.UUID `json:"user_id"`
+	ServiceName string    `json:"service_name,omitempty"`
+	Category    string    `json:"category,omitempty"`
+	Amount      int64     `json:"amount"`
+	Thresholds  []int     `json:"thresholds"`
+	CreatedAt   time.Time `json:"created_at" swaggerignore:"true"`
+}
+
+// BudgetSpend is the budget along with the spend it limits at some month.
+type BudgetSpend struct {
+	Budget *Budget
+	Spend  int64
+}
+
+// Reached returns thresholds, which are reached by the spend.
+func (s *BudgetSpend) Reached() []int {
+	reached := []int{}
+
+	for _, threshold := range s.Budget.Thresholds {
+		if s.Spend*100 >= s.Budget.Amount*int64(threshold) {
+			reached = append(reached, threshold)
+		}
+	}
+
+	return reached
+}
+
+type BudgetStatus struct {
+	Budget *Budget `json:"budget"`
+	Month  string  `json:"month"`
+	Spend  int64   `json:"spend"`
+	// Remaining is negative if the budget is exceeded.
+	Remaining int64 `json:"remaining"`
+	// Utilization is the percent of the amount spent.
+	Utilization float64 `json:"utilization"`
+	Reached     []int   `json:"reached_thresholds"`
+}
+
+func NewBudgetStatus(spend *BudgetSpend, month time.Time) *BudgetStatus {
+	return &BudgetStatus{
+		Budget:      spend.Budget,
+		Month:       month.Format(TimeLayout),
+		Spend:       spend.Spend,
+		Remaining:   spend.Budget.Amount - spend.Spend,
+		Utilization: math.Round(float64(spend.Spend)*10000/float64(spend.Budget.Amount)) / 100,
+		Reached:     spend.Reached(),
+	}
+}
+
+type BudgetAlert struct {
+	BudgetID    uuid.UUID `json:"budget_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	ServiceName string    `json:"service_name,omitempty"`
+	Category    string    `json:"category,omitempty"`
+	Month       time.Time `json:"month"`
+	Threshold   int       `json:"threshold"`
+	Amount      int64     `json:"amount"`
+	Spend       int64     `json:"spend"`
+}
+
+func NewBudgetAlert(spend *BudgetSpend, month time.Time, threshold int) *BudgetAlert {
+	return &BudgetAlert{
+		BudgetID:    spend.Budget.ID,
+		UserID:      spend.Budget.UserID,
+		ServiceName: spend.Budget.ServiceName,
+		Category:    spend.Budget.Category,
+		Month:       month,
+		Threshold:   threshold,
+		Amount:      spend.Budget.Amount,
+		Spend:       spend.Spend,
+	}
+}
+
+// Key is unique for every threshold of the budget within a month.
+func (a *BudgetAlert) Key() string {
+	return fmt.Sprintf("budget:%s:%s:%d", a.BudgetID, a.Month.Format(TimeLayout), a.Threshold)
+}
+
+func (a *BudgetAlert) Text() string {
+	scope := "all subscriptions"
+
+	switch {
+	case len(a.ServiceName) != 0 && len(a.Category) != 0:
+		scope = fmt.Sprintf("%s (%s)", a.ServiceName, a.Category)
+	case len(a.ServiceName) != 0:
+		scope = a.ServiceName
+	case len(a.Category) != 0:
+		scope = a.Category + " subscriptions"
+	}
+
+	return fmt.Sprintf(
+		"Spend on %s reached %d%% of the budget in %s: %d of %d",
+		scope, a.Threshold, a.Month.Format(TimeLayout), a.Spend, a.Amount,
+	)
+}
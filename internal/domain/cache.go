@@ -0,0 +1,12 @@
+package domain
+
+// CacheStats reports usage of the cache of lists and summaries of subscriptions since the instance started.
+type CacheStats struct {
+	Enabled   bool    `json:"enabled"`
+	Size      int     `json:"size"`
+	Capacity  int     `json:"capacity"`
+	Hits      uint64  `json:"hits"`
+	Misses    uint64  `json:"misses"`
+	Evictions uint64  `json:"evictions"`
+	HitRatio  float64 `json:"hit_ratio"`
+}
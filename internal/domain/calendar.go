@@ -0,0 +1,8 @@
+package domain
+
+// CalendarSub is a subscription shown in the user's calendar along with its pauses,
+// which months are not charged.
+type CalendarSub struct {
+	Sub    *Sub
+	Pauses []*Pause
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Duplicate is a pair of subscriptions to the same service, which periods overlap from From to To
+// (exclusive, zero for overlaps without end). WastedSpend sums charges of the cheaper subscription
+// for months of the overlap up to the current one, since only one of subscriptions is needed.
+type Duplicate struct {
+	ServiceName string    `json:"service_name"`
+	Subs        []*Sub    `json:"subs"`
+	From        time.Time `json:"overlap_from"`
+	To          time.Time `json:"overlap_to"`
+	WastedSpend int64     `json:"wasted_spend"`
+}
+
+type DuplicateJSONBody struct {
+	ServiceName string `json:"service_name"`
+	Subs        []*Sub `json:"subs"`
+	From        string `json:"overlap_from"`
+	To          string `json:"overlap_to,omitempty"`
+	WastedSpend int64  `json:"wasted_spend"`
+}
+
+func (d *Duplicate) MarshalJSON() ([]byte, error) {
+	const op = "Duplicate.MarshalJSON"
+
+	req := DuplicateJSONBody{
+		ServiceName: d.ServiceName,
+		Subs:        d.Subs,
+		From:        d.From.Format(TimeLayout),
+		WastedSpend: d.WastedSpend,
+	}
+
+	if !d.To.IsZero() {
+		req.To = d.To.Format(TimeLayout)
+	}
+
+	data, err := json.Marshal(&req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return data, nil
+}
+
+type DuplicatesReport struct {
+	UserID      uuid.UUID    `json:"user_id"`
+	WastedSpend int64        `json:"wasted_spend"`
+	Duplicates  []*Duplicate `json:"duplicates"`
+}
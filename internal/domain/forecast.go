@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceSpend is the amount charged at the month for subscriptions of the service.
+type ServiceSpend struct {
+	Month       time.Time `json:"-"`
+	ServiceName string    `json:"service_name"`
+	Amount      int64     `json:"amount"`
+}
+
+type MonthForecast struct {
+	Month    string          `json:"month"`
+	Total    int64           `json:"total"`
+	Services []*ServiceSpend `json:"services"`
+}
+
+type Forecast struct {
+	UserID uuid.UUID        `json:"user_id"`
+	Total  int64            `json:"total"`
+	Months []*MonthForecast `json:"months"`
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Member shares subscription with its owner: member pays either fixed Amount or a share of the charge
+// by Weight, the owner has weight 1 and pays the rest. Fixed amounts are paid first and are scaled down,
+// if they exceed the charge (e.g. during the trial).
+type Member struct {
+	SubID     uuid.UUID `json:"sub_id" swaggerignore:"true"`
+	UserID    uuid.UUID `json:"user_id" swaggerignore:"true"`
+	Weight    int       `json:"weight,omitempty"`
+	Amount    int64     `json:"amount,omitempty"`
+	CreatedAt time.Time `json:"created_at" swaggerignore:"true"`
+}
+
+// Debt is the amount Debtor owes Creditor for shared subscriptions at the month.
+type Debt struct {
+	Month    time.Time `json:"-"`
+	Debtor   uuid.UUID `json:"debtor"`
+	Creditor uuid.UUID `json:"creditor"`
+	Amount   int64     `json:"amount"`
+}
+
+type MonthSettlement struct {
+	Month string  `json:"month"`
+	Debts []*Debt `json:"debts"`
+}
+
+// Settlement reports debts between the user and other users sharing subscriptions with them,
+// debts of each pair of users are netted within a month.
+type Settlement struct {
+	UserID uuid.UUID          `json:"user_id"`
+	From   string             `json:"from"`
+	To     string             `json:"to"`
+	Months []*MonthSettlement `json:"months"`
+}
@@ -1,10 +1,41 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type FilterOpts struct {
 	UserID      uuid.UUID
 	ServiceName string
 	PageToken   uuid.UUID
 	PageSize    int
+
+	// Period of summary (months, both inclusive)
+	From time.Time
+	To   time.Time
+
+	// Only subscriptions, which trial ends within that many days (if positive)
+	TrialEndsWithin int
+
+	Category string
+
+	// Only subscriptions with any or, if TagsMatch is TagsMatchAll, all of the tags
+	Tags      []string
+	TagsMatch TagsMatch
+
+	// Dimension of summary groups, no groups if empty
+	GroupBy GroupBy
+
+	IncludeDeleted bool
+}
+
+type GetOpts struct {
+	IncludeDeleted bool
+}
+
+type PostOpts struct {
+	// Reject subscription, which period overlaps with another subscription to the same service
+	RejectOverlaps bool
 }
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Pause suspends subscription's billing for months From..To inclusive.
+// Pause without To lasts until the subscription is resumed.
+type Pause struct {
+	ID    int64     `json:"id" swaggerignore:"true"`
+	SubID uuid.UUID `json:"sub_id" swaggerignore:"true"`
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+}
+
+type PauseJSONBody struct {
+	ID    int64  `json:"id,omitempty"`
+	SubID string `json:"sub_id,omitempty"`
+	From  string `json:"from"`
+	To    string `json:"to,omitempty"`
+}
+
+func (p *Pause) UnmarshalJSON(b []byte) error {
+	const op = "Pause.UnmarshalJSON"
+
+	var req PauseJSONBody
+	if err := json.Unmarshal(b, &req); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var err error
+
+	p.From, err = time.Parse(TimeLayout, req.From)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	to, err := time.Parse(TimeLayout, req.To)
+	if len(req.To) != 0 && err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	} else if err == nil {
+		p.To = to
+	}
+
+	return nil
+}
+
+func (p *Pause) MarshalJSON() ([]byte, error) {
+	const op = "Pause.MarshalJSON"
+
+	req := PauseJSONBody{
+		ID:    p.ID,
+		SubID: p.SubID.String(),
+		From:  p.From.Format(TimeLayout),
+	}
+
+	if !p.To.IsZero() {
+		req.To = p.To.Format(TimeLayout)
+	}
+
+	data, err := json.Marshal(&req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return data, nil
+}
+
+// StartOfMonth truncates time to the first day of its month, as subscription's dates are stored.
+func StartOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PriceChange sets subscription's price starting from the given month.
+type PriceChange struct {
+	SubID         uuid.UUID `json:"sub_id" swaggerignore:"true"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	Price         int64     `json:"price"`
+}
+
+type PriceChangeJSONBody struct {
+	SubID         string `json:"sub_id,omitempty"`
+	EffectiveFrom string `json:"effective_from"`
+	Price         int    `json:"price"`
+}
+
+func (p *PriceChange) UnmarshalJSON(b []byte) error {
+	const op = "PriceChange.UnmarshalJSON"
+
+	var req PriceChangeJSONBody
+	if err := json.Unmarshal(b, &req); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	p.Price = int64(req.Price)
+
+	var err error
+
+	p.EffectiveFrom, err = time.Parse(TimeLayout, req.EffectiveFrom)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *PriceChange) MarshalJSON() ([]byte, error) {
+	const op = "PriceChange.MarshalJSON"
+
+	req := PriceChangeJSONBody{
+		SubID:         p.SubID.String(),
+		EffectiveFrom: p.EffectiveFrom.Format(TimeLayout),
+		Price:         int(p.Price),
+	}
+
+	data, err := json.Marshal(&req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return data, nil
+}
+
+// PutOpts control how subscription's update treats the price.
+// If PriceEffectiveFrom is set, the new price is recorded as a price change starting from
+// that month, otherwise the price is overwritten for the whole subscription's period.
+type PutOpts struct {
+	PriceEffectiveFrom time.Time
+}
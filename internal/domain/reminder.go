@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReminderKind string
+
+const (
+	// ReminderCharge warns about the next charge of subscription.
+	ReminderCharge ReminderKind = "charge"
+	// ReminderEnd warns about the end of subscription.
+	ReminderEnd ReminderKind = "end"
+)
+
+type Reminder struct {
+	Kind        ReminderKind `json:"kind"`
+	SubID       uuid.UUID    `json:"sub_id"`
+	TenantID    string       `json:"-"`
+	UserID      uuid.UUID    `json:"user_id"`
+	ServiceName string       `json:"service_name"`
+	Date        time.Time    `json:"date"`
+	Amount      int64        `json:"amount,omitempty"`
+}
+
+// Key is unique for every reminder, so that it is sent only once.
+func (r *Reminder) Key() string {
+	return fmt.Sprintf("%s:%s:%s", r.Kind, r.SubID, r.Date.Format(time.DateOnly))
+}
+
+func (r *Reminder) Text() string {
+	if r.Kind == ReminderEnd {
+		return fmt.Sprintf("Subscription %s ends on %s", r.ServiceName, r.Date.Format(time.DateOnly))
+	}
+
+	return fmt.Sprintf("Subscription %s will be charged %d on %s", r.ServiceName, r.Amount, r.Date.Format(time.DateOnly))
+}
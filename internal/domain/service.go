@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultCurrency = "RUB"
+)
+
+// Service is a catalog entry, which subscriptions are linked to by their service names:
+// the name matches the canonical name or one of the aliases case-insensitively.
+type Service struct {
+	ID       uuid.UUID `json:"id" swaggerignore:"true"`
+	Name     string    `json:"name"`
+	Aliases  []string  `json:"aliases"`
+	Category string    `json:"category,omitempty"`
+	// Currency is ISO 4217 code of subscriptions' prices.
+	Currency  string    `json:"currency"`
+	Website   string    `json:"website,omitempty"`
+	CreatedAt time.Time `json:"created_at" swaggerignore:"true"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// SubEvent notifies about subscription's change, ID is the id of the change in the audit log.
+// Event names are the same as webhooks' ones.
+type SubEvent struct {
+	ID       int64           `json:"id"`
+	Event    WebhookEvent    `json:"event"`
+	TenantID string          `json:"tenant_id"`
+	UserID   uuid.UUID       `json:"user_id"`
+	SubID    uuid.UUID       `json:"sub_id"`
+	Data     json.RawMessage `json:"data"`
+}
@@ -16,6 +16,25 @@ type Sub struct {
 	Price       int64     `json:"price"`
 	StartDate   time.Time `json:"start_date"`
 	EndDate     time.Time `json:"end_date"`
+
+	// ServiceID links subscription to the catalog, it is nil if the service is not in the catalog.
+	ServiceID uuid.UUID `json:"service_id" swaggerignore:"true"`
+
+	// Category defaults to the category of the catalog entry, tags are user-defined.
+	Category string   `json:"category"`
+	Tags     []string `json:"tags"`
+
+	// First TrialMonths months are charged by PromoPrice, which is zero for free trials.
+	TrialMonths int   `json:"trial_months"`
+	PromoPrice  int64 `json:"promo_price"`
+
+	DeletedAt time.Time `json:"deleted_at" swaggerignore:"true"`
+
+	// Paused reports whether billing is paused at the current month.
+	Paused bool `json:"-"`
+
+	// TenantID is the partner app the subscription belongs to, it is set by the repository.
+	TenantID string `json:"-"`
 }
 
 type SubJSONBody struct {
@@ -23,11 +42,31 @@ type SubJSONBody struct {
 	UserID string `json:"user_id"`
 
 	ServiceName string `json:"service_name"`
+	ServiceID   string `json:"service_id,omitempty"`
 	Price       int    `json:"price"`
 	StartDate   string `json:"start_date"`
 	EndDate     string `json:"end_date,omitempty"`
+
+	Category string   `json:"category,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+
+	TrialMonths int    `json:"trial_months,omitempty"`
+	PromoPrice  int    `json:"promo_price,omitempty"`
+	TrialEnd    string `json:"trial_end,omitempty"`
+
+	DeletedAt string `json:"deleted_at,omitempty"`
+	Status    string `json:"status,omitempty"`
 }
 
+type SubStatus string
+
+const (
+	SubActive    SubStatus = "active"
+	SubPaused    SubStatus = "paused"
+	SubEnded     SubStatus = "ended"
+	SubScheduled SubStatus = "scheduled"
+)
+
 const (
 	TimeLayout = "01-2006"
 )
@@ -42,6 +81,10 @@ func (s *Sub) UnmarshalJSON(b []byte) error {
 
 	s.ServiceName = req.ServiceName
 	s.Price = int64(req.Price)
+	s.TrialMonths = req.TrialMonths
+	s.PromoPrice = int64(req.PromoPrice)
+	s.Category = req.Category
+	s.Tags = req.Tags
 
 	var err error
 
@@ -74,12 +117,32 @@ func (s *Sub) MarshalJSON() ([]byte, error) {
 		ServiceName: s.ServiceName,
 		Price:       int(s.Price),
 		StartDate:   s.StartDate.Format(TimeLayout),
+		Category:    s.Category,
+		Tags:        s.Tags,
+		TrialMonths: s.TrialMonths,
+		PromoPrice:  int(s.PromoPrice),
 	}
 
 	if !s.EndDate.IsZero() {
 		req.EndDate = s.EndDate.Format(TimeLayout)
 	}
 
+	if s.ServiceID != uuid.Nil {
+		req.ServiceID = s.ServiceID.String()
+	}
+
+	if trialEnd := s.TrialEnd(); !trialEnd.IsZero() {
+		req.TrialEnd = trialEnd.Format(TimeLayout)
+	}
+
+	if !s.DeletedAt.IsZero() {
+		req.DeletedAt = s.DeletedAt.Format(time.RFC3339)
+	}
+
+	if !s.StartDate.IsZero() {
+		req.Status = string(s.Status(time.Now()))
+	}
+
 	data, err := json.Marshal(&req)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -87,3 +150,29 @@ func (s *Sub) MarshalJSON() ([]byte, error) {
 
 	return data, nil
 }
+
+// Status computes subscription's state at the month of the given time.
+// End date is exclusive, so subscription is ended starting from that month.
+func (s *Sub) Status(now time.Time) SubStatus {
+	month := StartOfMonth(now)
+
+	switch {
+	case month.Before(s.StartDate):
+		return SubScheduled
+	case !s.EndDate.IsZero() && !month.Before(s.EndDate):
+		return SubEnded
+	case s.Paused:
+		return SubPaused
+	default:
+		return SubActive
+	}
+}
+
+// TrialEnd returns the month of the first regular charge or zero time, if there is no trial.
+func (s *Sub) TrialEnd() time.Time {
+	if s.TrialMonths <= 0 {
+		return time.Time{}
+	}
+
+	return s.StartDate.AddDate(0, s.TrialMonths, 0)
+}
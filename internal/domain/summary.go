@@ -2,8 +2,30 @@ package domain
 
 import "github.com/google/uuid"
 
+type GroupBy string
+
+const (
+	GroupByService  GroupBy = "service"
+	GroupByCategory GroupBy = "category"
+	GroupByTag      GroupBy = "tag"
+)
+
 type Summary struct {
 	UserID      uuid.UUID `json:"user_id"`
 	ServiceName string    `json:"service_name,omitempty"`
+	Category    string    `json:"category,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	From        string    `json:"from,omitempty"`
+	To          string    `json:"to,omitempty"`
 	TotalPrice  int       `json:"total_price"`
+
+	GroupBy GroupBy         `json:"group_by,omitempty"`
+	Groups  []*SummaryGroup `json:"groups,omitempty"`
+}
+
+// SummaryGroup sums subscriptions with the same key, which is empty for subscriptions
+// without category or tags. Subscription with several tags is counted in each of its groups.
+type SummaryGroup struct {
+	Key        string `json:"key"`
+	TotalPrice int    `json:"total_price"`
 }
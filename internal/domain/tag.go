@@ -0,0 +1,15 @@
+package domain
+
+// Tag is a user-defined label, tags are shared by all subscriptions of the user.
+type Tag struct {
+	Name string `json:"name"`
+	// Subs is the number of subscriptions labeled with the tag.
+	Subs int `json:"subs"`
+}
+
+type TagsMatch string
+
+const (
+	TagsMatchAny TagsMatch = "any"
+	TagsMatchAll TagsMatch = "all"
+)
@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+type tenantKey struct{}
+
+// WithTenant passes the tenant of the request to repositories, which are isolated by tenants.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns an empty tenant, if it is not set, which has access to no data.
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
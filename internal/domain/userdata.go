@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobDataExport builds the archive of DataExport, the payload is DataExportPayload.
+const JobDataExport = "data_export"
+
+type DataExportPayload struct {
+	ExportID uuid.UUID `json:"export_id"`
+	UserID   uuid.UUID `json:"user_id"`
+}
+
+type DataExportResult struct {
+	ExportID uuid.UUID `json:"export_id"`
+	// Size of the archive in bytes.
+	Size int `json:"size"`
+}
+
+// DataExport is an export of all user's data, which is built by the job.
+// Status of the export is the status of its job, the archive can be downloaded once it is completed.
+type DataExport struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	JobID       uuid.UUID  `json:"job_id"`
+	Status      JobStatus  `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// UserData is everything stored about the user: subscriptions including deleted ones, their history,
+// budgets and sent notifications.
+type UserData struct {
+	Subs          []*Sub
+	History       []*AuditEntry
+	Budgets       []*Budget
+	Notifications []*NotificationRecord
+}
+
+// NotificationRecord is a sent reminder or budget alert, Key identifies it the same way as it is deduplicated.
+type NotificationRecord struct {
+	Key    string    `json:"key"`
+	SentAt time.Time `json:"sent_at"`
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookEvent string
+
+const (
+	EventSubCreated  WebhookEvent = "sub.created"
+	EventSubUpdated  WebhookEvent = "sub.updated"
+	EventSubDeleted  WebhookEvent = "sub.deleted"
+	EventSubRestored WebhookEvent = "sub.restored"
+	EventSubEnded    WebhookEvent = "sub.ended"
+)
+
+var WebhookEvents = []WebhookEvent{EventSubCreated, EventSubUpdated, EventSubDeleted, EventSubRestored, EventSubEnded}
+
+// Webhook receives events it is subscribed to, or all events if Events is empty.
+// Secret is used to sign deliveries and is never returned.
+type Webhook struct {
+	ID        uuid.UUID      `json:"id" swaggerignore:"true"`
+	URL       string         `json:"url"`
+	Secret    string         `json:"secret,omitempty"`
+	Events    []WebhookEvent `json:"events"`
+	CreatedAt time.Time      `json:"created_at" swaggerignore:"true"`
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryDead is set after all attempts failed, such deliveries are sent again only on redelivery.
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+type WebhookDelivery struct {
+	ID            int64          `json:"id"`
+	WebhookID     uuid.UUID      `json:"webhook_id"`
+	EventID       int64          `json:"event_id"`
+	Event         WebhookEvent   `json:"event"`
+	Status        DeliveryStatus `json:"status"`
+	Attempts      int            `json:"attempts"`
+	NextAttemptAt time.Time      `json:"next_attempt_at"`
+	LastError     string         `json:"last_error,omitempty"`
+	DeliveredAt   *time.Time     `json:"delivered_at,omitempty"`
+}
+
+type DeliveryFilterOpts struct {
+	WebhookID uuid.UUID
+	Status    DeliveryStatus
+	PageToken int64
+	PageSize  int
+}
+
+// OutgoingDelivery is a delivery claimed for sending along with its event and destination.
+type OutgoingDelivery struct {
+	ID       int64
+	Attempts int
+	URL      string
+	Secret   string
+	Payload  WebhookPayload
+}
+
+// WebhookPayload is the body of webhook request, Data is subscription's state
+// (for deletions - the state before deletion).
+type WebhookPayload struct {
+	EventID   int64           `json:"id"`
+	Event     WebhookEvent    `json:"event"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// AuditWebhookEvent maps subscription's change to webhook event, purges are not published.
+func AuditWebhookEvent(operation AuditOperation) (WebhookEvent, bool) {
+	switch operation {
+	case AuditCreate:
+		return EventSubCreated, true
+	case AuditUpdate, AuditPause, AuditResume:
+		return EventSubUpdated, true
+	case AuditDelete:
+		return EventSubDeleted, true
+	case AuditRestore:
+		return EventSubRestored, true
+	default:
+		return "", false
+	}
+}
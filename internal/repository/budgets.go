@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"subs-service/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BudgetsRepo interface {
+	PostBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error)
+	GetBudget(ctx context.Context, id uuid.UUID) (*domain.Budget, error)
+	ListBudgets(ctx context.Context, userID uuid.UUID) ([]*domain.Budget, error)
+	PutBudget(ctx context.Context, id uuid.UUID, budget *domain.Budget) (*domain.Budget, error)
+	DeleteBudget(ctx context.Context, id uuid.UUID) error
+
+	// GetBudgetSpend computes the spend limited by the budget at the month.
+	GetBudgetSpend(ctx context.Context, id uuid.UUID, month time.Time) (*domain.BudgetSpend, error)
+	// ListBudgetSpends computes spends of budgets of the user of the tenant from ctx or,
+	// if userID is nil, of all budgets of all tenants.
+	ListBudgetSpends(ctx context.Context, userID uuid.UUID, month time.Time) ([]*domain.BudgetSpend, error)
+
+	// RecordAlert saves the alert, false is returned if it was already recorded.
+	RecordAlert(ctx context.Context, alert *domain.BudgetAlert) (bool, error)
+	// DeleteAlert removes the alert, which failed to be sent.
+	DeleteAlert(ctx context.Context, alert *domain.BudgetAlert) error
+}
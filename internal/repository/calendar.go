@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"subs-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// CalendarRepo stores hashes of feed tokens only, so that tokens can't be recovered from the database.
+type CalendarRepo interface {
+	PutFeedToken(ctx context.Context, userID uuid.UUID, tokenHash []byte) error
+	DeleteFeedToken(ctx context.Context, userID uuid.UUID) error
+	// CheckFeedToken returns the tenant the token is issued in or an empty tenant, if the token is invalid.
+	// Feeds are requested without tenants, so tokens of all tenants are checked.
+	CheckFeedToken(ctx context.Context, userID uuid.UUID, tokenHash []byte) (string, error)
+
+	// ListCalendarSubs lists subscriptions, which are not ended yet.
+	ListCalendarSubs(ctx context.Context, userID uuid.UUID) ([]*domain.CalendarSub, error)
+}
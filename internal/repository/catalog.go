@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"subs-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type CatalogRepo interface {
+	PostService(ctx context.Context, service *domain.Service) (*domain.Service, error)
+	GetService(ctx context.Context, id uuid.UUID) (*domain.Service, error)
+	ListServices(ctx context.Context) ([]*domain.Service, error)
+	PutService(ctx context.Context, id uuid.UUID, service *domain.Service) (*domain.Service, error)
+	DeleteService(ctx context.Context, id uuid.UUID) error
+}
@@ -3,6 +3,28 @@ package repository
 import "errors"
 
 var (
-	ErrInvalidSubData = errors.New("invalid subscription data")
-	ErrNoSubIDExists = errors.New("no subscription with such id exists")
+	ErrInvalidSubData       = errors.New("invalid subscription data")
+	ErrNoSubIDExists        = errors.New("no subscription with such id exists")
+	ErrNoDeletedSubIDExists = errors.New("no deleted subscription with such id exists")
+	ErrInvalidPriceChange   = errors.New("price change must be effective within subscription's period")
+	ErrInvalidPause         = errors.New("pause must start within subscription's period")
+	ErrPauseOverlap         = errors.New("pause overlaps with another pause of the subscription")
+	ErrSubNotPaused         = errors.New("subscription is not paused")
+	ErrNoWebhookIDExists    = errors.New("no webhook with such id exists")
+	ErrNoDeliveryIDExists   = errors.New("no webhook delivery with such id exists")
+	ErrNoFeedTokenExists    = errors.New("no calendar feed token exists for the user")
+	ErrNoBudgetIDExists     = errors.New("no budget with such id exists")
+	ErrNoServiceIDExists    = errors.New("no catalog service with such id exists")
+	ErrServiceAliasExists   = errors.New("service name or alias is already used by another catalog service")
+	ErrNoTagExists          = errors.New("no tag with such name exists for the user")
+	ErrTagExists            = errors.New("tag with such name already exists for the user, merge tags instead")
+	ErrSubOverlap           = errors.New("subscription overlaps with another subscription of the user to the same service")
+	ErrMemberIsOwner        = errors.New("owner of subscription can't be its member")
+	ErrNoMemberExists       = errors.New("no member with such user id exists for the subscription")
+	ErrNoTenant             = errors.New("tenant of the request is not set")
+	ErrNoExportIDExists     = errors.New("no data export with such id exists for the user")
+	ErrNoExportCompleted    = errors.New("no completed data export exists for the user, request an export first")
+	ErrNoJobIDExists        = errors.New("no job with such id exists")
+	ErrJobFinished          = errors.New("job is already finished")
+	ErrJobNotRunning        = errors.New("job is not running anymore")
 )
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"subs-service/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobsRepo interface {
+	GetJob(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	// CancelJob cancels the pending or running job, finished jobs can't be cancelled.
+	CancelJob(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+}
+
+// JobQueueRepo is used by the runner, jobs are enqueued by other repositories within their transactions.
+type JobQueueRepo interface {
+	// ClaimJob locks the oldest due job of one of the types for the lease time, so that concurrent runners skip it.
+	// Running jobs, whose lease is over, are claimed again. Nil is returned if there is nothing to run.
+	ClaimJob(ctx context.Context, types []string, lease time.Duration) (*domain.Job, error)
+
+	// Attempt of the job is passed to the following methods, they return ErrJobNotRunning if the attempt
+	// is not running anymore: the job was cancelled or was reclaimed after the lease was over.
+	ExtendLease(ctx context.Context, id uuid.UUID, attempt int, lease time.Duration) error
+	SetJobProgress(ctx context.Context, id uuid.UUID, attempt int, progress int) error
+	CompleteJob(ctx context.Context, id uuid.UUID, attempt int, result []byte) error
+	// FailJob schedules the next attempt or, if retryAt is zero, fails the job.
+	FailJob(ctx context.Context, id uuid.UUID, attempt int, reason string, retryAt time.Time) error
+
+	// PurgeJobs deletes jobs finished before the given time.
+	PurgeJobs(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"subs-service/internal/domain"
+	pkgPostgres "subs-service/pkg/database/postgres"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// auditColumns selects nil subscription id for erasures, which are recorded without subscription.
+const auditColumns = `id, COALESCE(sub_id, '00000000-0000-0000-0000-000000000000'::uuid), user_id, actor, request_id,
+	operation, before, after, created_at`
+
+func scanAuditEntries(rows pgx.Rows) ([]*domain.AuditEntry, error) {
+	entries := []*domain.AuditEntry{}
+
+	for rows.Next() {
+		var entry domain.AuditEntry
+		var before, after []byte
+
+		if err := rows.Scan(
+			&entry.ID, &entry.SubID, &entry.UserID, &entry.Actor, &entry.RequestID,
+			&entry.Operation, &before, &after, &entry.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		entry.Before, entry.After = before, after
+		entries = append(entries, &entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// insertAudit records a change of subscription within the transaction of the change itself.
+// Either before or after is nil for creations and deletions respectively. Id of the entry is returned.
+func insertAudit(
+	ctx context.Context, tx pgx.Tx, operation domain.AuditOperation, before, after *domain.Sub,
+) (int64, error) {
+	query :=
+		`INSERT INTO subs_audit (tenant_id, sub_id, user_id, actor, request_id, operation, before, after)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+
+	var beforeJSON, afterJSON []byte
+	var err error
+
+	sub := after
+
+	if before != nil {
+		sub = before
+
+		if beforeJSON, err = json.Marshal(before); err != nil {
+			return 0, err
+		}
+	}
+
+	if after != nil {
+		if afterJSON, err = json.Marshal(after); err != nil {
+			return 0, err
+		}
+	}
+
+	meta := domain.AuditMetaFromContext(ctx)
+
+	var id int64
+	err = tx.QueryRow(
+		ctx, query,
+		sub.TenantID, sub.ID, sub.UserID, meta.Actor, meta.RequestID, operation, beforeJSON, afterJSON,
+	).Scan(&id)
+
+	return id, err
+}
+
+type AuditRepo struct {
+	cluster *pkgPostgres.Cluster
+}
+
+func NewAuditRepo(cluster *pkgPostgres.Cluster) *AuditRepo {
+	return &AuditRepo{
+		cluster: cluster,
+	}
+}
+
+func (r *AuditRepo) GetSubHistory(ctx context.Context, subID uuid.UUID) ([]*domain.AuditEntry, error) {
+	const op = "AuditRepo.GetSubHistory"
+
+	entries, err := r.listAudit(ctx, domain.AuditFilterOpts{SubID: subID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+func (r *AuditRepo) ListAudit(ctx context.Context, opts domain.AuditFilterOpts) ([]*domain.AuditEntry, error) {
+	const op = "AuditRepo.ListAudit"
+
+	entries, err := r.listAudit(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+func (r *AuditRepo) listAudit(ctx context.Context, opts domain.AuditFilterOpts) ([]*domain.AuditEntry, error) {
+	query := fmt.Sprintf("SELECT %s FROM subs_audit WHERE tenant_id = %s", auditColumns, tenantID)
+	args := []any{}
+
+	addFilter := func(cond string, arg any) {
+		args = append(args, arg)
+		query = fmt.Sprintf("%s AND %s $%d", query, cond, len(args))
+	}
+
+	if opts.SubID != uuid.Nil {
+		addFilter("sub_id =", opts.SubID)
+	}
+
+	if opts.UserID != uuid.Nil {
+		addFilter("user_id =", opts.UserID)
+	}
+
+	if len(opts.Actor) != 0 {
+		addFilter("actor =", opts.Actor)
+	}
+
+	if !opts.From.IsZero() {
+		addFilter("created_at >=", opts.From)
+	}
+
+	if !opts.To.IsZero() {
+		addFilter("created_at <", opts.To)
+	}
+
+	if opts.PageToken != 0 {
+		addFilter("id >", opts.PageToken)
+	}
+
+	query += " ORDER BY id"
+
+	if opts.PageSize > 0 {
+		args = append(args, opts.PageSize)
+		query = fmt.Sprintf("%s LIMIT $%d", query, len(args))
+	}
+
+	var entries []*domain.AuditEntry
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		entries, err = scanAuditEntries(rows)
+
+		return err
+	})
+
+	return entries, err
+}
@@ -0,0 +1,312 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	pkgPostgres "subs-service/pkg/database/postgres"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	budgetColumns = `b.id, b.user_id, COALESCE(b.service_name, ''), COALESCE(b.category, ''), b.amount, b.thresholds,
+		b.created_at`
+)
+
+// budgetSpend selects charges at month m of subscriptions limited by budget b
+// the same way as GetSummary does for a period, including the user's shares of shared subscriptions.
+var budgetSpend = fmt.Sprintf(`COALESCE((
+	SELECT SUM(%s) FROM subs s%s
+		WHERE s.tenant_id = b.tenant_id AND %s AND s.deleted_at IS NULL
+			AND (b.service_name IS NULL OR %s) AND (b.category IS NULL OR s.category = b.category)
+			AND s.start_date <= m.month AND (s.end_date IS NULL OR s.end_date > m.month) AND NOT %s
+), 0)`,
+	shareOf("b.user_id"), splitJoin(chargeAt("m.month", priceAtMonth)), sharedWith("b.user_id"),
+	serviceMatch("s", "b.service_name"), pausedAt("s", "m.month"),
+)
+
+func scanBudget(row pgx.Row, dest ...any) (*domain.Budget, error) {
+	var budget domain.Budget
+
+	dest = append([]any{
+		&budget.ID, &budget.UserID, &budget.ServiceName, &budget.Category, &budget.Amount, &budget.Thresholds,
+		&budget.CreatedAt,
+	}, dest...)
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	return &budget, nil
+}
+
+func scanBudgetSpend(row pgx.Row) (*domain.BudgetSpend, error) {
+	var spend domain.BudgetSpend
+
+	budget, err := scanBudget(row, &spend.Spend)
+	if err != nil {
+		return nil, err
+	}
+
+	spend.Budget = budget
+
+	return &spend, nil
+}
+
+// BudgetsRepo gives requests access to budgets of their tenant only,
+// budgets of all tenants are checked by background workers.
+type BudgetsRepo struct {
+	cluster *pkgPostgres.Cluster
+}
+
+func NewBudgetsRepo(cluster *pkgPostgres.Cluster) *BudgetsRepo {
+	return &BudgetsRepo{
+		cluster: cluster,
+	}
+}
+
+func (r *BudgetsRepo) PostBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error) {
+	const op = "BudgetsRepo.PostBudget"
+
+	query := fmt.Sprintf(
+		`INSERT INTO budgets AS b (tenant_id, user_id, service_name, category, amount, thresholds)
+			VALUES (%s, $1, NULLIF($2, ''), NULLIF($3, ''), $4, $5) RETURNING %s`,
+		tenantID, budgetColumns,
+	)
+
+	var created *domain.Budget
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		var err error
+		created, err = scanBudget(tx.QueryRow(
+			ctx, query, budget.UserID, budget.ServiceName, budget.Category, budget.Amount, budget.Thresholds,
+		))
+
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+func (r *BudgetsRepo) GetBudget(ctx context.Context, id uuid.UUID) (*domain.Budget, error) {
+	const op = "BudgetsRepo.GetBudget"
+
+	query := fmt.Sprintf("SELECT %s FROM budgets b WHERE b.tenant_id = %s AND b.id = $1", budgetColumns, tenantID)
+
+	var budget *domain.Budget
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		var err error
+		budget, err = scanBudget(tx.QueryRow(ctx, query, id))
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoBudgetIDExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return budget, nil
+}
+
+func (r *BudgetsRepo) ListBudgets(ctx context.Context, userID uuid.UUID) ([]*domain.Budget, error) {
+	const op = "BudgetsRepo.ListBudgets"
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM budgets b WHERE b.tenant_id = %s AND b.user_id = $1 ORDER BY b.created_at, b.id",
+		budgetColumns, tenantID,
+	)
+
+	budgets := []*domain.Budget{}
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, userID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var budget *domain.Budget
+
+			if budget, err = scanBudget(rows); err != nil {
+				return err
+			}
+
+			budgets = append(budgets, budget)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return budgets, nil
+}
+
+// PutBudget keeps the owner of the budget.
+func (r *BudgetsRepo) PutBudget(ctx context.Context, id uuid.UUID, budget *domain.Budget) (*domain.Budget, error) {
+	const op = "BudgetsRepo.PutBudget"
+
+	query := fmt.Sprintf(
+		`UPDATE budgets AS b SET service_name = NULLIF($1, ''), category = NULLIF($2, ''), amount = $3, thresholds = $4
+			WHERE b.tenant_id = %s AND b.id = $5 RETURNING %s`,
+		tenantID, budgetColumns,
+	)
+
+	var updated *domain.Budget
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		var err error
+		updated, err = scanBudget(tx.QueryRow(
+			ctx, query, budget.ServiceName, budget.Category, budget.Amount, budget.Thresholds, id,
+		))
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoBudgetIDExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+func (r *BudgetsRepo) DeleteBudget(ctx context.Context, id uuid.UUID) error {
+	const op = "BudgetsRepo.DeleteBudget"
+
+	query := fmt.Sprintf("DELETE FROM budgets WHERE tenant_id = %s AND id = $1", tenantID)
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, id)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return repository.ErrNoBudgetIDExists
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *BudgetsRepo) GetBudgetSpend(ctx context.Context, id uuid.UUID, month time.Time) (*domain.BudgetSpend, error) {
+	const op = "BudgetsRepo.GetBudgetSpend"
+
+	query := fmt.Sprintf(
+		"SELECT %s, %s FROM budgets b CROSS JOIN (SELECT $2::date AS month) AS m WHERE b.tenant_id = %s AND b.id = $1",
+		budgetColumns, budgetSpend, tenantID,
+	)
+
+	var spend *domain.BudgetSpend
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		var err error
+		spend, err = scanBudgetSpend(tx.QueryRow(ctx, query, id, month))
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoBudgetIDExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return spend, nil
+}
+
+// ListBudgetSpends reads from the primary, since it is called right after subscriptions' changes.
+// Budgets of the user are looked up within the tenant from ctx.
+func (r *BudgetsRepo) ListBudgetSpends(ctx context.Context, userID uuid.UUID, month time.Time) ([]*domain.BudgetSpend, error) {
+	const op = "BudgetsRepo.ListBudgetSpends"
+
+	query := fmt.Sprintf(
+		`SELECT %s, %s FROM budgets b CROSS JOIN (SELECT $2::date AS month) AS m
+			WHERE $1 = '00000000-0000-0000-0000-000000000000'::uuid OR (b.tenant_id = $3 AND b.user_id = $1)
+			ORDER BY b.created_at, b.id`,
+		budgetColumns, budgetSpend,
+	)
+
+	rows, err := r.cluster.Primary().Query(ctx, query, userID, month, domain.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	spends := []*domain.BudgetSpend{}
+
+	for rows.Next() {
+		var spend *domain.BudgetSpend
+
+		if spend, err = scanBudgetSpend(rows); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		spends = append(spends, spend)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return spends, nil
+}
+
+func (r *BudgetsRepo) RecordAlert(ctx context.Context, alert *domain.BudgetAlert) (bool, error) {
+	const op = "BudgetsRepo.RecordAlert"
+
+	query :=
+		`INSERT INTO budget_alerts (tenant_id, budget_id, month, threshold, amount, spend)
+			SELECT tenant_id, $1, $2, $3, $4, $5 FROM budgets WHERE id = $1
+			ON CONFLICT (budget_id, month, threshold) DO NOTHING`
+
+	tag, err := r.cluster.Primary().Exec(
+		ctx, query, alert.BudgetID, alert.Month, alert.Threshold, alert.Amount, alert.Spend,
+	)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag.RowsAffected() != 0, nil
+}
+
+func (r *BudgetsRepo) DeleteAlert(ctx context.Context, alert *domain.BudgetAlert) error {
+	const op = "BudgetsRepo.DeleteAlert"
+
+	query := "DELETE FROM budget_alerts WHERE budget_id = $1 AND month = $2 AND threshold = $3"
+
+	if _, err := r.cluster.Primary().Exec(ctx, query, alert.BudgetID, alert.Month, alert.Threshold); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
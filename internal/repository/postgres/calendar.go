@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	pkgPostgres "subs-service/pkg/database/postgres"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type CalendarRepo struct {
+	cluster *pkgPostgres.Cluster
+}
+
+func NewCalendarRepo(cluster *pkgPostgres.Cluster) *CalendarRepo {
+	return &CalendarRepo{
+		cluster: cluster,
+	}
+}
+
+// PutFeedToken replaces the previous token of the user, if any.
+func (r *CalendarRepo) PutFeedToken(ctx context.Context, userID uuid.UUID, tokenHash []byte) error {
+	const op = "CalendarRepo.PutFeedToken"
+
+	query := fmt.Sprintf(
+		`INSERT INTO calendar_tokens (tenant_id, user_id, token_hash) VALUES (%s, $1, $2)
+			ON CONFLICT (tenant_id, user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = now()`,
+		tenantID,
+	)
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, query, userID, tokenHash)
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *CalendarRepo) DeleteFeedToken(ctx context.Context, userID uuid.UUID) error {
+	const op = "CalendarRepo.DeleteFeedToken"
+
+	query := fmt.Sprintf("DELETE FROM calendar_tokens WHERE tenant_id = %s AND user_id = $1", tenantID)
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, userID)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return repository.ErrNoFeedTokenExists
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// CheckFeedToken reads from the primary, so that a just created token is valid despite replicas' lag.
+func (r *CalendarRepo) CheckFeedToken(ctx context.Context, userID uuid.UUID, tokenHash []byte) (string, error) {
+	const op = "CalendarRepo.CheckFeedToken"
+
+	query := "SELECT tenant_id FROM calendar_tokens WHERE user_id = $1 AND token_hash = $2"
+
+	var tenant string
+	if err := r.cluster.Primary().QueryRow(ctx, query, userID, tokenHash).Scan(&tenant); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tenant, nil
+}
+
+func (r *CalendarRepo) ListCalendarSubs(ctx context.Context, userID uuid.UUID) ([]*domain.CalendarSub, error) {
+	const op = "CalendarRepo.ListCalendarSubs"
+
+	subsQuery := fmt.Sprintf(
+		`SELECT %s FROM subs
+			WHERE tenant_id = %s AND user_id = $1 AND deleted_at IS NULL AND (end_date IS NULL OR end_date > %s)
+			ORDER BY start_date, id`,
+		subColumns, tenantID, currentMonth,
+	)
+
+	pausesQuery := fmt.Sprintf(
+		`SELECT id, sub_id, from_month, COALESCE(to_month, '0001-01-01'::date)
+			FROM sub_pauses WHERE tenant_id = %s AND sub_id = ANY($1) ORDER BY from_month`,
+		tenantID,
+	)
+
+	subs := []*domain.CalendarSub{}
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		found, err := querySubs(ctx, tx, subsQuery, userID)
+		if err != nil || len(found) == 0 {
+			return err
+		}
+
+		byID := make(map[uuid.UUID]*domain.CalendarSub, len(found))
+		ids := make([]uuid.UUID, 0, len(found))
+
+		for _, sub := range found {
+			calendarSub := &domain.CalendarSub{Sub: sub}
+			subs = append(subs, calendarSub)
+			byID[sub.ID] = calendarSub
+			ids = append(ids, sub.ID)
+		}
+
+		rows, err := tx.Query(ctx, pausesQuery, ids)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var pause domain.Pause
+
+			if err = rows.Scan(&pause.ID, &pause.SubID, &pause.From, &pause.To); err != nil {
+				return err
+			}
+
+			byID[pause.SubID].Pauses = append(byID[pause.SubID].Pauses, &pause)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subs, nil
+}
@@ -0,0 +1,248 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	"subs-service/pkg/database"
+	pkgPostgres "subs-service/pkg/database/postgres"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// Canonical name is stored as an alias too, but it is not listed among aliases.
+	serviceColumns = `s.id, s.name, ARRAY(
+		SELECT a.alias FROM service_aliases a WHERE a.service_id = s.id AND a.alias <> lower(btrim(s.name))
+			ORDER BY a.alias
+	), s.category, s.currency, s.website, s.created_at`
+)
+
+// serviceMatch checks whether subscription sub belongs to the service named by SQL expression name:
+// subscriptions linked to the catalog are matched by the catalog entry the name resolves to,
+// other subscriptions are matched by the name itself.
+func serviceMatch(sub, name string) string {
+	return fmt.Sprintf(`(%[1]s.service_id = (SELECT a.service_id FROM service_aliases a WHERE a.alias = lower(btrim(%[2]s)))
+		OR (%[1]s.service_id IS NULL AND %[1]s.service_name = %[2]s))`, sub, name)
+}
+
+// resolveService links subscription to the catalog entry its service name is an alias of
+// and replaces the name with the canonical one. Subscription without category gets the category
+// of the entry. Unknown services are left unlinked.
+func resolveService(ctx context.Context, tx pgx.Tx, sub *domain.Sub) error {
+	query :=
+		`SELECT s.id, s.name, s.category FROM service_aliases a JOIN services s ON s.id = a.service_id
+			WHERE a.alias = lower(btrim($1))`
+
+	var category string
+
+	err := tx.QueryRow(ctx, query, sub.ServiceName).Scan(&sub.ServiceID, &sub.ServiceName, &category)
+	if errors.Is(err, pgx.ErrNoRows) {
+		sub.ServiceID = uuid.Nil
+		return nil
+	}
+
+	if err == nil && len(sub.Category) == 0 {
+		sub.Category = category
+	}
+
+	return err
+}
+
+// nullUUID stores nil id as NULL.
+func nullUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+
+	return &id
+}
+
+func scanService(row pgx.Row) (*domain.Service, error) {
+	var service domain.Service
+
+	if err := row.Scan(
+		&service.ID, &service.Name, &service.Aliases, &service.Category, &service.Currency,
+		&service.Website, &service.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &service, nil
+}
+
+// putAliases replaces aliases of the service, its canonical name is always one of them.
+func putAliases(ctx context.Context, tx pgx.Tx, id uuid.UUID, service *domain.Service) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM service_aliases WHERE service_id = $1", id); err != nil {
+		return err
+	}
+
+	query :=
+		`INSERT INTO service_aliases (alias, service_id)
+			SELECT DISTINCT lower(btrim(alias)), $1 FROM unnest($2::text[]) AS alias`
+
+	_, err := tx.Exec(ctx, query, id, append([]string{service.Name}, service.Aliases...))
+
+	return err
+}
+
+type CatalogRepo struct {
+	cluster *pkgPostgres.Cluster
+}
+
+func NewCatalogRepo(cluster *pkgPostgres.Cluster) *CatalogRepo {
+	return &CatalogRepo{
+		cluster: cluster,
+	}
+}
+
+func (r *CatalogRepo) PostService(ctx context.Context, service *domain.Service) (*domain.Service, error) {
+	const op = "CatalogRepo.PostService"
+
+	insertQuery :=
+		`INSERT INTO services (name, category, currency, website) VALUES (btrim($1), $2, $3, $4) RETURNING id`
+
+	selectQuery := fmt.Sprintf("SELECT %s FROM services s WHERE s.id = $1", serviceColumns)
+
+	var created *domain.Service
+
+	err := pkgPostgres.WithTx(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		var id uuid.UUID
+
+		if err := tx.QueryRow(
+			ctx, insertQuery, service.Name, service.Category, service.Currency, service.Website,
+		).Scan(&id); err != nil {
+			return err
+		}
+
+		if err := putAliases(ctx, tx, id, service); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = scanService(tx.QueryRow(ctx, selectQuery, id))
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(pkgPostgres.DetectError(err), database.ErrUniqueViolation) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrServiceAliasExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+func (r *CatalogRepo) GetService(ctx context.Context, id uuid.UUID) (*domain.Service, error) {
+	const op = "CatalogRepo.GetService"
+
+	query := fmt.Sprintf("SELECT %s FROM services s WHERE s.id = $1", serviceColumns)
+
+	service, err := scanService(r.cluster.Reader(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoServiceIDExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return service, nil
+}
+
+func (r *CatalogRepo) ListServices(ctx context.Context) ([]*domain.Service, error) {
+	const op = "CatalogRepo.ListServices"
+
+	query := fmt.Sprintf("SELECT %s FROM services s ORDER BY s.name, s.id", serviceColumns)
+
+	rows, err := r.cluster.Reader(ctx).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	services := []*domain.Service{}
+
+	for rows.Next() {
+		var service *domain.Service
+
+		if service, err = scanService(rows); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		services = append(services, service)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return services, nil
+}
+
+// PutService does not relink existing subscriptions, they are resolved again on their updates.
+func (r *CatalogRepo) PutService(ctx context.Context, id uuid.UUID, service *domain.Service) (*domain.Service, error) {
+	const op = "CatalogRepo.PutService"
+
+	updateQuery :=
+		`UPDATE services SET name = btrim($1), category = $2, currency = $3, website = $4 WHERE id = $5`
+
+	selectQuery := fmt.Sprintf("SELECT %s FROM services s WHERE s.id = $1", serviceColumns)
+
+	var updated *domain.Service
+
+	err := pkgPostgres.WithTx(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, updateQuery, service.Name, service.Category, service.Currency, service.Website, id)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return pgx.ErrNoRows
+		}
+
+		if err = putAliases(ctx, tx, id, service); err != nil {
+			return err
+		}
+
+		updated, err = scanService(tx.QueryRow(ctx, selectQuery, id))
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoServiceIDExists)
+		}
+
+		if errors.Is(pkgPostgres.DetectError(err), database.ErrUniqueViolation) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrServiceAliasExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// DeleteService unlinks subscriptions of the service, they keep its canonical name.
+func (r *CatalogRepo) DeleteService(ctx context.Context, id uuid.UUID) error {
+	const op = "CatalogRepo.DeleteService"
+
+	tag, err := r.cluster.Primary().Exec(ctx, "DELETE FROM services WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, repository.ErrNoServiceIDExists)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// serviceKey selects the normalized service of subscription sub: the catalog entry it is linked to
+// or its name is an alias of, otherwise the name in lower case without surrounding spaces.
+func serviceKey(sub string) string {
+	return fmt.Sprintf(`COALESCE(
+		COALESCE(
+			%[1]s.service_id, (SELECT a.service_id FROM service_aliases a WHERE a.alias = lower(btrim(%[1]s.service_name)))
+		)::text,
+		lower(btrim(%[1]s.service_name))
+	)`, sub)
+}
+
+// overlaps checks whether periods of subscriptions a and b overlap, end dates are exclusive.
+func overlaps(a, b string) string {
+	return fmt.Sprintf(`(%[1]s.start_date < COALESCE(%[2]s.end_date, 'infinity'::date)
+		AND %[2]s.start_date < COALESCE(%[1]s.end_date, 'infinity'::date))`, a, b)
+}
+
+// monthCharge selects the amount subscription with the given id is charged at month m,
+// paused months are not charged.
+func monthCharge(id string) string {
+	return fmt.Sprintf(
+		"(SELECT CASE WHEN %s THEN 0 ELSE %s END FROM subs s WHERE s.id = %s)",
+		pausedAt("s", "m.month::date"), chargeAt("m.month", priceAtMonth), id,
+	)
+}
+
+// checkOverlaps serializes creation of user's subscriptions, so that concurrent
+// overlapping subscriptions are not created.
+func checkOverlaps(ctx context.Context, tx pgx.Tx, sub *domain.Sub) error {
+	query := fmt.Sprintf(
+		`SELECT EXISTS (
+			SELECT 1 FROM subs s, (
+				SELECT $2::uuid AS service_id, $3::text AS service_name,
+					$4::date AS start_date, NULLIF($5::date, '0001-01-01'::date) AS end_date
+			) AS n
+			WHERE s.tenant_id = %s AND s.user_id = $1 AND s.deleted_at IS NULL AND %s = %s AND %s
+		)`,
+		tenantID, serviceKey("s"), serviceKey("n"), overlaps("s", "n"),
+	)
+
+	lockQuery := fmt.Sprintf("SELECT pg_advisory_xact_lock(hashtextextended(%s || '/' || $1::text, 0))", tenantID)
+
+	if _, err := tx.Exec(ctx, lockQuery, sub.UserID); err != nil {
+		return err
+	}
+
+	var exists bool
+
+	if err := tx.QueryRow(
+		ctx, query, sub.UserID, nullUUID(sub.ServiceID), sub.ServiceName, sub.StartDate, sub.EndDate,
+	).Scan(&exists); err != nil {
+		return err
+	}
+
+	if exists {
+		return repository.ErrSubOverlap
+	}
+
+	return nil
+}
+
+// ListDuplicates finds pairs of user's subscriptions to the same service with overlapping periods.
+func (r *SubsRepo) ListDuplicates(ctx context.Context, userID uuid.UUID) ([]*domain.Duplicate, error) {
+	const op = "SubsRepo.ListDuplicates"
+
+	pairsQuery := fmt.Sprintf(
+		`SELECT p.a_id, p.b_id, p.from_month, COALESCE(p.to_month, '0001-01-01'::date), COALESCE((
+				SELECT SUM(LEAST(%[1]s, %[2]s)) FROM generate_series(
+					p.from_month::timestamp,
+					LEAST(COALESCE(p.to_month - interval '1 month', %[3]s), %[3]s)::timestamp,
+					interval '1 month'
+				) AS m(month)
+			), 0)
+			FROM (
+				SELECT a.id AS a_id, b.id AS b_id,
+					GREATEST(a.start_date, b.start_date) AS from_month, LEAST(a.end_date, b.end_date) AS to_month
+				FROM subs a JOIN subs b ON b.tenant_id = a.tenant_id AND b.user_id = a.user_id AND a.id < b.id AND %[4]s = %[5]s AND %[6]s
+				WHERE a.tenant_id = %[7]s AND a.user_id = $1 AND a.deleted_at IS NULL AND b.deleted_at IS NULL
+			) AS p
+			ORDER BY p.from_month, p.a_id, p.b_id`,
+		monthCharge("p.a_id"), monthCharge("p.b_id"), currentMonth, serviceKey("a"), serviceKey("b"), overlaps("a", "b"),
+		tenantID,
+	)
+
+	subsQuery := fmt.Sprintf("SELECT %s FROM subs WHERE tenant_id = %s AND id = ANY($1)", subColumns, tenantID)
+
+	duplicates := []*domain.Duplicate{}
+	pairs := [][2]uuid.UUID{}
+	ids := []uuid.UUID{}
+
+	var subs []*domain.Sub
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, pairsQuery, userID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var duplicate domain.Duplicate
+			var pair [2]uuid.UUID
+
+			if err = rows.Scan(&pair[0], &pair[1], &duplicate.From, &duplicate.To, &duplicate.WastedSpend); err != nil {
+				return err
+			}
+
+			duplicates = append(duplicates, &duplicate)
+			pairs = append(pairs, pair)
+			ids = append(ids, pair[0], pair[1])
+		}
+
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		subs, err = querySubs(ctx, tx, subsQuery, ids)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	byID := make(map[uuid.UUID]*domain.Sub, len(subs))
+	for _, sub := range subs {
+		byID[sub.ID] = sub
+	}
+
+	// Subscriptions purged in between are skipped along with their duplicates.
+	found := duplicates[:0]
+
+	for i, duplicate := range duplicates {
+		a, b := byID[pairs[i][0]], byID[pairs[i][1]]
+		if a == nil || b == nil {
+			continue
+		}
+
+		duplicate.ServiceName = a.ServiceName
+		duplicate.Subs = []*domain.Sub{a, b}
+		found = append(found, duplicate)
+	}
+
+	return found, nil
+}
@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	pkgPostgres "subs-service/pkg/database/postgres"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type ExportsRepo struct {
+	cluster *pkgPostgres.Cluster
+}
+
+func NewExportsRepo(cluster *pkgPostgres.Cluster) *ExportsRepo {
+	return &ExportsRepo{
+		cluster: cluster,
+	}
+}
+
+// GetUserData reads from the primary, so that the export includes changes made right before the request.
+func (r *ExportsRepo) GetUserData(ctx context.Context, userID uuid.UUID) (*domain.UserData, error) {
+	const op = "ExportsRepo.GetUserData"
+
+	subsQuery := fmt.Sprintf(
+		"SELECT %s FROM subs WHERE tenant_id = %s AND user_id = $1 ORDER BY start_date, id",
+		subColumns, tenantID,
+	)
+
+	historyQuery := fmt.Sprintf(
+		"SELECT %s FROM subs_audit WHERE tenant_id = %s AND user_id = $1 ORDER BY id",
+		auditColumns, tenantID,
+	)
+
+	budgetsQuery := fmt.Sprintf(
+		"SELECT %s FROM budgets b WHERE b.tenant_id = %s AND b.user_id = $1 ORDER BY b.created_at, b.id",
+		budgetColumns, tenantID,
+	)
+
+	notificationsQuery := fmt.Sprintf(
+		`SELECT n.key, n.sent_at FROM sent_notifications n WHERE n.tenant_id = %[1]s AND n.user_id = $1
+		UNION ALL
+		SELECT 'budget:' || a.budget_id || ':' || to_char(a.month, 'MM-YYYY') || ':' || a.threshold, a.created_at
+			FROM budget_alerts a JOIN budgets b ON b.id = a.budget_id
+			WHERE b.tenant_id = %[1]s AND b.user_id = $1
+		ORDER BY 2, 1`,
+		tenantID,
+	)
+
+	var data domain.UserData
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		var err error
+
+		if data.Subs, err = querySubs(ctx, tx, subsQuery, userID); err != nil {
+			return err
+		}
+
+		if data.History, err = queryAudit(ctx, tx, historyQuery, userID); err != nil {
+			return err
+		}
+
+		if data.Budgets, err = queryBudgets(ctx, tx, budgetsQuery, userID); err != nil {
+			return err
+		}
+
+		data.Notifications, err = queryNotifications(ctx, tx, notificationsQuery, userID)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &data, nil
+}
+
+func (r *ExportsRepo) CompleteExport(ctx context.Context, id uuid.UUID, archive []byte) error {
+	const op = "ExportsRepo.CompleteExport"
+
+	query := fmt.Sprintf("UPDATE data_exports SET archive = $2 WHERE tenant_id = %s AND id = $1", tenantID)
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, id, archive)
+		if err != nil {
+			return err
+		}
+
+		// The export is deleted along with all user's data during the erasure.
+		if tag.RowsAffected() == 0 {
+			return repository.ErrNoExportIDExists
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func queryAudit(ctx context.Context, conn querier, query string, args ...any) ([]*domain.AuditEntry, error) {
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAuditEntries(rows)
+}
+
+func queryBudgets(ctx context.Context, conn querier, query string, args ...any) ([]*domain.Budget, error) {
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	budgets := []*domain.Budget{}
+
+	for rows.Next() {
+		budget, scanErr := scanBudget(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+
+		budgets = append(budgets, budget)
+	}
+
+	return budgets, rows.Err()
+}
+
+func queryNotifications(ctx context.Context, conn querier, query string, args ...any) ([]*domain.NotificationRecord, error) {
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := []*domain.NotificationRecord{}
+
+	for rows.Next() {
+		var notification domain.NotificationRecord
+
+		if err = rows.Scan(&notification.Key, &notification.SentAt); err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, &notification)
+	}
+
+	return notifications, rows.Err()
+}
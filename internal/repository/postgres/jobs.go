@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	pkgPostgres "subs-service/pkg/database/postgres"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const jobColumns = `j.id, j.type, j.payload, j.status, j.progress, j.result, j.error, j.attempts,
+	j.created_at, j.updated_at, j.completed_at`
+
+func scanJob(row pgx.Row, dest ...any) (*domain.Job, error) {
+	var job domain.Job
+	var payload, result []byte
+
+	dest = append([]any{
+		&job.ID, &job.Type, &payload, &job.Status, &job.Progress, &result, &job.Error, &job.Attempts,
+		&job.CreatedAt, &job.UpdatedAt, &job.CompletedAt,
+	}, dest...)
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	job.Payload, job.Result = payload, result
+
+	return &job, nil
+}
+
+// insertJob enqueues the job of the request's tenant within the transaction of the operation, which requests it.
+func insertJob(ctx context.Context, tx pgx.Tx, jobType string, payload any) (uuid.UUID, error) {
+	query := fmt.Sprintf("INSERT INTO jobs (tenant_id, type, payload) VALUES (%s, $1, $2) RETURNING id", tenantID)
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, query, jobType, data).Scan(&id)
+
+	return id, err
+}
+
+type JobsRepo struct {
+	cluster *pkgPostgres.Cluster
+}
+
+func NewJobsRepo(cluster *pkgPostgres.Cluster) *JobsRepo {
+	return &JobsRepo{
+		cluster: cluster,
+	}
+}
+
+// GetJob reads from the primary, since progress of running jobs changes often.
+func (r *JobsRepo) GetJob(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	const op = "JobsRepo.GetJob"
+
+	query := fmt.Sprintf("SELECT %s FROM jobs j WHERE j.tenant_id = %s AND j.id = $1", jobColumns, tenantID)
+
+	var job *domain.Job
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		var err error
+		job, err = scanJob(tx.QueryRow(ctx, query, id))
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoJobIDExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return job, nil
+}
+
+// CancelJob doesn't interrupt the running handler immediately, the runner stops it
+// once it finds out the job is cancelled.
+func (r *JobsRepo) CancelJob(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	const op = "JobsRepo.CancelJob"
+
+	lockQuery := fmt.Sprintf("SELECT %s FROM jobs j WHERE j.tenant_id = %s AND j.id = $1 FOR UPDATE", jobColumns, tenantID)
+
+	cancelQuery := fmt.Sprintf(
+		`UPDATE jobs j SET status = 'cancelled', lease_until = NULL, updated_at = now(), completed_at = now()
+			WHERE j.id = $1 RETURNING %s`,
+		jobColumns,
+	)
+
+	var job *domain.Job
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		var err error
+
+		if job, err = scanJob(tx.QueryRow(ctx, lockQuery, id)); err != nil {
+			return err
+		}
+
+		if job.Finished() {
+			return repository.ErrJobFinished
+		}
+
+		job, err = scanJob(tx.QueryRow(ctx, cancelQuery, id))
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoJobIDExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return job, nil
+}
+
+// JobQueueRepo runs jobs of all tenants.
+type JobQueueRepo struct {
+	cluster *pkgPostgres.Cluster
+}
+
+func NewJobQueueRepo(cluster *pkgPostgres.Cluster) *JobQueueRepo {
+	return &JobQueueRepo{
+		cluster: cluster,
+	}
+}
+
+func (r *JobQueueRepo) ClaimJob(ctx context.Context, types []string, lease time.Duration) (*domain.Job, error) {
+	const op = "JobQueueRepo.ClaimJob"
+
+	query := fmt.Sprintf(
+		`WITH due AS (
+			SELECT id FROM jobs
+				WHERE type = ANY($1) AND (
+					(status = 'pending' AND next_attempt_at <= now()) OR (status = 'running' AND lease_until <= now())
+				)
+				ORDER BY next_attempt_at LIMIT 1 FOR UPDATE SKIP LOCKED
+		)
+		UPDATE jobs j SET status = 'running', attempts = j.attempts + 1,
+			lease_until = now() + $2 * interval '1 second', updated_at = now()
+			FROM due WHERE j.id = due.id
+			RETURNING %s, j.tenant_id`,
+		jobColumns,
+	)
+
+	var tenant string
+
+	job, err := scanJob(r.cluster.Primary().QueryRow(ctx, query, types, lease.Seconds()), &tenant)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	job.TenantID = tenant
+
+	return job, nil
+}
+
+func (r *JobQueueRepo) ExtendLease(ctx context.Context, id uuid.UUID, attempt int, lease time.Duration) error {
+	const op = "JobQueueRepo.ExtendLease"
+
+	query :=
+		`UPDATE jobs SET lease_until = now() + $3 * interval '1 second'
+			WHERE id = $1 AND attempts = $2 AND status = 'running'`
+
+	if err := r.updateRunning(ctx, query, id, attempt, lease.Seconds()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *JobQueueRepo) SetJobProgress(ctx context.Context, id uuid.UUID, attempt int, progress int) error {
+	const op = "JobQueueRepo.SetJobProgress"
+
+	query :=
+		`UPDATE jobs SET progress = $3, updated_at = now()
+			WHERE id = $1 AND attempts = $2 AND status = 'running'`
+
+	if err := r.updateRunning(ctx, query, id, attempt, progress); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *JobQueueRepo) CompleteJob(ctx context.Context, id uuid.UUID, attempt int, result []byte) error {
+	const op = "JobQueueRepo.CompleteJob"
+
+	query :=
+		`UPDATE jobs SET status = 'completed', progress = 100, result = $3, error = '', lease_until = NULL,
+			updated_at = now(), completed_at = now()
+			WHERE id = $1 AND attempts = $2 AND status = 'running'`
+
+	if err := r.updateRunning(ctx, query, id, attempt, result); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *JobQueueRepo) FailJob(ctx context.Context, id uuid.UUID, attempt int, reason string, retryAt time.Time) error {
+	const op = "JobQueueRepo.FailJob"
+
+	query :=
+		`UPDATE jobs SET error = $3, lease_until = NULL, updated_at = now(),
+			status = CASE WHEN $4::timestamptz IS NULL THEN 'failed' ELSE 'pending' END,
+			next_attempt_at = COALESCE($4, next_attempt_at),
+			completed_at = CASE WHEN $4::timestamptz IS NULL THEN now() END
+			WHERE id = $1 AND attempts = $2 AND status = 'running'`
+
+	var next *time.Time
+	if !retryAt.IsZero() {
+		next = &retryAt
+	}
+
+	if err := r.updateRunning(ctx, query, id, attempt, reason, next); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *JobQueueRepo) PurgeJobs(ctx context.Context, before time.Time) (int64, error) {
+	const op = "JobQueueRepo.PurgeJobs"
+
+	tag, err := r.cluster.Primary().Exec(ctx, "DELETE FROM jobs WHERE completed_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// updateRunning updates the running attempt of the job, the job and the attempt are the first arguments of the query.
+func (r *JobQueueRepo) updateRunning(ctx context.Context, query string, id uuid.UUID, attempt int, args ...any) error {
+	tag, err := r.cluster.Primary().Exec(ctx, query, append([]any{id, attempt}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return repository.ErrJobNotRunning
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// splitJoin joins charge c of subscription s, which is given by SQL expression, and totals f
+// of its members, so that shares are computed without repeating the charge expression.
+func splitJoin(charge string) string {
+	return fmt.Sprintf(` CROSS JOIN LATERAL (SELECT %s AS amount) AS c
+		CROSS JOIN LATERAL (
+			SELECT COALESCE(SUM(x.amount), 0) AS fixed, COALESCE(SUM(x.weight), 0) + 1 AS weights
+				FROM sub_members x WHERE x.sub_id = s.id
+		) AS f`, charge)
+}
+
+// memberShare selects the share of member sm in charge c of subscription s: fixed amounts are paid first
+// and are scaled down, if they exceed the charge, the rest is split by weights, the owner has weight 1.
+func memberShare(member string) string {
+	return fmt.Sprintf(`CASE WHEN %[1]s.amount IS NOT NULL THEN %[1]s.amount * LEAST(f.fixed, c.amount) / f.fixed
+		ELSE (c.amount - LEAST(f.fixed, c.amount)) * %[1]s.weight / f.weights END`, member)
+}
+
+// shareOf selects the share of user in charge c of subscription s: members pay their shares,
+// the owner pays the rest including what is left after rounding of the shares.
+func shareOf(user string) string {
+	return fmt.Sprintf(`CASE WHEN s.user_id = %[1]s
+		THEN c.amount - COALESCE((SELECT SUM(%[2]s) FROM sub_members sm WHERE sm.sub_id = s.id), 0)
+		ELSE (SELECT %[2]s FROM sub_members sm WHERE sm.sub_id = s.id AND sm.user_id = %[1]s) END`,
+		user, memberShare("sm"),
+	)
+}
+
+// sharedWith checks whether subscription s is owned by user or shared with them.
+func sharedWith(user string) string {
+	return fmt.Sprintf(
+		"(s.user_id = %[1]s OR EXISTS (SELECT 1 FROM sub_members sm WHERE sm.sub_id = s.id AND sm.user_id = %[1]s))",
+		user,
+	)
+}
+
+// PutMember adds the member to the subscription or replaces the share of existing one.
+func (r *SubsRepo) PutMember(ctx context.Context, member *domain.Member) (*domain.Member, error) {
+	const op = "SubsRepo.PutMember"
+
+	ownerQuery := fmt.Sprintf(
+		"SELECT user_id FROM subs WHERE tenant_id = %s AND id = $1 AND deleted_at IS NULL FOR UPDATE", tenantID,
+	)
+
+	upsertQuery := fmt.Sprintf(
+		`INSERT INTO sub_members (tenant_id, sub_id, user_id, weight, amount) VALUES (%s, $1, $2, NULLIF($3, 0), NULLIF($4, 0))
+			ON CONFLICT (sub_id, user_id) DO UPDATE SET weight = EXCLUDED.weight, amount = EXCLUDED.amount
+			RETURNING created_at`,
+		tenantID,
+	)
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		var owner uuid.UUID
+		if err := tx.QueryRow(ctx, ownerQuery, member.SubID).Scan(&owner); err != nil {
+			return err
+		}
+
+		if owner == member.UserID {
+			return repository.ErrMemberIsOwner
+		}
+
+		return tx.QueryRow(
+			ctx, upsertQuery, member.SubID, member.UserID, member.Weight, member.Amount,
+		).Scan(&member.CreatedAt)
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoSubIDExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return member, nil
+}
+
+func (r *SubsRepo) DeleteMember(ctx context.Context, subID, userID uuid.UUID) error {
+	const op = "SubsRepo.DeleteMember"
+
+	query := fmt.Sprintf(
+		`DELETE FROM sub_members sm USING subs s
+			WHERE sm.tenant_id = %s AND sm.sub_id = $1 AND sm.user_id = $2 AND s.id = sm.sub_id AND s.deleted_at IS NULL`,
+		tenantID,
+	)
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, subID, userID)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return repository.ErrNoMemberExists
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *SubsRepo) ListMembers(ctx context.Context, subID uuid.UUID) ([]*domain.Member, error) {
+	const op = "SubsRepo.ListMembers"
+
+	query := fmt.Sprintf(
+		`SELECT sub_id, user_id, COALESCE(weight, 0), COALESCE(amount, 0), created_at
+			FROM sub_members WHERE tenant_id = %s AND sub_id = $1 ORDER BY created_at, user_id`,
+		tenantID,
+	)
+
+	members := []*domain.Member{}
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		if err := checkSubExists(ctx, tx, subID); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, query, subID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var member domain.Member
+
+			if err = rows.Scan(&member.SubID, &member.UserID, &member.Weight, &member.Amount, &member.CreatedAt); err != nil {
+				return err
+			}
+
+			members = append(members, &member)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return members, nil
+}
+
+// GetSettlement sums shares members owe owners of subscriptions shared by or with the user
+// for each month of the period. Shares are charged the same way GetSummary sums them.
+func (r *SubsRepo) GetSettlement(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Debt, error) {
+	const op = "SubsRepo.GetSettlement"
+
+	// End date is exclusive, start date is inclusive as well as period bounds.
+	query := fmt.Sprintf(
+		`SELECT m.month, sm.user_id, s.user_id, SUM(%s) FROM subs s
+			CROSS JOIN LATERAL generate_series(
+				GREATEST(s.start_date, $2::date)::timestamp,
+				LEAST(COALESCE(s.end_date - interval '1 month', $3::date), $3::date)::timestamp,
+				interval '1 month'
+			) AS m(month)
+			%s
+			JOIN sub_members sm ON sm.sub_id = s.id
+			WHERE s.tenant_id = %s AND s.deleted_at IS NULL AND (s.user_id = $1 OR sm.user_id = $1) AND NOT %s
+			GROUP BY m.month, sm.user_id, s.user_id
+			ORDER BY m.month, sm.user_id, s.user_id`,
+		memberShare("sm"), splitJoin(chargeAt("m.month", priceAtMonth)), tenantID, pausedAt("s", "m.month::date"),
+	)
+
+	debts := []*domain.Debt{}
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, userID, from, to)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var debt domain.Debt
+
+			if err = rows.Scan(&debt.Month, &debt.Debtor, &debt.Creditor, &debt.Amount); err != nil {
+				return err
+			}
+
+			debts = append(debts, &debt)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return debts, nil
+}
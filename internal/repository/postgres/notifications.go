@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"subs-service/internal/domain"
+	pkgPostgres "subs-service/pkg/database/postgres"
+)
+
+type NotificationsRepo struct {
+	cluster *pkgPostgres.Cluster
+}
+
+func NewNotificationsRepo(cluster *pkgPostgres.Cluster) *NotificationsRepo {
+	return &NotificationsRepo{
+		cluster: cluster,
+	}
+}
+
+func (r *NotificationsRepo) ClaimNotification(ctx context.Context, reminder *domain.Reminder) (bool, error) {
+	const op = "NotificationsRepo.ClaimNotification"
+
+	query := `INSERT INTO sent_notifications (key, tenant_id, user_id, sub_id) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key) DO NOTHING`
+
+	tag, err := r.cluster.Primary().Exec(ctx, query, reminder.Key(), reminder.TenantID, reminder.UserID, reminder.SubID)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *NotificationsRepo) ReleaseNotification(ctx context.Context, key string) error {
+	const op = "NotificationsRepo.ReleaseNotification"
+
+	if _, err := r.cluster.Primary().Exec(ctx, "DELETE FROM sent_notifications WHERE key = $1", key); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
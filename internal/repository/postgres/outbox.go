@@ -0,0 +1,224 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"subs-service/internal/domain"
+	pkgPostgres "subs-service/pkg/database/postgres"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// recordChange writes subscription's change to the audit log, publishes it to the webhook outbox
+// and notifies subscribers of the stream within the transaction of the change itself.
+func recordChange(ctx context.Context, tx pgx.Tx, operation domain.AuditOperation, before, after *domain.Sub) error {
+	id, err := insertAudit(ctx, tx, operation, before, after)
+	if err != nil {
+		return err
+	}
+
+	event, ok := domain.AuditWebhookEvent(operation)
+	if !ok {
+		return nil
+	}
+
+	sub := after
+	if sub == nil {
+		sub = before
+	}
+
+	if err = insertEvent(ctx, tx, event, sub); err != nil {
+		return err
+	}
+
+	return notifySubEvent(ctx, tx, id, event, sub)
+}
+
+// insertEvent writes event to the outbox along with deliveries to all subscribed webhooks of the subscription's tenant.
+// Events nobody is subscribed to are not stored.
+func insertEvent(ctx context.Context, tx pgx.Tx, event domain.WebhookEvent, sub *domain.Sub) error {
+	query :=
+		`WITH targets AS (
+			SELECT id FROM webhooks WHERE tenant_id = $5 AND (cardinality(events) = 0 OR $1 = ANY (events))
+		), event AS (
+			INSERT INTO webhook_events (tenant_id, event, sub_id, user_id, payload)
+				SELECT $5, $1, $2, $3, $4 WHERE EXISTS (SELECT 1 FROM targets) RETURNING id
+		)
+		INSERT INTO webhook_deliveries (tenant_id, webhook_id, event_id)
+			SELECT $5, targets.id, event.id FROM targets CROSS JOIN event`
+
+	payload, err := json.Marshal(sub)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, string(event), sub.ID, sub.UserID, payload, sub.TenantID)
+
+	return err
+}
+
+type OutboxRepo struct {
+	cluster *pkgPostgres.Cluster
+}
+
+func NewOutboxRepo(cluster *pkgPostgres.Cluster) *OutboxRepo {
+	return &OutboxRepo{
+		cluster: cluster,
+	}
+}
+
+func (r *OutboxRepo) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutgoingDelivery, error) {
+	const op = "OutboxRepo.ClaimDeliveries"
+
+	query :=
+		`WITH due AS (
+			SELECT id FROM webhook_deliveries WHERE status = 'pending' AND next_attempt_at <= now()
+				ORDER BY next_attempt_at LIMIT $1 FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE webhook_deliveries d SET next_attempt_at = now() + $2 * interval '1 second'
+				FROM due WHERE d.id = due.id
+				RETURNING d.id, d.attempts, d.webhook_id, d.event_id
+		)
+		SELECT c.id, c.attempts, w.url, w.secret, e.id, e.event, e.created_at, e.payload
+			FROM claimed c
+			JOIN webhooks w ON w.id = c.webhook_id
+			JOIN webhook_events e ON e.id = c.event_id`
+
+	rows, err := r.cluster.Primary().Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	deliveries := []*domain.OutgoingDelivery{}
+
+	for rows.Next() {
+		var delivery domain.OutgoingDelivery
+		var event string
+
+		if err = rows.Scan(
+			&delivery.ID, &delivery.Attempts, &delivery.URL, &delivery.Secret,
+			&delivery.Payload.EventID, &event, &delivery.Payload.CreatedAt, &delivery.Payload.Data,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		delivery.Payload.Event = domain.WebhookEvent(event)
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *OutboxRepo) CompleteDelivery(ctx context.Context, id int64) error {
+	const op = "OutboxRepo.CompleteDelivery"
+
+	query :=
+		`UPDATE webhook_deliveries SET status = 'delivered', attempts = attempts + 1,
+			last_error = '', delivered_at = now() WHERE id = $1`
+
+	if _, err := r.cluster.Primary().Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepo) FailDelivery(ctx context.Context, id int64, reason string, retryAt time.Time) error {
+	const op = "OutboxRepo.FailDelivery"
+
+	query :=
+		`UPDATE webhook_deliveries SET attempts = attempts + 1, last_error = $2,
+			status = CASE WHEN $3::timestamptz IS NULL THEN 'dead' ELSE 'pending' END,
+			next_attempt_at = COALESCE($3, next_attempt_at)
+			WHERE id = $1`
+
+	var next *time.Time
+	if !retryAt.IsZero() {
+		next = &retryAt
+	}
+
+	if _, err := r.cluster.Primary().Exec(ctx, query, id, reason, next); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// EmitEndedEvents emits events of all tenants, it is deduplicated with sent notifications,
+// as outbox events are purged eventually.
+func (r *OutboxRepo) EmitEndedEvents(ctx context.Context, since time.Time) (int64, error) {
+	const op = "OutboxRepo.EmitEndedEvents"
+
+	selectQuery := fmt.Sprintf(
+		"SELECT %s FROM subs WHERE deleted_at IS NULL AND end_date > $1 AND end_date <= now()", subColumns,
+	)
+
+	claimQuery := `INSERT INTO sent_notifications (key, tenant_id, user_id, sub_id) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key) DO NOTHING`
+
+	var emitted int64
+
+	err := pkgPostgres.WithTx(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, selectQuery, since)
+		if err != nil {
+			return err
+		}
+
+		subs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Sub, error) {
+			return scanSub(row)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, sub := range subs {
+			key := fmt.Sprintf("webhook:%s:%s:%s", domain.EventSubEnded, sub.ID, sub.EndDate.Format(time.DateOnly))
+
+			tag, execErr := tx.Exec(ctx, claimQuery, key, sub.TenantID, sub.UserID, sub.ID)
+			if execErr != nil {
+				return execErr
+			}
+
+			if tag.RowsAffected() == 0 {
+				continue
+			}
+
+			if err = insertEvent(ctx, tx, domain.EventSubEnded, sub); err != nil {
+				return err
+			}
+
+			emitted++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return emitted, nil
+}
+
+func (r *OutboxRepo) PurgeEvents(ctx context.Context, before time.Time) (int64, error) {
+	const op = "OutboxRepo.PurgeEvents"
+
+	query :=
+		`DELETE FROM webhook_events e WHERE e.created_at < $1 AND NOT EXISTS (
+			SELECT 1 FROM webhook_deliveries d WHERE d.event_id = e.id AND d.status <> 'delivered'
+		)`
+
+	tag, err := r.cluster.Primary().Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
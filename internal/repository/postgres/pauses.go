@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	"subs-service/pkg/database"
+	pkgPostgres "subs-service/pkg/database/postgres"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	currentMonth = "date_trunc('month', now())::date"
+)
+
+// pausedAt checks whether billing of subscription sub is paused at month,
+// both are SQL expressions.
+func pausedAt(sub, month string) string {
+	return fmt.Sprintf(`EXISTS (
+		SELECT 1 FROM sub_pauses pa WHERE pa.sub_id = %[1]s.id AND pa.from_month <= %[2]s
+			AND (pa.to_month IS NULL OR pa.to_month >= %[2]s)
+	)`, sub, month)
+}
+
+// checkSubExists checks whether the subscription exists and is not deleted.
+func checkSubExists(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM subs WHERE tenant_id = %s AND id = $1 AND deleted_at IS NULL)", tenantID)
+
+	var exists bool
+	if err := tx.QueryRow(ctx, query, id).Scan(&exists); err != nil {
+		return err
+	}
+
+	if !exists {
+		return repository.ErrNoSubIDExists
+	}
+
+	return nil
+}
+
+func checkPause(sub *domain.Sub, pause *domain.Pause) error {
+	if pause.From.Before(sub.StartDate) || (!sub.EndDate.IsZero() && !pause.From.Before(sub.EndDate)) {
+		return repository.ErrInvalidPause
+	}
+
+	return nil
+}
+
+func (r *SubsRepo) PauseSub(ctx context.Context, pause *domain.Pause) (*domain.Pause, error) {
+	const op = "SubsRepo.PauseSub"
+
+	selectQuery := fmt.Sprintf(
+		"SELECT %s FROM subs WHERE tenant_id = %s AND id = $1 AND deleted_at IS NULL FOR UPDATE", subColumns, tenantID,
+	)
+
+	overlapQuery := fmt.Sprintf(
+		`SELECT EXISTS (
+			SELECT 1 FROM sub_pauses WHERE tenant_id = %s AND sub_id = $1
+				AND from_month <= COALESCE(NULLIF($3::date, '0001-01-01'::date), 'infinity'::date)
+				AND COALESCE(to_month, 'infinity'::date) >= $2::date
+		)`,
+		tenantID,
+	)
+
+	insertQuery := fmt.Sprintf(
+		`INSERT INTO sub_pauses (tenant_id, sub_id, from_month, to_month)
+			VALUES (%s, $1, $2, NULLIF($3, '0001-01-01'::date)) RETURNING id`,
+		tenantID,
+	)
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		before, err := scanSub(tx.QueryRow(ctx, selectQuery, pause.SubID))
+		if err != nil {
+			return err
+		}
+
+		if err = checkPause(before, pause); err != nil {
+			return err
+		}
+
+		var overlaps bool
+		if err = tx.QueryRow(ctx, overlapQuery, pause.SubID, pause.From, pause.To).Scan(&overlaps); err != nil {
+			return err
+		}
+
+		if overlaps {
+			return repository.ErrPauseOverlap
+		}
+
+		if err = tx.QueryRow(ctx, insertQuery, pause.SubID, pause.From, pause.To).Scan(&pause.ID); err != nil {
+			return err
+		}
+
+		after, err := scanSub(tx.QueryRow(ctx, selectQuery, pause.SubID))
+		if err != nil {
+			return err
+		}
+
+		return recordChange(ctx, tx, domain.AuditPause, before, after)
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoSubIDExists)
+		}
+
+		if errors.Is(pkgPostgres.DetectError(err), database.ErrCheckViolation) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrInvalidPause)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pause, nil
+}
+
+// ResumeSub resumes billing starting from the given month: the pause covering it is cut
+// right before that month or removed, if it starts at that month.
+func (r *SubsRepo) ResumeSub(ctx context.Context, id uuid.UUID, month time.Time) (*domain.Sub, error) {
+	const op = "SubsRepo.ResumeSub"
+
+	selectQuery := fmt.Sprintf(
+		"SELECT %s FROM subs WHERE tenant_id = %s AND id = $1 AND deleted_at IS NULL FOR UPDATE", subColumns, tenantID,
+	)
+
+	cutQuery := fmt.Sprintf(
+		`UPDATE sub_pauses SET to_month = ($2::date - interval '1 month')::date
+			WHERE tenant_id = %s AND sub_id = $1 AND from_month < $2 AND (to_month IS NULL OR to_month >= $2)`,
+		tenantID,
+	)
+
+	deleteQuery := fmt.Sprintf("DELETE FROM sub_pauses WHERE tenant_id = %s AND sub_id = $1 AND from_month = $2", tenantID)
+
+	var after *domain.Sub
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		before, err := scanSub(tx.QueryRow(ctx, selectQuery, id))
+		if err != nil {
+			return err
+		}
+
+		var resumed int64
+
+		for _, query := range []string{cutQuery, deleteQuery} {
+			tag, execErr := tx.Exec(ctx, query, id, month)
+			if execErr != nil {
+				return execErr
+			}
+
+			resumed += tag.RowsAffected()
+		}
+
+		if resumed == 0 {
+			return repository.ErrSubNotPaused
+		}
+
+		if after, err = scanSub(tx.QueryRow(ctx, selectQuery, id)); err != nil {
+			return err
+		}
+
+		return recordChange(ctx, tx, domain.AuditResume, before, after)
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoSubIDExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return after, nil
+}
+
+func (r *SubsRepo) ListPauses(ctx context.Context, subID uuid.UUID) ([]*domain.Pause, error) {
+	const op = "SubsRepo.ListPauses"
+
+	query := fmt.Sprintf(
+		`SELECT id, sub_id, from_month, COALESCE(to_month, '0001-01-01'::date)
+			FROM sub_pauses WHERE tenant_id = %s AND sub_id = $1 ORDER BY from_month`,
+		tenantID,
+	)
+
+	pauses := []*domain.Pause{}
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		if err := checkSubExists(ctx, tx, subID); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, query, subID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var pause domain.Pause
+
+			if err = rows.Scan(&pause.ID, &pause.SubID, &pause.From, &pause.To); err != nil {
+				return err
+			}
+
+			pauses = append(pauses, &pause)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pauses, nil
+}
@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	"subs-service/pkg/database"
+	pkgPostgres "subs-service/pkg/database/postgres"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// priceAtMonth selects price of subscription s in effect at month m.
+	// Subscriptions without price history are charged by their price.
+	priceAtMonth = `COALESCE((
+		SELECT p.price FROM sub_prices p WHERE p.sub_id = s.id AND p.effective_from <= m.month
+			ORDER BY p.effective_from DESC LIMIT 1
+	), s.price)`
+)
+
+// trialEnd selects the month of the first regular charge of subscription sub.
+func trialEnd(sub string) string {
+	return fmt.Sprintf("(%[1]s.start_date + %[1]s.trial_months * interval '1 month')", sub)
+}
+
+// chargeAt selects the amount subscription s is charged at month by the given regular price:
+// trial months are charged by the promotional price (zero for free trials). Subscriptions without
+// a trial are always charged by the regular price, even at months before their start (e.g. by
+// the default summary, which sums current prices).
+func chargeAt(month, price string) string {
+	return fmt.Sprintf(
+		"CASE WHEN s.trial_months > 0 AND %s < %s THEN COALESCE(s.promo_price, 0) ELSE %s END", month, trialEnd("s"), price,
+	)
+}
+
+// normalizePrices makes subscription's price history consistent with its data:
+// history starts exactly at the start date and subs.price is the latest price.
+func normalizePrices(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	baseQuery := fmt.Sprintf(
+		`INSERT INTO sub_prices (tenant_id, sub_id, effective_from, price)
+			SELECT s.tenant_id, s.id, s.start_date, COALESCE(
+				(SELECT p.price FROM sub_prices p WHERE p.sub_id = s.id AND p.effective_from <= s.start_date
+					ORDER BY p.effective_from DESC LIMIT 1),
+				(SELECT p.price FROM sub_prices p WHERE p.sub_id = s.id ORDER BY p.effective_from LIMIT 1),
+				s.price
+			) FROM subs s WHERE s.tenant_id = %s AND s.id = $1
+		ON CONFLICT (sub_id, effective_from) DO NOTHING`,
+		tenantID,
+	)
+
+	cleanupQuery := fmt.Sprintf(
+		`DELETE FROM sub_prices p USING subs s
+			WHERE p.tenant_id = %s AND p.sub_id = $1 AND s.id = p.sub_id AND p.effective_from < s.start_date`,
+		tenantID,
+	)
+
+	priceQuery := fmt.Sprintf(
+		`UPDATE subs s SET price = (
+			SELECT p.price FROM sub_prices p WHERE p.sub_id = s.id ORDER BY p.effective_from DESC LIMIT 1
+		) WHERE s.tenant_id = %s AND s.id = $1`,
+		tenantID,
+	)
+
+	for _, query := range []string{baseQuery, cleanupQuery, priceQuery} {
+		if _, err := tx.Exec(ctx, query, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resetPrices replaces subscription's price history with its current price.
+func resetPrices(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	deleteQuery := fmt.Sprintf("DELETE FROM sub_prices WHERE tenant_id = %s AND sub_id = $1", tenantID)
+
+	insertQuery := fmt.Sprintf(
+		`INSERT INTO sub_prices (tenant_id, sub_id, effective_from, price)
+			SELECT tenant_id, id, start_date, price FROM subs WHERE tenant_id = %s AND id = $1`,
+		tenantID,
+	)
+
+	if _, err := tx.Exec(ctx, deleteQuery, id); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, insertQuery, id)
+
+	return err
+}
+
+func upsertPrice(ctx context.Context, tx pgx.Tx, id uuid.UUID, effectiveFrom time.Time, price int64) error {
+	query := fmt.Sprintf(
+		`INSERT INTO sub_prices (tenant_id, sub_id, effective_from, price) VALUES (%s, $1, $2, $3)
+			ON CONFLICT (sub_id, effective_from) DO UPDATE SET price = EXCLUDED.price`,
+		tenantID,
+	)
+
+	_, err := tx.Exec(ctx, query, id, effectiveFrom, price)
+
+	return err
+}
+
+func checkPriceChange(sub *domain.Sub, effectiveFrom time.Time) error {
+	if effectiveFrom.Before(sub.StartDate) || (!sub.EndDate.IsZero() && !effectiveFrom.Before(sub.EndDate)) {
+		return repository.ErrInvalidPriceChange
+	}
+
+	return nil
+}
+
+func (r *SubsRepo) AddPriceChange(ctx context.Context, change *domain.PriceChange) (*domain.Sub, error) {
+	const op = "SubsRepo.AddPriceChange"
+
+	selectQuery := fmt.Sprintf(
+		"SELECT %s FROM subs WHERE tenant_id = %s AND id = $1 AND deleted_at IS NULL FOR UPDATE", subColumns, tenantID,
+	)
+
+	var after *domain.Sub
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		before, err := scanSub(tx.QueryRow(ctx, selectQuery, change.SubID))
+		if err != nil {
+			return err
+		}
+
+		if err = checkPriceChange(before, change.EffectiveFrom); err != nil {
+			return err
+		}
+
+		if err = normalizePrices(ctx, tx, change.SubID); err != nil {
+			return err
+		}
+
+		if err = upsertPrice(ctx, tx, change.SubID, change.EffectiveFrom, change.Price); err != nil {
+			return err
+		}
+
+		if err = normalizePrices(ctx, tx, change.SubID); err != nil {
+			return err
+		}
+
+		if after, err = scanSub(tx.QueryRow(ctx, selectQuery, change.SubID)); err != nil {
+			return err
+		}
+
+		return recordChange(ctx, tx, domain.AuditUpdate, before, after)
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoSubIDExists)
+		}
+
+		if errors.Is(pkgPostgres.DetectError(err), database.ErrCheckViolation) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrInvalidSubData)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return after, nil
+}
+
+func (r *SubsRepo) ListPriceChanges(ctx context.Context, subID uuid.UUID) ([]*domain.PriceChange, error) {
+	const op = "SubsRepo.ListPriceChanges"
+
+	// Subscriptions created before price history was introduced have no rows in sub_prices.
+	query := fmt.Sprintf(
+		`SELECT s.id, COALESCE(p.effective_from, s.start_date), COALESCE(p.price, s.price)
+			FROM subs s LEFT JOIN sub_prices p ON p.sub_id = s.id
+			WHERE s.tenant_id = %s AND s.id = $1 AND s.deleted_at IS NULL
+			ORDER BY p.effective_from`,
+		tenantID,
+	)
+
+	changes := []*domain.PriceChange{}
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, subID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var change domain.PriceChange
+
+			if err = rows.Scan(&change.SubID, &change.EffectiveFrom, &change.Price); err != nil {
+				return err
+			}
+
+			changes = append(changes, &change)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrNoSubIDExists)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"subs-service/internal/domain"
+	pkgPostgres "subs-service/pkg/database/postgres"
+	"time"
+)
+
+type RemindersRepo struct {
+	cluster *pkgPostgres.Cluster
+}
+
+func NewRemindersRepo(cluster *pkgPostgres.Cluster) *RemindersRepo {
+	return &RemindersRepo{
+		cluster: cluster,
+	}
+}
+
+// ListReminders treats the first day of the month as the charge date. The current month
+// is already charged, so the next charge is either at the next month or at the start date.
+// Paused months and free trial months are not charged, so they are not reminded about.
+func (r *RemindersRepo) ListReminders(ctx context.Context, before time.Time) ([]*domain.Reminder, error) {
+	const op = "RemindersRepo.ListReminders"
+
+	query := fmt.Sprintf(
+		`SELECT * FROM (
+			SELECT $2::text, s.id, s.tenant_id, s.user_id, s.service_name, m.month, %s AS price FROM subs s
+				CROSS JOIN LATERAL (
+					SELECT GREATEST(s.start_date, %s + interval '1 month')::date AS month
+				) AS m
+				WHERE s.deleted_at IS NULL AND m.month <= $1
+					AND (s.end_date IS NULL OR s.end_date > m.month) AND NOT %s
+		) AS charges WHERE charges.price > 0
+		UNION ALL
+		SELECT $3::text, id, tenant_id, user_id, service_name, end_date, 0 FROM subs
+			WHERE deleted_at IS NULL AND end_date > now() AND end_date <= $1`,
+		chargeAt("m.month", priceAtMonth), currentMonth, pausedAt("s", "m.month"),
+	)
+
+	rows, err := r.cluster.Reader(ctx).Query(ctx, query, before, domain.ReminderCharge, domain.ReminderEnd)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	reminders := []*domain.Reminder{}
+
+	for rows.Next() {
+		var reminder domain.Reminder
+		var kind string
+
+		if err = rows.Scan(
+			&kind, &reminder.SubID, &reminder.TenantID, &reminder.UserID, &reminder.ServiceName, &reminder.Date, &reminder.Amount,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		reminder.Kind = domain.ReminderKind(kind)
+		reminders = append(reminders, &reminder)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reminders, nil
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"subs-service/internal/domain"
+	pkgPostgres "subs-service/pkg/database/postgres"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	subEventsChannel = "sub_events"
+)
+
+// notifySubEvent notifies listening instances of the service, notification is sent on commit.
+func notifySubEvent(ctx context.Context, tx pgx.Tx, id int64, event domain.WebhookEvent, sub *domain.Sub) error {
+	data, err := json.Marshal(sub)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(&domain.SubEvent{
+		ID:       id,
+		Event:    event,
+		TenantID: sub.TenantID,
+		UserID:   sub.UserID,
+		SubID:    sub.ID,
+		Data:     data,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "SELECT pg_notify($1, $2)", subEventsChannel, string(payload))
+
+	return err
+}
+
+type SubEventsRepo struct {
+	cluster *pkgPostgres.Cluster
+	backoff time.Duration
+}
+
+// NewSubEventsRepo listens to the primary, as notifications are not sent to replicas.
+func NewSubEventsRepo(cluster *pkgPostgres.Cluster, backoff time.Duration) *SubEventsRepo {
+	return &SubEventsRepo{
+		cluster: cluster,
+		backoff: backoff,
+	}
+}
+
+func (r *SubEventsRepo) ListenSubEvents(ctx context.Context, onListen func(), fn func(event *domain.SubEvent)) {
+	pkgPostgres.Listen(ctx, r.cluster.Primary(), subEventsChannel, r.backoff, onListen, func(payload string) {
+		var event domain.SubEvent
+
+		if err := json.Unmarshal([]byte(payload), &event); err != nil {
+			log.Printf("[ERROR] Failed to decode subscription event: %s", err.Error())
+			return
+		}
+
+		fn(&event)
+	})
+}
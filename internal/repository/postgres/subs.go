@@ -8,33 +8,63 @@ import (
 	"subs-service/internal/repository"
 	"subs-service/pkg/database"
 	pkgPostgres "subs-service/pkg/database/postgres"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var (
+	subColumns = `id, tenant_id, user_id, service_name, COALESCE(service_id, '00000000-0000-0000-0000-000000000000'::uuid), price, start_date, COALESCE(end_date, '0001-01-01'::date),
+		trial_months, COALESCE(promo_price, 0), category, ` + subTags("subs") + `, deleted_at, ` + pausedAt("subs", currentMonth)
+)
+
+func scanSub(row pgx.Row) (*domain.Sub, error) {
+	var sub domain.Sub
+	var deletedAt *time.Time
+
+	if err := row.Scan(
+		&sub.ID, &sub.TenantID, &sub.UserID, &sub.ServiceName, &sub.ServiceID, &sub.Price, &sub.StartDate, &sub.EndDate,
+		&sub.TrialMonths, &sub.PromoPrice, &sub.Category, &sub.Tags, &deletedAt, &sub.Paused,
+	); err != nil {
+		return nil, err
+	}
+
+	if deletedAt != nil {
+		sub.DeletedAt = *deletedAt
+	}
+
+	return &sub, nil
+}
+
+// SubsRepo serves reads from replicas (if any) and writes from the primary.
+// Requests access subscriptions of their tenant only, see withTenant.
 type SubsRepo struct {
-	pool *pgxpool.Pool
+	cluster *pkgPostgres.Cluster
 }
 
-func NewSubsRepo(pool *pgxpool.Pool) *SubsRepo {
+func NewSubsRepo(cluster *pkgPostgres.Cluster) *SubsRepo {
 	return &SubsRepo{
-		pool: pool,
+		cluster: cluster,
 	}
 }
 
-func (r *SubsRepo) GetSub(ctx context.Context, id uuid.UUID) (*domain.Sub, error) {
+func (r *SubsRepo) GetSub(ctx context.Context, id uuid.UUID, opts domain.GetOpts) (*domain.Sub, error) {
 	const op = "SubsRepo.GetSub"
 
-	query :=
-		`SELECT id, user_id, service_name, price, start_date, COALESCE(end_date, '0001-01-01'::date)
-			FROM subs WHERE id = $1`
+	query := fmt.Sprintf("SELECT %s FROM subs WHERE tenant_id = %s AND id = $1", subColumns, tenantID)
+	if !opts.IncludeDeleted {
+		query += " AND deleted_at IS NULL"
+	}
 
-	var sub domain.Sub
-	err := r.pool.QueryRow(
-		ctx, query, id,
-	).Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.Price, &sub.StartDate, &sub.EndDate)
+	var sub *domain.Sub
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		var err error
+		sub, err = scanSub(tx.QueryRow(ctx, query, id))
+
+		return err
+	})
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -44,21 +74,52 @@ func (r *SubsRepo) GetSub(ctx context.Context, id uuid.UUID) (*domain.Sub, error
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &sub, nil
+	return sub, nil
 }
 
-func (r *SubsRepo) PostSub(ctx context.Context, sub *domain.Sub) (uuid.UUID, error) {
+func (r *SubsRepo) PostSub(ctx context.Context, sub *domain.Sub, opts domain.PostOpts) (uuid.UUID, error) {
 	const op = "SubsRepo.PostSub"
 
-	query := 
-		`INSERT INTO subs (user_id, service_name, price, start_date, end_date)
-			VALUES ($1, $2, $3, $4, NULLIF($5, '0001-01-01'::date)) RETURNING id`
+	query := fmt.Sprintf(
+		`INSERT INTO subs (tenant_id, user_id, service_name, service_id, price, start_date, end_date, trial_months, promo_price, category)
+			VALUES (%s, $1, $2, $3, $4, $5, NULLIF($6, '0001-01-01'::date), $7, NULLIF($8, 0), $9) RETURNING id, tenant_id`,
+		tenantID,
+	)
 
 	var subID uuid.UUID
-	err := r.pool.QueryRow(
-		ctx, query,
-		sub.UserID, sub.ServiceName, sub.Price, sub.StartDate, sub.EndDate,
-	).Scan(&subID)
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		if err := resolveService(ctx, tx, sub); err != nil {
+			return err
+		}
+
+		if opts.RejectOverlaps {
+			if err := checkOverlaps(ctx, tx, sub); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.QueryRow(
+			ctx, query,
+			sub.UserID, sub.ServiceName, nullUUID(sub.ServiceID), sub.Price, sub.StartDate, sub.EndDate,
+			sub.TrialMonths, sub.PromoPrice, sub.Category,
+		).Scan(&subID, &sub.TenantID); err != nil {
+			return err
+		}
+
+		if err := putTags(ctx, tx, subID, sub.UserID, sub.Tags); err != nil {
+			return err
+		}
+
+		created := *sub
+		created.ID = subID
+
+		if err := resetPrices(ctx, tx, subID); err != nil {
+			return err
+		}
+
+		return recordChange(ctx, tx, domain.AuditCreate, nil, &created)
+	})
 
 	if err != nil {
 		pgErr := pkgPostgres.DetectError(err)
@@ -73,19 +134,96 @@ func (r *SubsRepo) PostSub(ctx context.Context, sub *domain.Sub) (uuid.UUID, err
 	return subID, nil
 }
 
-func (r *SubsRepo) PutSub(ctx context.Context, id uuid.UUID, sub *domain.Sub) error {
+// PutSub either overwrites subscription's price history with the new price
+// or records a price change, see domain.PutOpts.
+func (r *SubsRepo) PutSub(ctx context.Context, id uuid.UUID, sub *domain.Sub, opts domain.PutOpts) error {
 	const op = "SubsRepo.PutSub"
 
-	query :=
-		`UPDATE subs SET user_id = $1, service_name = $2, price = $3, start_date = $4, end_date = $5
-			WHERE id = $6`
+	selectQuery := fmt.Sprintf(
+		"SELECT %s FROM subs WHERE tenant_id = %s AND id = $1 AND deleted_at IS NULL FOR UPDATE", subColumns, tenantID,
+	)
 
-	tag, err := r.pool.Exec(
-		ctx, query,
-		sub.UserID, sub.ServiceName, sub.Price, sub.StartDate, sub.EndDate, id,
+	updateQuery := fmt.Sprintf(
+		`UPDATE subs SET user_id = $1, service_name = $2, service_id = $3, price = $4, start_date = $5,
+			end_date = NULLIF($6, '0001-01-01'::date), trial_months = $7, promo_price = NULLIF($8, 0), category = $9
+			WHERE tenant_id = %s AND id = $10`,
+		tenantID,
 	)
 
+	memberQuery := fmt.Sprintf("DELETE FROM sub_members WHERE tenant_id = %s AND sub_id = $1 AND user_id = $2", tenantID)
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		before, err := scanSub(tx.QueryRow(ctx, selectQuery, id))
+		if err != nil {
+			return err
+		}
+
+		recordPrice := !opts.PriceEffectiveFrom.IsZero()
+
+		if recordPrice {
+			if err = checkPriceChange(sub, opts.PriceEffectiveFrom); err != nil {
+				return err
+			}
+
+			if err = normalizePrices(ctx, tx, id); err != nil {
+				return err
+			}
+		}
+
+		if err = resolveService(ctx, tx, sub); err != nil {
+			return err
+		}
+
+		if _, err = tx.Exec(
+			ctx, updateQuery,
+			sub.UserID, sub.ServiceName, nullUUID(sub.ServiceID), sub.Price, sub.StartDate, sub.EndDate,
+			sub.TrialMonths, sub.PromoPrice, sub.Category, id,
+		); err != nil {
+			return err
+		}
+
+		// New owner pays the rest, so they are no longer a member.
+		if _, err = tx.Exec(ctx, memberQuery, id, sub.UserID); err != nil {
+			return err
+		}
+
+		if err = putTags(ctx, tx, id, sub.UserID, sub.Tags); err != nil {
+			return err
+		}
+
+		if err = dropUnusedTags(ctx, tx, before.UserID, sub.UserID); err != nil {
+			return err
+		}
+
+		if recordPrice {
+			if err = upsertPrice(ctx, tx, id, opts.PriceEffectiveFrom, sub.Price); err == nil {
+				err = normalizePrices(ctx, tx, id)
+			}
+		} else {
+			err = resetPrices(ctx, tx, id)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		after, err := scanSub(tx.QueryRow(ctx, selectQuery, id))
+		if err != nil {
+			return err
+		}
+
+		// Latest price may differ from the recorded one, if it is not the latest change.
+		sub.Price = after.Price
+		sub.TenantID = after.TenantID
+
+		return recordChange(ctx, tx, domain.AuditUpdate, before, after)
+	})
+
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, repository.ErrNoSubIDExists)
+		}
+
 		pgErr := pkgPostgres.DetectError(err)
 
 		if errors.Is(pgErr, database.ErrCheckViolation) {
@@ -95,39 +233,102 @@ func (r *SubsRepo) PutSub(ctx context.Context, id uuid.UUID, sub *domain.Sub) er
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if tag.RowsAffected() != 1 {
-		return fmt.Errorf("%s: %w", op, repository.ErrNoSubIDExists)
-	}
-
 	return nil
 }
 
+// DeleteSub only marks subscription as deleted, so that it can be restored
+// until it is purged by PurgeDeletedSubs.
 func (r *SubsRepo) DeleteSub(ctx context.Context, id uuid.UUID) error {
 	const op = "SubsRepo.DeleteSub"
 
-	query := "DELETE FROM subs WHERE id = $1"
+	query := fmt.Sprintf(
+		"UPDATE subs SET deleted_at = now() WHERE tenant_id = %s AND id = $1 AND deleted_at IS NULL RETURNING %s",
+		tenantID, subColumns,
+	)
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		before, err := scanSub(tx.QueryRow(ctx, query, id))
+		if err != nil {
+			return err
+		}
+
+		// Deletion mark is not a part of subscription's data.
+		before.DeletedAt = time.Time{}
+
+		return recordChange(ctx, tx, domain.AuditDelete, before, nil)
+	})
 
-	tag, err := r.pool.Exec(ctx, query, id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, repository.ErrNoSubIDExists)
+		}
+
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if tag.RowsAffected() != 1 {
-		return fmt.Errorf("%s: %w", op, repository.ErrNoSubIDExists)
+	return nil
+}
+
+func (r *SubsRepo) RestoreSub(ctx context.Context, id uuid.UUID) (*domain.Sub, error) {
+	const op = "SubsRepo.RestoreSub"
+
+	query := fmt.Sprintf(
+		"UPDATE subs SET deleted_at = NULL WHERE tenant_id = %s AND id = $1 AND deleted_at IS NOT NULL RETURNING %s",
+		tenantID, subColumns,
+	)
+
+	var sub *domain.Sub
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		var err error
+		if sub, err = scanSub(tx.QueryRow(ctx, query, id)); err != nil {
+			return err
+		}
+
+		return recordChange(ctx, tx, domain.AuditRestore, nil, sub)
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoDeletedSubIDExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return sub, nil
+}
+
+// PurgeDeletedSubs permanently deletes subscriptions of all tenants, which were marked as deleted before the given time.
+func (r *SubsRepo) PurgeDeletedSubs(ctx context.Context, before time.Time) (int64, error) {
+	const op = "SubsRepo.PurgeDeletedSubs"
+
+	query :=
+		`WITH purged AS (
+			DELETE FROM subs WHERE deleted_at < $1 RETURNING id, tenant_id, user_id
+		)
+		INSERT INTO subs_audit (tenant_id, sub_id, user_id, actor, operation)
+			SELECT tenant_id, id, user_id, $2, $3 FROM purged`
+
+	tag, err := r.cluster.Primary().Exec(ctx, query, before, domain.SystemActor, domain.AuditPurge)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag.RowsAffected(), nil
 }
 
 func (r *SubsRepo) ListSubs(ctx context.Context, opts domain.FilterOpts) ([]*domain.Sub, error) {
 	const op = "SubRepo.ListSubs"
 
-	query :=
-		`SELECT id, user_id, service_name, price, start_date, COALESCE(end_date, '0001-01-01'::date)
-			FROM subs WHERE user_id = $1`
+	query := fmt.Sprintf("SELECT %s FROM subs WHERE tenant_id = %s AND user_id = $1", subColumns, tenantID)
 	args := []any{opts.UserID}
 	i := 2
 
+	if !opts.IncludeDeleted {
+		query += " AND deleted_at IS NULL"
+	}
+
 	if opts.PageToken != uuid.Nil {
 		query = fmt.Sprintf("%s AND id > $%d", query, i)
 		args = append(args, opts.PageToken)
@@ -135,50 +336,152 @@ func (r *SubsRepo) ListSubs(ctx context.Context, opts domain.FilterOpts) ([]*dom
 	}
 
 	if len(opts.ServiceName) != 0 {
-		query = fmt.Sprintf("%s AND service_name = $%d", query, i)
+		query = fmt.Sprintf("%s AND %s", query, serviceMatch("subs", fmt.Sprintf("$%d", i)))
 		args = append(args, opts.ServiceName)
 		i++
 	}
 
-	query = fmt.Sprintf("%s ORDER BY id LIMIT $%d", query, i)
-	args = append(args, opts.PageSize)
+	if opts.TrialEndsWithin > 0 {
+		query = fmt.Sprintf(
+			"%s AND trial_months > 0 AND %s BETWEEN current_date AND current_date + $%d * interval '1 day'",
+			query, trialEnd("subs"), i,
+		)
+		args = append(args, opts.TrialEndsWithin)
+		i++
+	}
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	if len(opts.Category) != 0 {
+		query = fmt.Sprintf("%s AND category = $%d", query, i)
+		args = append(args, opts.Category)
+		i++
+	}
 
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+	if len(opts.Tags) != 0 {
+		query = fmt.Sprintf("%s AND %s", query, tagsMatch("subs", fmt.Sprintf("$%d::text[]", i), opts.TagsMatch))
+		args = append(args, opts.Tags)
+		i++
 	}
 
-	subs := []*domain.Sub{}
+	query = fmt.Sprintf("%s ORDER BY id LIMIT $%d", query, i)
+	args = append(args, opts.PageSize)
 
-	for rows.Next() {
-		var sub domain.Sub
+	var subs []*domain.Sub
 
-		if err = rows.Scan(
-			&sub.ID, &sub.UserID, &sub.ServiceName, &sub.Price, &sub.StartDate, &sub.EndDate,
-		); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
-		}
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		var err error
+		subs, err = querySubs(ctx, tx, query, args...)
+
+		return err
+	})
 
-		subs = append(subs, &sub)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return subs, nil
 }
 
+// summaryGroupKey selects the key of summary groups along with the joins it requires.
+func summaryGroupKey(groupBy domain.GroupBy) (string, string) {
+	switch groupBy {
+	case domain.GroupByCategory:
+		return "s.category", ""
+	case domain.GroupByTag:
+		return "COALESCE(gt.name, '')", " LEFT JOIN sub_tags gst ON gst.sub_id = s.id LEFT JOIN tags gt ON gt.id = gst.tag_id"
+	default:
+		return "s.service_name", ""
+	}
+}
+
+// GetSummary sums current prices of subscriptions or, if the period is set,
+// charges for each month of the period by the price in effect at that month.
+// Paused months are not charged, trial months are charged by the promotional price.
+// Groups are summed the same way, if the dimension to group by is set.
+// Subscriptions shared by or with the user are charged by the user's share.
 func (r *SubsRepo) GetSummary(ctx context.Context, opts domain.FilterOpts) (*domain.Summary, error) {
 	const op = "SubRepo.GetSummary"
 
-	query := "SELECT COALESCE(SUM(price), 0) FROM subs WHERE user_id = $1"
+	from := "subs s"
+	charge := chargeAt(currentMonth, "s.price")
+	paused := pausedAt("s", currentMonth)
 	args := []any{opts.UserID}
 
+	if !opts.From.IsZero() {
+		// End date is exclusive, start date is inclusive as well as period bounds.
+		from =
+			`subs s CROSS JOIN LATERAL generate_series(
+				GREATEST(s.start_date, $2::date)::timestamp,
+				LEAST(COALESCE(s.end_date - interval '1 month', $3::date), $3::date)::timestamp,
+				interval '1 month'
+			) AS m(month)`
+		charge = chargeAt("m.month", priceAtMonth)
+		paused = pausedAt("s", "m.month::date")
+		args = append(args, opts.From, opts.To)
+	}
+
+	// Shared subscriptions are charged by the user's share.
+	from += splitJoin(charge)
+	charge = shareOf("$1")
+	where := fmt.Sprintf("s.tenant_id = %s AND %s AND NOT %s", tenantID, sharedWith("$1"), paused)
+
+	if !opts.IncludeDeleted {
+		where += " AND s.deleted_at IS NULL"
+	}
+
 	if len(opts.ServiceName) != 0 {
-		query = fmt.Sprintf("%s AND service_name = $2", query)
 		args = append(args, opts.ServiceName)
+		where = fmt.Sprintf("%s AND %s", where, serviceMatch("s", fmt.Sprintf("$%d", len(args))))
+	}
+
+	if len(opts.Category) != 0 {
+		args = append(args, opts.Category)
+		where = fmt.Sprintf("%s AND s.category = $%d", where, len(args))
+	}
+
+	if len(opts.Tags) != 0 {
+		args = append(args, opts.Tags)
+		where = fmt.Sprintf("%s AND %s", where, tagsMatch("s", fmt.Sprintf("$%d::text[]", len(args)), opts.TagsMatch))
 	}
 
+	query := fmt.Sprintf("SELECT COALESCE(SUM(%s), 0) FROM %s WHERE %s", charge, from, where)
+
+	key, join := summaryGroupKey(opts.GroupBy)
+	groupsQuery := fmt.Sprintf(
+		"SELECT %s AS key, SUM(%s) FROM %s%s WHERE %s GROUP BY key ORDER BY key",
+		key, charge, from, join, where,
+	)
+
 	var sum domain.Summary
-	err := r.pool.QueryRow(ctx, query, args...).Scan(&sum.TotalPrice)
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, query, args...).Scan(&sum.TotalPrice); err != nil {
+			return err
+		}
+
+		if len(opts.GroupBy) == 0 {
+			return nil
+		}
+
+		rows, err := tx.Query(ctx, groupsQuery, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		sum.Groups = []*domain.SummaryGroup{}
+
+		for rows.Next() {
+			var group domain.SummaryGroup
+
+			if err = rows.Scan(&group.Key, &group.TotalPrice); err != nil {
+				return err
+			}
+
+			sum.Groups = append(sum.Groups, &group)
+		}
+
+		return rows.Err()
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -186,3 +489,54 @@ func (r *SubsRepo) GetSummary(ctx context.Context, opts domain.FilterOpts) (*dom
 
 	return &sum, nil
 }
+
+// GetForecast projects charges of user's subscriptions for the given number of months starting
+// from the given one the same way GetSummary sums them for a period: by the price in effect
+// at the month, by the promotional price during the trial, skipping paused months,
+// by the user's share of subscriptions shared by or with the user.
+func (r *SubsRepo) GetForecast(ctx context.Context, userID uuid.UUID, from time.Time, months int) ([]*domain.ServiceSpend, error) {
+	const op = "SubRepo.GetForecast"
+
+	// End date is exclusive, open pauses are expected to last through the whole forecast.
+	query := fmt.Sprintf(
+		`SELECT m.month, s.service_name, SUM(%s) FROM (
+				SELECT generate_series(
+					$2::timestamp, $2::date + ($3 - 1) * interval '1 month', interval '1 month'
+				)::date AS month
+			) AS m
+			JOIN subs s ON s.tenant_id = %s AND %s AND s.deleted_at IS NULL
+				AND s.start_date <= m.month AND (s.end_date IS NULL OR s.end_date > m.month)%s
+			WHERE NOT %s
+			GROUP BY m.month, s.service_name
+			ORDER BY m.month, s.service_name`,
+		shareOf("$1"), tenantID, sharedWith("$1"), splitJoin(chargeAt("m.month", priceAtMonth)), pausedAt("s", "m.month"),
+	)
+
+	spends := []*domain.ServiceSpend{}
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, userID, from, months)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var spend domain.ServiceSpend
+
+			if err = rows.Scan(&spend.Month, &spend.ServiceName, &spend.Amount); err != nil {
+				return err
+			}
+
+			spends = append(spends, &spend)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return spends, nil
+}
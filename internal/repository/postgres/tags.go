@@ -0,0 +1,303 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	"subs-service/pkg/database"
+	pkgPostgres "subs-service/pkg/database/postgres"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// subTags selects names of tags of subscription sub sorted bytewise, the same way as the API sorts them.
+func subTags(sub string) string {
+	return fmt.Sprintf(`ARRAY(
+		SELECT t.name FROM sub_tags st JOIN tags t ON t.id = st.tag_id WHERE st.sub_id = %s.id ORDER BY t.name COLLATE "C"
+	)`, sub)
+}
+
+// tagsMatch checks whether subscription sub is labeled with any or all of the tags,
+// which are given by SQL expression of distinct names.
+func tagsMatch(sub, tags string, match domain.TagsMatch) string {
+	if match == domain.TagsMatchAll {
+		return fmt.Sprintf(`(
+			SELECT count(*) FROM sub_tags st JOIN tags t ON t.id = st.tag_id
+				WHERE st.sub_id = %[1]s.id AND t.name = ANY(%[2]s)
+		) = cardinality(%[2]s)`, sub, tags)
+	}
+
+	return fmt.Sprintf(`EXISTS (
+		SELECT 1 FROM sub_tags st JOIN tags t ON t.id = st.tag_id WHERE st.sub_id = %[1]s.id AND t.name = ANY(%[2]s)
+	)`, sub, tags)
+}
+
+// putTags replaces tags of the subscription, tags are created for the user on demand.
+// Upsert locks existing tags, so that they are not dropped as unused concurrently.
+func putTags(ctx context.Context, tx pgx.Tx, subID, userID uuid.UUID, tags []string) error {
+	deleteQuery := fmt.Sprintf("DELETE FROM sub_tags WHERE tenant_id = %s AND sub_id = $1", tenantID)
+
+	if _, err := tx.Exec(ctx, deleteQuery, subID); err != nil {
+		return err
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(
+		`WITH t AS (
+			INSERT INTO tags (tenant_id, user_id, name) SELECT %[1]s, $2::uuid, unnest($3::text[])
+				ON CONFLICT (tenant_id, user_id, name) DO UPDATE SET name = EXCLUDED.name RETURNING id
+		)
+		INSERT INTO sub_tags (tenant_id, sub_id, tag_id) SELECT %[1]s, $1::uuid, id FROM t`,
+		tenantID,
+	)
+
+	_, err := tx.Exec(ctx, query, subID, userID, tags)
+
+	return err
+}
+
+// dropUnusedTags deletes tags of the users, which no subscription is labeled with.
+func dropUnusedTags(ctx context.Context, tx pgx.Tx, userIDs ...uuid.UUID) error {
+	query := fmt.Sprintf(
+		`DELETE FROM tags t WHERE t.tenant_id = %s AND t.user_id = ANY($1)
+			AND NOT EXISTS (SELECT 1 FROM sub_tags st WHERE st.tag_id = t.id)`,
+		tenantID,
+	)
+
+	_, err := tx.Exec(ctx, query, userIDs)
+
+	return err
+}
+
+// querier is implemented by both pools and transactions.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func querySubs(ctx context.Context, conn querier, query string, args ...any) ([]*domain.Sub, error) {
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subs := []*domain.Sub{}
+
+	for rows.Next() {
+		sub, scanErr := scanSub(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
+
+// changeTags applies the change to tags of the user and records changes of subscriptions labeled
+// with the tags before the change. Deleted subscriptions are changed without recording.
+func changeTags(ctx context.Context, tx pgx.Tx, userID uuid.UUID, tags []string, change func() error) error {
+	lockQuery := fmt.Sprintf(
+		`SELECT %[1]s FROM subs WHERE tenant_id = %[2]s AND deleted_at IS NULL AND id IN (
+			SELECT st.sub_id FROM sub_tags st JOIN tags t ON t.id = st.tag_id
+				WHERE t.tenant_id = %[2]s AND t.user_id = $1 AND t.name = ANY($2)
+		) ORDER BY id FOR UPDATE`,
+		subColumns, tenantID,
+	)
+
+	selectQuery := fmt.Sprintf("SELECT %s FROM subs WHERE tenant_id = %s AND id = ANY($1) ORDER BY id", subColumns, tenantID)
+
+	before, err := querySubs(ctx, tx, lockQuery, userID, tags)
+	if err != nil {
+		return err
+	}
+
+	if err = change(); err != nil {
+		return err
+	}
+
+	ids := make([]uuid.UUID, 0, len(before))
+	for _, sub := range before {
+		ids = append(ids, sub.ID)
+	}
+
+	after, err := querySubs(ctx, tx, selectQuery, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range before {
+		if err = recordChange(ctx, tx, domain.AuditUpdate, before[i], after[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func countTagged(ctx context.Context, tx pgx.Tx, userID uuid.UUID, name string) (*domain.Tag, error) {
+	query := fmt.Sprintf(
+		`SELECT count(s.id) FROM tags t
+			JOIN sub_tags st ON st.tag_id = t.id JOIN subs s ON s.id = st.sub_id AND s.deleted_at IS NULL
+			WHERE t.tenant_id = %s AND t.user_id = $1 AND t.name = $2`,
+		tenantID,
+	)
+
+	tag := domain.Tag{Name: name}
+
+	if err := tx.QueryRow(ctx, query, userID, name).Scan(&tag.Subs); err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// ListTags lists tags of the user's subscriptions, deleted subscriptions are not counted.
+func (r *SubsRepo) ListTags(ctx context.Context, userID uuid.UUID) ([]*domain.Tag, error) {
+	const op = "SubsRepo.ListTags"
+
+	query := fmt.Sprintf(
+		`SELECT t.name, count(s.id) FROM tags t
+			JOIN sub_tags st ON st.tag_id = t.id JOIN subs s ON s.id = st.sub_id AND s.deleted_at IS NULL
+			WHERE t.tenant_id = %s AND t.user_id = $1
+			GROUP BY t.name ORDER BY t.name COLLATE "C"`,
+		tenantID,
+	)
+
+	tags := []*domain.Tag{}
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, userID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var tag domain.Tag
+
+			if err = rows.Scan(&tag.Name, &tag.Subs); err != nil {
+				return err
+			}
+
+			tags = append(tags, &tag)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tags, nil
+}
+
+// RenameTag renames the tag across all subscriptions of the user, existing tag can't be a new name.
+func (r *SubsRepo) RenameTag(ctx context.Context, userID uuid.UUID, from, to string) (*domain.Tag, error) {
+	const op = "SubsRepo.RenameTag"
+
+	query := fmt.Sprintf("UPDATE tags SET name = $3 WHERE tenant_id = %s AND user_id = $1 AND name = $2", tenantID)
+
+	var tag *domain.Tag
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		err := changeTags(ctx, tx, userID, []string{from}, func() error {
+			renamed, execErr := tx.Exec(ctx, query, userID, from, to)
+			if execErr != nil {
+				return execErr
+			}
+
+			if renamed.RowsAffected() == 0 {
+				return repository.ErrNoTagExists
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		tag, err = countTagged(ctx, tx, userID, to)
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(pkgPostgres.DetectError(err), database.ErrUniqueViolation) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrTagExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag, nil
+}
+
+// MergeTags relabels subscriptions of the user from the source tags to the target one
+// and deletes the sources, the target is created if it doesn't exist.
+func (r *SubsRepo) MergeTags(ctx context.Context, userID uuid.UUID, sources []string, target string) (*domain.Tag, error) {
+	const op = "SubsRepo.MergeTags"
+
+	targetQuery := fmt.Sprintf(
+		`INSERT INTO tags (tenant_id, user_id, name) VALUES (%s, $1, $2)
+			ON CONFLICT (tenant_id, user_id, name) DO UPDATE SET name = EXCLUDED.name RETURNING id`,
+		tenantID,
+	)
+
+	relabelQuery := fmt.Sprintf(
+		`INSERT INTO sub_tags (tenant_id, sub_id, tag_id)
+			SELECT st.tenant_id, st.sub_id, $3::bigint FROM sub_tags st JOIN tags t ON t.id = st.tag_id
+				WHERE t.tenant_id = %s AND t.user_id = $1 AND t.name = ANY($2)
+		ON CONFLICT (sub_id, tag_id) DO NOTHING`,
+		tenantID,
+	)
+
+	deleteQuery := fmt.Sprintf("DELETE FROM tags WHERE tenant_id = %s AND user_id = $1 AND name = ANY($2)", tenantID)
+
+	var tag *domain.Tag
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		err := changeTags(ctx, tx, userID, sources, func() error {
+			var targetID int64
+			if scanErr := tx.QueryRow(ctx, targetQuery, userID, target).Scan(&targetID); scanErr != nil {
+				return scanErr
+			}
+
+			if _, execErr := tx.Exec(ctx, relabelQuery, userID, sources, targetID); execErr != nil {
+				return execErr
+			}
+
+			deleted, execErr := tx.Exec(ctx, deleteQuery, userID, sources)
+			if execErr != nil {
+				return execErr
+			}
+
+			if deleted.RowsAffected() == 0 {
+				return repository.ErrNoTagExists
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		tag, err = countTagged(ctx, tx, userID, target)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag, nil
+}
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	pkgPostgres "subs-service/pkg/database/postgres"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// tenantID selects the tenant set by withTenant, queries filter by it explicitly
+// to make use of indexes, which lead with tenant_id.
+const tenantID = "current_setting('app.tenant_id')"
+
+// withTenant runs fn in a transaction, which is restricted to rows of the request's tenant
+// by row-level security policies. SET LOCAL doesn't accept parameters, so set_config is used instead.
+func withTenant(ctx context.Context, pool *pgxpool.Pool, fn func(tx pgx.Tx) error) error {
+	tenant := domain.TenantFromContext(ctx)
+	if len(tenant) == 0 {
+		return repository.ErrNoTenant
+	}
+
+	return pkgPostgres.WithTx(ctx, pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(
+			ctx, "SELECT set_config('app.tenant_id', $1, true), set_config('role', 'subs_tenant', true)", tenant,
+		); err != nil {
+			return err
+		}
+
+		return fn(tx)
+	})
+}
@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	pkgPostgres "subs-service/pkg/database/postgres"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	exportColumns = "e.id, e.user_id, e.job_id, j.status, j.error, e.created_at, j.completed_at"
+	exportTables  = "data_exports e JOIN jobs j ON j.id = e.job_id"
+)
+
+func scanExport(row pgx.Row, dest ...any) (*domain.DataExport, error) {
+	var export domain.DataExport
+
+	dest = append([]any{
+		&export.ID, &export.UserID, &export.JobID, &export.Status, &export.Error, &export.CreatedAt, &export.CompletedAt,
+	}, dest...)
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+type UserDataRepo struct {
+	cluster *pkgPostgres.Cluster
+}
+
+func NewUserDataRepo(cluster *pkgPostgres.Cluster) *UserDataRepo {
+	return &UserDataRepo{
+		cluster: cluster,
+	}
+}
+
+// PostExport returns the unfinished export of the user, if any, instead of requesting another one.
+func (r *UserDataRepo) PostExport(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error) {
+	const op = "UserDataRepo.PostExport"
+
+	pendingQuery := fmt.Sprintf(
+		`SELECT %s FROM %s
+			WHERE e.tenant_id = %s AND e.user_id = $1 AND j.status IN ('pending', 'running')
+			ORDER BY e.created_at LIMIT 1`,
+		exportColumns, exportTables, tenantID,
+	)
+
+	insertQuery := fmt.Sprintf(
+		"INSERT INTO data_exports (id, tenant_id, user_id, job_id) VALUES ($1, %s, $2, $3)",
+		tenantID,
+	)
+
+	selectQuery := fmt.Sprintf("SELECT %s FROM %s WHERE e.id = $1", exportColumns, exportTables)
+
+	var export *domain.DataExport
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		var err error
+
+		export, err = scanExport(tx.QueryRow(ctx, pendingQuery, userID))
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		id := uuid.New()
+
+		jobID, err := insertJob(ctx, tx, domain.JobDataExport, domain.DataExportPayload{ExportID: id, UserID: userID})
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.Exec(ctx, insertQuery, id, userID, jobID); err != nil {
+			return err
+		}
+
+		export, err = scanExport(tx.QueryRow(ctx, selectQuery, id))
+
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return export, nil
+}
+
+func (r *UserDataRepo) GetExport(ctx context.Context, userID, id uuid.UUID) (*domain.DataExport, error) {
+	const op = "UserDataRepo.GetExport"
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE e.tenant_id = %s AND e.user_id = $1 AND e.id = $2",
+		exportColumns, exportTables, tenantID,
+	)
+
+	var export *domain.DataExport
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		var err error
+		export, err = scanExport(tx.QueryRow(ctx, query, userID, id))
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoExportIDExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return export, nil
+}
+
+func (r *UserDataRepo) GetExportArchive(ctx context.Context, userID uuid.UUID) (*domain.DataExport, []byte, error) {
+	const op = "UserDataRepo.GetExportArchive"
+
+	query := fmt.Sprintf(
+		`SELECT %s, e.archive FROM %s
+			WHERE e.tenant_id = %s AND e.user_id = $1 AND j.status = 'completed' AND e.archive IS NOT NULL
+			ORDER BY j.completed_at DESC LIMIT 1`,
+		exportColumns, exportTables, tenantID,
+	)
+
+	var export *domain.DataExport
+	var archive []byte
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		var err error
+		export, err = scanExport(tx.QueryRow(ctx, query, userID), &archive)
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, fmt.Errorf("%s: %w", op, repository.ErrNoExportCompleted)
+		}
+
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return export, archive, nil
+}
+
+// EraseUserData deletes user's subscriptions (along with their tags, prices, pauses and members), memberships
+// in subscriptions of other users, tags, budgets with their alerts, calendar token, exports with their jobs, sent reminders
+// and webhook events. Audit entries of the user are kept without snapshots of subscriptions,
+// and the erasure is recorded by the tombstone entry. Changes are not published to webhooks and the stream.
+func (r *UserDataRepo) EraseUserData(ctx context.Context, userID uuid.UUID) error {
+	const op = "UserDataRepo.EraseUserData"
+
+	queries := []string{
+		fmt.Sprintf("DELETE FROM sent_notifications WHERE tenant_id = %s AND user_id = $1", tenantID),
+		fmt.Sprintf("DELETE FROM webhook_events WHERE tenant_id = %s AND user_id = $1", tenantID),
+		fmt.Sprintf("DELETE FROM subs WHERE tenant_id = %s AND user_id = $1", tenantID),
+		fmt.Sprintf("DELETE FROM sub_members WHERE tenant_id = %s AND user_id = $1", tenantID),
+		fmt.Sprintf("DELETE FROM tags WHERE tenant_id = %s AND user_id = $1", tenantID),
+		fmt.Sprintf("DELETE FROM budgets WHERE tenant_id = %s AND user_id = $1", tenantID),
+		fmt.Sprintf("DELETE FROM calendar_tokens WHERE tenant_id = %s AND user_id = $1", tenantID),
+		fmt.Sprintf(
+			"DELETE FROM jobs j USING data_exports e WHERE j.id = e.job_id AND e.tenant_id = %s AND e.user_id = $1",
+			tenantID,
+		),
+		fmt.Sprintf(
+			`UPDATE subs_audit SET before = NULL, after = NULL
+				WHERE tenant_id = %s AND user_id = $1 AND (before IS NOT NULL OR after IS NOT NULL)`,
+			tenantID,
+		),
+	}
+
+	tombstoneQuery := fmt.Sprintf(
+		`INSERT INTO subs_audit (tenant_id, user_id, actor, request_id, operation)
+			VALUES (%s, $1, $2, $3, $4)`,
+		tenantID,
+	)
+
+	meta := domain.AuditMetaFromContext(ctx)
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		for _, query := range queries {
+			if _, err := tx.Exec(ctx, query, userID); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.Exec(ctx, tombstoneQuery, userID, meta.Actor, meta.RequestID, domain.AuditErase)
+
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
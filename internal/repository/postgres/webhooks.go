@@ -0,0 +1,306 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	pkgPostgres "subs-service/pkg/database/postgres"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// Secret is selected only for delivery.
+	webhookColumns = "id, url, events, created_at"
+
+	deliveryColumns = `d.id, d.webhook_id, d.event_id, e.event, d.status, d.attempts,
+		d.next_attempt_at, d.last_error, d.delivered_at`
+)
+
+func scanWebhook(row pgx.Row) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	var events []string
+
+	if err := row.Scan(&webhook.ID, &webhook.URL, &events, &webhook.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	webhook.Events = make([]domain.WebhookEvent, 0, len(events))
+	for _, event := range events {
+		webhook.Events = append(webhook.Events, domain.WebhookEvent(event))
+	}
+
+	return &webhook, nil
+}
+
+func scanDelivery(row pgx.Row) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	var event, status string
+
+	if err := row.Scan(
+		&delivery.ID, &delivery.WebhookID, &delivery.EventID, &event, &status, &delivery.Attempts,
+		&delivery.NextAttemptAt, &delivery.LastError, &delivery.DeliveredAt,
+	); err != nil {
+		return nil, err
+	}
+
+	delivery.Event = domain.WebhookEvent(event)
+	delivery.Status = domain.DeliveryStatus(status)
+
+	return &delivery, nil
+}
+
+func webhookEvents(webhook *domain.Webhook) []string {
+	events := make([]string, 0, len(webhook.Events))
+	for _, event := range webhook.Events {
+		events = append(events, string(event))
+	}
+
+	return events
+}
+
+// WebhooksRepo gives access to webhooks of the request's tenant only.
+type WebhooksRepo struct {
+	cluster *pkgPostgres.Cluster
+}
+
+func NewWebhooksRepo(cluster *pkgPostgres.Cluster) *WebhooksRepo {
+	return &WebhooksRepo{
+		cluster: cluster,
+	}
+}
+
+func (r *WebhooksRepo) PostWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	const op = "WebhooksRepo.PostWebhook"
+
+	query := fmt.Sprintf(
+		"INSERT INTO webhooks (tenant_id, url, secret, events) VALUES (%s, $1, $2, $3) RETURNING %s", tenantID, webhookColumns,
+	)
+
+	var created *domain.Webhook
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		var err error
+		created, err = scanWebhook(tx.QueryRow(ctx, query, webhook.URL, webhook.Secret, webhookEvents(webhook)))
+
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+func (r *WebhooksRepo) GetWebhook(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	const op = "WebhooksRepo.GetWebhook"
+
+	query := fmt.Sprintf("SELECT %s FROM webhooks WHERE tenant_id = %s AND id = $1", webhookColumns, tenantID)
+
+	var webhook *domain.Webhook
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		var err error
+		webhook, err = scanWebhook(tx.QueryRow(ctx, query, id))
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoWebhookIDExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhook, nil
+}
+
+func (r *WebhooksRepo) ListWebhooks(ctx context.Context) ([]*domain.Webhook, error) {
+	const op = "WebhooksRepo.ListWebhooks"
+
+	query := fmt.Sprintf("SELECT %s FROM webhooks WHERE tenant_id = %s ORDER BY created_at, id", webhookColumns, tenantID)
+
+	webhooks := []*domain.Webhook{}
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var webhook *domain.Webhook
+
+			if webhook, err = scanWebhook(rows); err != nil {
+				return err
+			}
+
+			webhooks = append(webhooks, webhook)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhooks, nil
+}
+
+// PutWebhook keeps the previous secret, if the new one is empty.
+func (r *WebhooksRepo) PutWebhook(ctx context.Context, id uuid.UUID, webhook *domain.Webhook) (*domain.Webhook, error) {
+	const op = "WebhooksRepo.PutWebhook"
+
+	query := fmt.Sprintf(
+		`UPDATE webhooks SET url = $1, secret = COALESCE(NULLIF($2, ''), secret), events = $3
+			WHERE tenant_id = %s AND id = $4 RETURNING %s`,
+		tenantID, webhookColumns,
+	)
+
+	var updated *domain.Webhook
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		var err error
+		updated, err = scanWebhook(tx.QueryRow(ctx, query, webhook.URL, webhook.Secret, webhookEvents(webhook), id))
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoWebhookIDExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+func (r *WebhooksRepo) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	const op = "WebhooksRepo.DeleteWebhook"
+
+	query := fmt.Sprintf("DELETE FROM webhooks WHERE tenant_id = %s AND id = $1", tenantID)
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, id)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return repository.ErrNoWebhookIDExists
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *WebhooksRepo) ListDeliveries(ctx context.Context, opts domain.DeliveryFilterOpts) ([]*domain.WebhookDelivery, error) {
+	const op = "WebhooksRepo.ListDeliveries"
+
+	existsQuery := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM webhooks WHERE tenant_id = %s AND id = $1)", tenantID)
+
+	query := fmt.Sprintf(
+		`SELECT %s FROM webhook_deliveries d JOIN webhook_events e ON e.id = d.event_id
+			WHERE d.tenant_id = %s AND d.webhook_id = $1`,
+		deliveryColumns, tenantID,
+	)
+	args := []any{opts.WebhookID}
+
+	if len(opts.Status) != 0 {
+		args = append(args, string(opts.Status))
+		query = fmt.Sprintf("%s AND d.status = $%d", query, len(args))
+	}
+
+	if opts.PageToken != 0 {
+		args = append(args, opts.PageToken)
+		query = fmt.Sprintf("%s AND d.id > $%d", query, len(args))
+	}
+
+	args = append(args, opts.PageSize)
+	query = fmt.Sprintf("%s ORDER BY d.id LIMIT $%d", query, len(args))
+
+	deliveries := []*domain.WebhookDelivery{}
+
+	err := withTenant(ctx, r.cluster.Reader(ctx), func(tx pgx.Tx) error {
+		var exists bool
+		if err := tx.QueryRow(ctx, existsQuery, opts.WebhookID).Scan(&exists); err != nil {
+			return err
+		}
+
+		if !exists {
+			return repository.ErrNoWebhookIDExists
+		}
+
+		rows, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var delivery *domain.WebhookDelivery
+
+			if delivery, err = scanDelivery(rows); err != nil {
+				return err
+			}
+
+			deliveries = append(deliveries, delivery)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// RedeliverDelivery schedules delivery to be sent again right away with a fresh attempts budget.
+func (r *WebhooksRepo) RedeliverDelivery(ctx context.Context, webhookID uuid.UUID, id int64) (*domain.WebhookDelivery, error) {
+	const op = "WebhooksRepo.RedeliverDelivery"
+
+	query := fmt.Sprintf(
+		`UPDATE webhook_deliveries d SET status = 'pending', attempts = 0, next_attempt_at = now(),
+			last_error = '', delivered_at = NULL
+			FROM webhook_events e WHERE d.tenant_id = %s AND d.id = $1 AND d.webhook_id = $2 AND e.id = d.event_id
+			RETURNING %s`,
+		tenantID, deliveryColumns,
+	)
+
+	var delivery *domain.WebhookDelivery
+
+	err := withTenant(ctx, r.cluster.Primary(), func(tx pgx.Tx) error {
+		var err error
+		delivery, err = scanDelivery(tx.QueryRow(ctx, query, id, webhookID))
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrNoDeliveryIDExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return delivery, nil
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"subs-service/internal/domain"
+	"time"
+)
+
+type RemindersRepo interface {
+	// ListReminders finds upcoming charges and ends of subscriptions, which happen before the given time.
+	ListReminders(ctx context.Context, before time.Time) ([]*domain.Reminder, error)
+}
+
+// NotificationsRepo keeps keys of sent notifications, so that they are not sent twice.
+type NotificationsRepo interface {
+	// ClaimNotification marks reminder as sent, false is returned if it already was.
+	ClaimNotification(ctx context.Context, reminder *domain.Reminder) (bool, error)
+	// ReleaseNotification unmarks notification, which failed to be sent.
+	ReleaseNotification(ctx context.Context, key string) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"subs-service/internal/domain"
+)
+
+type SubEventsRepo interface {
+	// ListenSubEvents calls fn for changes of subscriptions made by all instances of the service
+	// until ctx is done. Changes made while listening is interrupted are lost, onListen is called
+	// each time listening is (re)started.
+	ListenSubEvents(ctx context.Context, onListen func(), fn func(event *domain.SubEvent))
+}
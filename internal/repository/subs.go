@@ -3,15 +3,32 @@ package repository
 import (
 	"context"
 	"subs-service/internal/domain"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type SubsRepo interface {
-	GetSub(ctx context.Context, id uuid.UUID) (*domain.Sub, error)
-	PostSub(ctx context.Context, sub *domain.Sub) (uuid.UUID, error)
-	PutSub(ctx context.Context, id uuid.UUID, sub *domain.Sub) error
+	GetSub(ctx context.Context, id uuid.UUID, opts domain.GetOpts) (*domain.Sub, error)
+	PostSub(ctx context.Context, sub *domain.Sub, opts domain.PostOpts) (uuid.UUID, error)
+	PutSub(ctx context.Context, id uuid.UUID, sub *domain.Sub, opts domain.PutOpts) error
 	DeleteSub(ctx context.Context, id uuid.UUID) error
+	RestoreSub(ctx context.Context, id uuid.UUID) (*domain.Sub, error)
+	PurgeDeletedSubs(ctx context.Context, before time.Time) (int64, error)
+	AddPriceChange(ctx context.Context, change *domain.PriceChange) (*domain.Sub, error)
+	ListPriceChanges(ctx context.Context, subID uuid.UUID) ([]*domain.PriceChange, error)
+	PauseSub(ctx context.Context, pause *domain.Pause) (*domain.Pause, error)
+	ResumeSub(ctx context.Context, id uuid.UUID, month time.Time) (*domain.Sub, error)
+	ListPauses(ctx context.Context, subID uuid.UUID) ([]*domain.Pause, error)
 	ListSubs(ctx context.Context, opts domain.FilterOpts) ([]*domain.Sub, error)
 	GetSummary(ctx context.Context, opts domain.FilterOpts) (*domain.Summary, error)
+	GetForecast(ctx context.Context, userID uuid.UUID, from time.Time, months int) ([]*domain.ServiceSpend, error)
+	ListDuplicates(ctx context.Context, userID uuid.UUID) ([]*domain.Duplicate, error)
+	ListTags(ctx context.Context, userID uuid.UUID) ([]*domain.Tag, error)
+	RenameTag(ctx context.Context, userID uuid.UUID, from, to string) (*domain.Tag, error)
+	MergeTags(ctx context.Context, userID uuid.UUID, sources []string, target string) (*domain.Tag, error)
+	PutMember(ctx context.Context, member *domain.Member) (*domain.Member, error)
+	DeleteMember(ctx context.Context, subID, userID uuid.UUID) error
+	ListMembers(ctx context.Context, subID uuid.UUID) ([]*domain.Member, error)
+	GetSettlement(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Debt, error)
 }
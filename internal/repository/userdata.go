@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"subs-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type UserDataRepo interface {
+	PostExport(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error)
+	GetExport(ctx context.Context, userID, id uuid.UUID) (*domain.DataExport, error)
+	// GetExportArchive returns the latest completed export of the user along with its archive.
+	GetExportArchive(ctx context.Context, userID uuid.UUID) (*domain.DataExport, []byte, error)
+
+	// EraseUserData deletes all user's data and anonymizes the user's audit entries in one transaction.
+	EraseUserData(ctx context.Context, userID uuid.UUID) error
+}
+
+// ExportsRepo is used by the handler of data export jobs, see domain.JobDataExport.
+type ExportsRepo interface {
+	// GetUserData reads data of the user of the context's tenant.
+	GetUserData(ctx context.Context, userID uuid.UUID) (*domain.UserData, error)
+	CompleteExport(ctx context.Context, id uuid.UUID, archive []byte) error
+}
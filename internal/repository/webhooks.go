@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"subs-service/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhooksRepo interface {
+	PostWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	GetWebhook(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]*domain.Webhook, error)
+	PutWebhook(ctx context.Context, id uuid.UUID, webhook *domain.Webhook) (*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+
+	ListDeliveries(ctx context.Context, opts domain.DeliveryFilterOpts) ([]*domain.WebhookDelivery, error)
+	RedeliverDelivery(ctx context.Context, webhookID uuid.UUID, id int64) (*domain.WebhookDelivery, error)
+}
+
+// OutboxRepo is used by the worker, which delivers events written to the outbox by SubsRepo.
+type OutboxRepo interface {
+	// ClaimDeliveries locks due deliveries for the lease time, so that concurrent workers skip them.
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutgoingDelivery, error)
+	CompleteDelivery(ctx context.Context, id int64) error
+	// FailDelivery schedules the next attempt or, if retryAt is zero, moves delivery to the dead-letter state.
+	FailDelivery(ctx context.Context, id int64, reason string, retryAt time.Time) error
+
+	// EmitEndedEvents publishes ends of subscriptions, which ended after the given time.
+	EmitEndedEvents(ctx context.Context, since time.Time) (int64, error)
+	// PurgeEvents deletes events created before the given time, which have no undelivered deliveries.
+	PurgeEvents(ctx context.Context, before time.Time) (int64, error)
+}
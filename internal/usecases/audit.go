@@ -0,0 +1,13 @@
+package usecases
+
+import (
+	"context"
+	"subs-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type AuditService interface {
+	GetSubHistory(ctx context.Context, subID uuid.UUID) ([]*domain.AuditEntry, error)
+	ListAudit(ctx context.Context, opts domain.AuditFilterOpts) ([]*domain.AuditEntry, error)
+}
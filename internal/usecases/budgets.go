@@ -0,0 +1,18 @@
+package usecases
+
+import (
+	"context"
+	"subs-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type BudgetService interface {
+	PostBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error)
+	GetBudget(ctx context.Context, id uuid.UUID) (*domain.Budget, error)
+	ListBudgets(ctx context.Context, userID uuid.UUID) ([]*domain.Budget, error)
+	PutBudget(ctx context.Context, id uuid.UUID, budget *domain.Budget) (*domain.Budget, error)
+	DeleteBudget(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	// GetBudgetStatus reports utilisation of the budget at the current month.
+	GetBudgetStatus(ctx context.Context, id uuid.UUID) (*domain.BudgetStatus, error)
+}
@@ -0,0 +1,11 @@
+package usecases
+
+import (
+	"context"
+	"subs-service/internal/domain"
+)
+
+type CacheService interface {
+	// GetCacheStats reports stats of the cache of this instance only.
+	GetCacheStats(ctx context.Context) *domain.CacheStats
+}
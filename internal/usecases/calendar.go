@@ -0,0 +1,15 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type CalendarService interface {
+	// CreateFeedToken issues a new secret token of the user's calendar feed, the previous one is revoked.
+	CreateFeedToken(ctx context.Context, userID uuid.UUID) (string, error)
+	RevokeFeedToken(ctx context.Context, userID uuid.UUID) error
+	// GetCalendar renders the user's calendar in iCalendar format, if the token is valid.
+	GetCalendar(ctx context.Context, userID uuid.UUID, token string) ([]byte, error)
+}
@@ -3,5 +3,6 @@ package usecases
 import "errors"
 
 var (
-	ErrWrongPassword = errors.New("wrong user password")
+	ErrWrongPassword    = errors.New("wrong user password")
+	ErrInvalidFeedToken = errors.New("invalid calendar feed token")
 )
@@ -0,0 +1,14 @@
+package usecases
+
+import (
+	"context"
+	"subs-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type JobService interface {
+	GetJob(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	// CancelJob cancels the pending or running job, the running handler is stopped eventually.
+	CancelJob(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type AuditService struct {
+	auditRepo repository.AuditRepo
+}
+
+func NewAuditService(auditRepo repository.AuditRepo) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+	}
+}
+
+func (s *AuditService) GetSubHistory(ctx context.Context, subID uuid.UUID) ([]*domain.AuditEntry, error) {
+	const op = "AuditService.GetSubHistory"
+
+	entries, err := s.auditRepo.GetSubHistory(ctx, subID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrNoSubIDExists)
+	}
+
+	return entries, nil
+}
+
+func (s *AuditService) ListAudit(ctx context.Context, opts domain.AuditFilterOpts) ([]*domain.AuditEntry, error) {
+	const op = "AuditService.ListAudit"
+
+	entries, err := s.auditRepo.ListAudit(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	"subs-service/pkg/notify"
+	"time"
+)
+
+// BudgetChecker compares spends with budgets and alerts users about reached thresholds.
+// Budgets of a user are checked whenever user's subscriptions change, all budgets are checked
+// periodically. Every alert is recorded and sent once, even if several instances check budgets.
+type BudgetChecker struct {
+	budgetsRepo   repository.BudgetsRepo
+	subEventsRepo repository.SubEventsRepo
+	notifier      notify.Notifier
+	cfg           config.BudgetsConfig
+}
+
+func NewBudgetChecker(
+	budgetsRepo repository.BudgetsRepo,
+	subEventsRepo repository.SubEventsRepo,
+	notifier notify.Notifier,
+	cfg config.BudgetsConfig,
+) *BudgetChecker {
+	return &BudgetChecker{
+		budgetsRepo:   budgetsRepo,
+		subEventsRepo: subEventsRepo,
+		notifier:      notifier,
+		cfg:           cfg,
+	}
+}
+
+// Check checks all budgets.
+func (c *BudgetChecker) Check(ctx context.Context) error {
+	const op = "BudgetChecker.Check"
+
+	if err := c.check(ctx, domain.SubEvent{}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Run checks budgets of users, whose subscriptions are changed, until ctx is done. Changes are queued,
+// if the queue is full, the change is skipped and its budgets are checked by the periodic check.
+func (c *BudgetChecker) Run(ctx context.Context) {
+	events := make(chan domain.SubEvent, c.cfg.QueueSize)
+
+	go c.subEventsRepo.ListenSubEvents(ctx, func() {}, func(event *domain.SubEvent) {
+		select {
+		case events <- *event:
+		default:
+			log.Printf("[ERROR] Budget check queue is full, skipped user %s", event.UserID)
+		}
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			if err := c.check(ctx, event); err != nil {
+				log.Printf("[ERROR] Failed to check budgets of user %s: %s", event.UserID, err.Error())
+			}
+		}
+	}
+}
+
+// check checks budgets of the user changed by the event, users are identified within their tenants.
+func (c *BudgetChecker) check(ctx context.Context, event domain.SubEvent) error {
+	month := domain.StartOfMonth(time.Now())
+
+	spends, err := c.budgetsRepo.ListBudgetSpends(domain.WithTenant(ctx, event.TenantID), event.UserID, month)
+	if err != nil {
+		return err
+	}
+
+	var sent int
+	var errs []error
+
+	for _, spend := range spends {
+		for _, threshold := range spend.Reached() {
+			alert := domain.NewBudgetAlert(spend, month, threshold)
+
+			var recorded bool
+
+			if recorded, err = c.budgetsRepo.RecordAlert(ctx, alert); err != nil {
+				return err
+			}
+
+			if !recorded {
+				continue
+			}
+
+			// Failed alert is deleted to be sent again by the next check.
+			if err = c.notifier.Notify(ctx, budgetNotification(alert)); err != nil {
+				errs = append(errs, err)
+
+				if err = c.budgetsRepo.DeleteAlert(ctx, alert); err != nil {
+					errs = append(errs, err)
+				}
+
+				continue
+			}
+
+			sent++
+		}
+	}
+
+	if sent != 0 {
+		log.Printf("[INFO] Sent %d budget alerts", sent)
+	}
+
+	return errors.Join(errs...)
+}
+
+func budgetNotification(alert *domain.BudgetAlert) *notify.Notification {
+	subject := "Budget is almost spent"
+	if alert.Threshold >= 100 {
+		subject = "Budget is exceeded"
+	}
+
+	return &notify.Notification{
+		Key:     alert.Key(),
+		UserID:  alert.UserID.String(),
+		Subject: subject,
+		Text:    alert.Text(),
+		Data:    alert,
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BudgetService struct {
+	budgetsRepo repository.BudgetsRepo
+	cfg         config.BudgetsConfig
+}
+
+func NewBudgetService(budgetsRepo repository.BudgetsRepo, cfg config.BudgetsConfig) *BudgetService {
+	return &BudgetService{
+		budgetsRepo: budgetsRepo,
+		cfg:         cfg,
+	}
+}
+
+// withThresholds sets the default thresholds, if none are given.
+func (s *BudgetService) withThresholds(budget *domain.Budget) *domain.Budget {
+	if len(budget.Thresholds) == 0 {
+		budget.Thresholds = slices.Clone(s.cfg.DefaultThresholds)
+	}
+
+	slices.Sort(budget.Thresholds)
+	budget.Thresholds = slices.Compact(budget.Thresholds)
+
+	return budget
+}
+
+func (s *BudgetService) PostBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error) {
+	const op = "BudgetService.PostBudget"
+
+	created, err := s.budgetsRepo.PostBudget(ctx, s.withThresholds(budget))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+func (s *BudgetService) GetBudget(ctx context.Context, id uuid.UUID) (*domain.Budget, error) {
+	const op = "BudgetService.GetBudget"
+
+	budget, err := s.budgetsRepo.GetBudget(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return budget, nil
+}
+
+func (s *BudgetService) ListBudgets(ctx context.Context, userID uuid.UUID) ([]*domain.Budget, error) {
+	const op = "BudgetService.ListBudgets"
+
+	budgets, err := s.budgetsRepo.ListBudgets(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return budgets, nil
+}
+
+func (s *BudgetService) PutBudget(ctx context.Context, id uuid.UUID, budget *domain.Budget) (*domain.Budget, error) {
+	const op = "BudgetService.PutBudget"
+
+	updated, err := s.budgetsRepo.PutBudget(ctx, id, s.withThresholds(budget))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+func (s *BudgetService) DeleteBudget(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	const op = "BudgetService.DeleteBudget"
+
+	if err := s.budgetsRepo.DeleteBudget(ctx, id); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *BudgetService) GetBudgetStatus(ctx context.Context, id uuid.UUID) (*domain.BudgetStatus, error) {
+	const op = "BudgetService.GetBudgetStatus"
+
+	month := domain.StartOfMonth(time.Now())
+
+	spend, err := s.budgetsRepo.GetBudgetSpend(ctx, id, month)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return domain.NewBudgetStatus(spend, month), nil
+}
@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/usecases"
+	"subs-service/pkg/cache"
+
+	"github.com/google/uuid"
+)
+
+const (
+	cachedListSubs   = "list_subs"
+	cachedGetSummary = "get_summary"
+)
+
+type cacheKey struct {
+	tenant string
+	userID uuid.UUID
+	method string
+	opts   string
+}
+
+// CachedSubService caches lists and summaries of subscriptions for cfg.TTL. Mutations made through it
+// remove cached results of the users they affect: the owner and the members of the changed subscription.
+// Other services of the instance report their changes by InvalidateUser, changes made through other instances
+// are visible once cached results expire. Cached results are shared, so callers must not modify them.
+type CachedSubService struct {
+	usecases.SubService
+	cache *cache.LRU[cacheKey, any]
+	// pinned contexts bypass the cache, since they must read own recent writes, their results are cached though.
+	pinned func(ctx context.Context) bool
+}
+
+func NewCachedSubService(
+	subSvc usecases.SubService,
+	cfg config.CacheConfig,
+	pinned func(ctx context.Context) bool,
+) *CachedSubService {
+	s := &CachedSubService{
+		SubService: subSvc,
+		pinned:     pinned,
+	}
+
+	if cfg.Enabled {
+		s.cache = cache.NewLRU[cacheKey, any](cfg.Size, cfg.TTL)
+	}
+
+	return s
+}
+
+func (s *CachedSubService) ListSubs(ctx context.Context, opts domain.FilterOpts) ([]*domain.Sub, error) {
+	const op = "CachedSubService.ListSubs"
+
+	subs, err := getCached(ctx, s, cachedListSubs, opts, s.SubService.ListSubs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subs, nil
+}
+
+func (s *CachedSubService) GetSummary(ctx context.Context, opts domain.FilterOpts) (*domain.Summary, error) {
+	const op = "CachedSubService.GetSummary"
+
+	sum, err := getCached(ctx, s, cachedGetSummary, opts, s.SubService.GetSummary)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sum, nil
+}
+
+func (s *CachedSubService) PostSub(ctx context.Context, sub *domain.Sub) (*domain.Sub, error) {
+	const op = "CachedSubService.PostSub"
+
+	sub, err := s.SubService.PostSub(ctx, sub)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(ctx, []uuid.UUID{sub.UserID})
+
+	return sub, nil
+}
+
+// PutSub resolves users before the update, since the subscription may be moved to another user.
+func (s *CachedSubService) PutSub(ctx context.Context, id uuid.UUID, sub *domain.Sub, opts domain.PutOpts) (*domain.Sub, error) {
+	const op = "CachedSubService.PutSub"
+
+	users := s.subUsers(ctx, id)
+
+	sub, err := s.SubService.PutSub(ctx, id, sub, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if users != nil {
+		users = append(users, sub.UserID)
+	}
+
+	s.invalidate(ctx, users)
+
+	return sub, nil
+}
+
+// DeleteSub resolves users before the deletion, since members of deleted subscriptions can't be listed.
+func (s *CachedSubService) DeleteSub(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	const op = "CachedSubService.DeleteSub"
+
+	users := s.subUsers(ctx, id)
+
+	id, err := s.SubService.DeleteSub(ctx, id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(ctx, users)
+
+	return id, nil
+}
+
+func (s *CachedSubService) RestoreSub(ctx context.Context, id uuid.UUID) (*domain.Sub, error) {
+	const op = "CachedSubService.RestoreSub"
+
+	sub, err := s.SubService.RestoreSub(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(ctx, s.subUsers(ctx, id))
+
+	return sub, nil
+}
+
+func (s *CachedSubService) AddPriceChange(ctx context.Context, change *domain.PriceChange) (*domain.Sub, error) {
+	const op = "CachedSubService.AddPriceChange"
+
+	sub, err := s.SubService.AddPriceChange(ctx, change)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(ctx, s.subUsers(ctx, change.SubID))
+
+	return sub, nil
+}
+
+func (s *CachedSubService) PauseSub(ctx context.Context, pause *domain.Pause) (*domain.Pause, error) {
+	const op = "CachedSubService.PauseSub"
+
+	pause, err := s.SubService.PauseSub(ctx, pause)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(ctx, s.subUsers(ctx, pause.SubID))
+
+	return pause, nil
+}
+
+func (s *CachedSubService) ResumeSub(ctx context.Context, id uuid.UUID) (*domain.Sub, error) {
+	const op = "CachedSubService.ResumeSub"
+
+	sub, err := s.SubService.ResumeSub(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(ctx, s.subUsers(ctx, id))
+
+	return sub, nil
+}
+
+// RenameTag and MergeTags invalidate results of the user only, so members see new tags
+// of shared subscriptions once cached results expire.
+func (s *CachedSubService) RenameTag(ctx context.Context, userID uuid.UUID, from, to string) (*domain.Tag, error) {
+	const op = "CachedSubService.RenameTag"
+
+	tag, err := s.SubService.RenameTag(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(ctx, []uuid.UUID{userID})
+
+	return tag, nil
+}
+
+func (s *CachedSubService) MergeTags(ctx context.Context, userID uuid.UUID, sources []string, target string) (*domain.Tag, error) {
+	const op = "CachedSubService.MergeTags"
+
+	tag, err := s.SubService.MergeTags(ctx, userID, sources, target)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(ctx, []uuid.UUID{userID})
+
+	return tag, nil
+}
+
+func (s *CachedSubService) PutMember(ctx context.Context, member *domain.Member) (*domain.Member, error) {
+	const op = "CachedSubService.PutMember"
+
+	member, err := s.SubService.PutMember(ctx, member)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(ctx, s.subUsers(ctx, member.SubID))
+
+	return member, nil
+}
+
+func (s *CachedSubService) DeleteMember(ctx context.Context, subID, userID uuid.UUID) error {
+	const op = "CachedSubService.DeleteMember"
+
+	if err := s.SubService.DeleteMember(ctx, subID, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	users := s.subUsers(ctx, subID)
+	if users != nil {
+		users = append(users, userID)
+	}
+
+	s.invalidate(ctx, users)
+
+	return nil
+}
+
+func (s *CachedSubService) GetCacheStats(_ context.Context) *domain.CacheStats {
+	if s.cache == nil {
+		return &domain.CacheStats{}
+	}
+
+	stats := s.cache.Stats()
+
+	res := &domain.CacheStats{
+		Enabled:   true,
+		Size:      stats.Size,
+		Capacity:  stats.Capacity,
+		Hits:      stats.Hits,
+		Misses:    stats.Misses,
+		Evictions: stats.Evictions,
+	}
+
+	if total := stats.Hits + stats.Misses; total != 0 {
+		res.HitRatio = float64(stats.Hits) / float64(total)
+	}
+
+	return res
+}
+
+// InvalidateUser removes cached results after changes of all data of the user (e.g. its erasure).
+// Subscriptions and shares of the user affect results of other users, who are unknown once the data are gone,
+// so results of all users of the tenant are removed.
+func (s *CachedSubService) InvalidateUser(ctx context.Context, _ uuid.UUID) {
+	s.invalidate(ctx, nil)
+}
+
+// InvalidateAll removes cached results of all tenants after changes of data shared by them (e.g. the catalog).
+func (s *CachedSubService) InvalidateAll(_ context.Context) {
+	if s.cache == nil {
+		return
+	}
+
+	s.cache.RemoveFunc(func(cacheKey) bool {
+		return true
+	})
+}
+
+// getCached doesn't cache errors.
+func getCached[T any](
+	ctx context.Context,
+	s *CachedSubService,
+	method string,
+	opts domain.FilterOpts,
+	get func(ctx context.Context, opts domain.FilterOpts) (T, error),
+) (T, error) {
+	if s.cache == nil {
+		return get(ctx, opts)
+	}
+
+	key := cacheKey{
+		tenant: domain.TenantFromContext(ctx),
+		userID: opts.UserID,
+		method: method,
+		opts:   normalizeFilterOpts(opts),
+	}
+
+	if !s.pinned(ctx) {
+		if res, ok := s.cache.Get(key); ok {
+			return res.(T), nil
+		}
+	}
+
+	version := s.cache.Version()
+
+	res, err := get(ctx, opts)
+	if err != nil {
+		return res, err
+	}
+
+	s.cache.Add(key, res, version)
+
+	return res, nil
+}
+
+// normalizeFilterOpts makes equal filters share cached results, e.g. regardless of the order of tags.
+func normalizeFilterOpts(opts domain.FilterOpts) string {
+	tags := slices.Clone(opts.Tags)
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+
+	tagsMatch := opts.TagsMatch
+	if len(tags) == 0 {
+		tagsMatch = ""
+	}
+
+	var from, to string
+	if !opts.From.IsZero() {
+		from, to = opts.From.Format(domain.TimeLayout), opts.To.Format(domain.TimeLayout)
+	}
+
+	return fmt.Sprintf(
+		"%q %q %q %q %q %s %d %s %s %d %t",
+		opts.ServiceName, opts.Category, tags, tagsMatch, opts.GroupBy, opts.PageToken, opts.PageSize,
+		from, to, opts.TrialEndsWithin, opts.IncludeDeleted,
+	)
+}
+
+// subUsers returns the owner and the members of the subscription, whose cached results the changes
+// of the subscription affect, or nil, if they are unknown.
+func (s *CachedSubService) subUsers(ctx context.Context, subID uuid.UUID) []uuid.UUID {
+	if s.cache == nil {
+		return nil
+	}
+
+	sub, err := s.SubService.GetSub(ctx, subID, domain.GetOpts{})
+	if err != nil {
+		return nil
+	}
+
+	members, err := s.SubService.ListMembers(ctx, subID)
+	if err != nil {
+		return nil
+	}
+
+	users := []uuid.UUID{sub.UserID}
+	for _, member := range members {
+		users = append(users, member.UserID)
+	}
+
+	return users
+}
+
+// invalidate removes cached results of the users of the request's tenant or, if users are unknown (nil),
+// results of all users of the tenant.
+func (s *CachedSubService) invalidate(ctx context.Context, users []uuid.UUID) {
+	if s.cache == nil {
+		return
+	}
+
+	tenant := domain.TenantFromContext(ctx)
+
+	s.cache.RemoveFunc(func(key cacheKey) bool {
+		return key.tenant == tenant && (users == nil || slices.Contains(users, key.userID))
+	})
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	"subs-service/internal/usecases"
+	"subs-service/pkg/ical"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	calendarProdID = "-//subs-service//Subscriptions//EN"
+	feedTokenSize  = 32
+)
+
+type CalendarService struct {
+	calendarRepo repository.CalendarRepo
+	cfg          config.CalendarConfig
+}
+
+func NewCalendarService(calendarRepo repository.CalendarRepo, cfg config.CalendarConfig) *CalendarService {
+	return &CalendarService{
+		calendarRepo: calendarRepo,
+		cfg:          cfg,
+	}
+}
+
+func hashFeedToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
+
+func (s *CalendarService) CreateFeedToken(ctx context.Context, userID uuid.UUID) (string, error) {
+	const op = "CalendarService.CreateFeedToken"
+
+	secret := make([]byte, feedTokenSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	if err := s.calendarRepo.PutFeedToken(ctx, userID, hashFeedToken(token)); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+func (s *CalendarService) RevokeFeedToken(ctx context.Context, userID uuid.UUID) error {
+	const op = "CalendarService.RevokeFeedToken"
+
+	if err := s.calendarRepo.DeleteFeedToken(ctx, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *CalendarService) GetCalendar(ctx context.Context, userID uuid.UUID, token string) ([]byte, error) {
+	const op = "CalendarService.GetCalendar"
+
+	tenant, err := s.calendarRepo.CheckFeedToken(ctx, userID, hashFeedToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(tenant) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, usecases.ErrInvalidFeedToken)
+	}
+
+	subs, err := s.calendarRepo.ListCalendarSubs(domain.WithTenant(ctx, tenant), userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	calendar := ical.Calendar{
+		ProdID:          calendarProdID,
+		Name:            s.cfg.Name,
+		RefreshInterval: s.cfg.RefreshInterval,
+	}
+
+	now := time.Now()
+	for _, sub := range subs {
+		calendar.Events = append(calendar.Events, subEvents(sub, now)...)
+	}
+
+	return calendar.Encode(), nil
+}
+
+// subEvents renders a monthly recurring event of subscription's charges, which excludes paused months,
+// and an event of its end. Months are charged on their first days.
+func subEvents(calendarSub *domain.CalendarSub, now time.Time) []ical.Event {
+	sub := calendarSub.Sub
+	events := []ical.Event{}
+
+	// End date is exclusive, so the last charge is a month before.
+	var lastCharge time.Time
+	if !sub.EndDate.IsZero() {
+		lastCharge = sub.EndDate.AddDate(0, -1, 0)
+	}
+
+	var exDates []time.Time
+
+	for _, pause := range calendarSub.Pauses {
+		if pause.To.IsZero() {
+			// Subscription is not charged after the open pause starts, until it is resumed.
+			if pauseEnd := pause.From.AddDate(0, -1, 0); lastCharge.IsZero() || pauseEnd.Before(lastCharge) {
+				lastCharge = pauseEnd
+			}
+
+			continue
+		}
+
+		for month := pause.From; !month.After(pause.To); month = month.AddDate(0, 1, 0) {
+			exDates = append(exDates, month)
+		}
+	}
+
+	if lastCharge.IsZero() || !lastCharge.Before(sub.StartDate) {
+		rrule := "FREQ=MONTHLY"
+		if !lastCharge.IsZero() {
+			rrule += ";UNTIL=" + ical.Date(lastCharge)
+		}
+
+		events = append(events, ical.Event{
+			UID:         fmt.Sprintf("%s-charge@subs-service", sub.ID),
+			Stamp:       now,
+			Date:        sub.StartDate,
+			Summary:     fmt.Sprintf("%s: %d", sub.ServiceName, sub.Price),
+			Description: chargeDescription(sub, lastCharge),
+			RRule:       rrule,
+			ExDates:     exDates,
+		})
+	}
+
+	if !sub.EndDate.IsZero() {
+		events = append(events, ical.Event{
+			UID:     fmt.Sprintf("%s-end@subs-service", sub.ID),
+			Stamp:   now,
+			Date:    sub.EndDate,
+			Summary: fmt.Sprintf("%s ends", sub.ServiceName),
+			Description: fmt.Sprintf(
+				"Subscription %s ends, it was charged %d monthly.", sub.ServiceName, sub.Price,
+			),
+		})
+	}
+
+	return events
+}
+
+func chargeDescription(sub *domain.Sub, lastCharge time.Time) string {
+	lines := []string{fmt.Sprintf("Subscription %s is charged %d monthly.", sub.ServiceName, sub.Price)}
+
+	if trialEnd := sub.TrialEnd(); !trialEnd.IsZero() {
+		if sub.PromoPrice == 0 {
+			lines = append(lines, fmt.Sprintf("Free trial until %s.", trialEnd.Format(domain.TimeLayout)))
+		} else {
+			lines = append(lines, fmt.Sprintf(
+				"Trial until %s is charged %d monthly.", trialEnd.Format(domain.TimeLayout), sub.PromoPrice,
+			))
+		}
+	}
+
+	if !lastCharge.IsZero() {
+		lines = append(lines, fmt.Sprintf("Last charge: %s.", lastCharge.Format(domain.TimeLayout)))
+	}
+
+	return strings.Join(lines, "\n")
+}
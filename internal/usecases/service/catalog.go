@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type CatalogService struct {
+	catalogRepo repository.CatalogRepo
+	// invalidate removes cached results of all tenants, as names of their subscriptions follow the catalog.
+	invalidate func(ctx context.Context)
+}
+
+func NewCatalogService(catalogRepo repository.CatalogRepo, invalidate func(ctx context.Context)) *CatalogService {
+	return &CatalogService{
+		catalogRepo: catalogRepo,
+		invalidate:  invalidate,
+	}
+}
+
+func (s *CatalogService) PostService(ctx context.Context, service *domain.Service) (*domain.Service, error) {
+	const op = "CatalogService.PostService"
+
+	created, err := s.catalogRepo.PostService(ctx, service)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(ctx)
+
+	return created, nil
+}
+
+func (s *CatalogService) GetService(ctx context.Context, id uuid.UUID) (*domain.Service, error) {
+	const op = "CatalogService.GetService"
+
+	service, err := s.catalogRepo.GetService(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return service, nil
+}
+
+func (s *CatalogService) ListServices(ctx context.Context) ([]*domain.Service, error) {
+	const op = "CatalogService.ListServices"
+
+	services, err := s.catalogRepo.ListServices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return services, nil
+}
+
+func (s *CatalogService) PutService(ctx context.Context, id uuid.UUID, service *domain.Service) (*domain.Service, error) {
+	const op = "CatalogService.PutService"
+
+	updated, err := s.catalogRepo.PutService(ctx, id, service)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(ctx)
+
+	return updated, nil
+}
+
+func (s *CatalogService) DeleteService(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	const op = "CatalogService.DeleteService"
+
+	if err := s.catalogRepo.DeleteService(ctx, id); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(ctx)
+
+	return id, nil
+}
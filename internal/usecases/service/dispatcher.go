@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	"subs-service/pkg/webhook"
+	"time"
+)
+
+// WebhookDispatcher delivers events from the outbox to webhooks. Failed deliveries are retried
+// with exponential backoff and are moved to the dead-letter state after MaxAttempts.
+type WebhookDispatcher struct {
+	outboxRepo repository.OutboxRepo
+	client     *webhook.Client
+	cfg        config.WebhooksConfig
+}
+
+func NewWebhookDispatcher(outboxRepo repository.OutboxRepo, cfg config.WebhooksConfig) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		outboxRepo: outboxRepo,
+		client:     webhook.NewClient(cfg.Timeout),
+		cfg:        cfg,
+	}
+}
+
+func (d *WebhookDispatcher) Dispatch(ctx context.Context) error {
+	const op = "WebhookDispatcher.Dispatch"
+
+	now := time.Now()
+
+	// Failures of maintenance must not stop delivery of pending events, they are retried next time.
+	if _, err := d.outboxRepo.EmitEndedEvents(ctx, now.Add(-d.cfg.Retention)); err != nil {
+		log.Printf("[ERROR] Failed to emit ended subscription events: %s", err.Error())
+	}
+
+	if _, err := d.outboxRepo.PurgeEvents(ctx, now.Add(-d.cfg.Retention)); err != nil {
+		log.Printf("[ERROR] Failed to purge webhook events: %s", err.Error())
+	}
+
+	// Lease covers sending of the whole batch, so that deliveries are not claimed twice.
+	lease := d.cfg.Timeout*time.Duration(d.cfg.BatchSize) + d.cfg.Interval
+
+	deliveries, err := d.outboxRepo.ClaimDeliveries(ctx, d.cfg.BatchSize, lease)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var processed int
+
+	for _, delivery := range deliveries {
+		if err = d.deliver(ctx, delivery); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		processed++
+	}
+
+	if processed != 0 {
+		log.Printf("[INFO] Processed %d webhook deliveries", processed)
+	}
+
+	return nil
+}
+
+// deliver returns error only if delivery's state failed to be saved.
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *domain.OutgoingDelivery) error {
+	body, err := json.Marshal(&delivery.Payload)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		webhook.EventHeader: string(delivery.Payload.Event),
+		webhook.IDHeader:    strconv.FormatInt(delivery.Payload.EventID, 10),
+	}
+
+	sendErr := d.client.Send(ctx, delivery.URL, delivery.Secret, headers, body)
+	if sendErr == nil {
+		return d.outboxRepo.CompleteDelivery(ctx, delivery.ID)
+	}
+
+	attempts := delivery.Attempts + 1
+
+	var retryAt time.Time
+	if attempts < d.cfg.MaxAttempts {
+		retryAt = time.Now().Add(backoff(attempts, d.cfg.RetryBackoff, d.cfg.MaxRetryBackoff))
+	} else {
+		log.Printf("[WARN] Webhook delivery %d is dead after %d attempts", delivery.ID, attempts)
+	}
+
+	return d.outboxRepo.FailDelivery(ctx, delivery.ID, sendErr.Error(), retryAt)
+}
+
+// backoff doubles the delay after every failed attempt.
+func backoff(attempts int, base, maxBackoff time.Duration) time.Duration {
+	delay := base
+
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxBackoff)
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Exporter builds archives of exports of users' data, it handles jobs of type domain.JobDataExport.
+type Exporter struct {
+	exportsRepo repository.ExportsRepo
+}
+
+func NewExporter(exportsRepo repository.ExportsRepo) *Exporter {
+	return &Exporter{
+		exportsRepo: exportsRepo,
+	}
+}
+
+func (e *Exporter) Export(ctx context.Context, job *domain.Job, progress JobProgressFunc) (any, error) {
+	const op = "Exporter.Export"
+
+	var payload domain.DataExportPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	data, err := e.exportsRepo.GetUserData(ctx, payload.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = progress(ctx, 50); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	archive, err := buildArchive(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = e.exportsRepo.CompleteExport(ctx, payload.ExportID, archive); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return domain.DataExportResult{ExportID: payload.ExportID, Size: len(archive)}, nil
+}
+
+// archiveFile is written to the archive both as JSON and CSV.
+type archiveFile struct {
+	name   string
+	data   any
+	header []string
+	rows   [][]string
+}
+
+func buildArchive(data *domain.UserData) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := zip.NewWriter(&buf)
+
+	for _, file := range userDataFiles(data) {
+		if err := writeArchiveFile(w, file); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeArchiveFile(w *zip.Writer, file archiveFile) error {
+	jsonFile, err := w.Create(file.name + ".json")
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(jsonFile)
+	encoder.SetIndent("", "  ")
+
+	if err = encoder.Encode(file.data); err != nil {
+		return err
+	}
+
+	csvFile, err := w.Create(file.name + ".csv")
+	if err != nil {
+		return err
+	}
+
+	csvWriter := csv.NewWriter(csvFile)
+	if err = csvWriter.Write(file.header); err != nil {
+		return err
+	}
+
+	return csvWriter.WriteAll(file.rows)
+}
+
+func userDataFiles(data *domain.UserData) []archiveFile {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+
+		return t.Format(time.RFC3339)
+	}
+
+	formatMonth := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+
+		return t.Format(domain.TimeLayout)
+	}
+
+	formatID := func(id uuid.UUID) string {
+		if id == uuid.Nil {
+			return ""
+		}
+
+		return id.String()
+	}
+
+	subs := archiveFile{
+		name: "subs",
+		data: data.Subs,
+		header: []string{
+			"id", "service_name", "service_id", "price", "start_date", "end_date",
+			"trial_months", "promo_price", "category", "tags", "deleted_at",
+		},
+	}
+
+	for _, sub := range data.Subs {
+		subs.rows = append(subs.rows, []string{
+			sub.ID.String(), sub.ServiceName, formatID(sub.ServiceID), strconv.FormatInt(sub.Price, 10),
+			formatMonth(sub.StartDate), formatMonth(sub.EndDate), strconv.Itoa(sub.TrialMonths),
+			strconv.FormatInt(sub.PromoPrice, 10), sub.Category, strings.Join(sub.Tags, ";"), formatTime(sub.DeletedAt),
+		})
+	}
+
+	history := archiveFile{
+		name:   "history",
+		data:   data.History,
+		header: []string{"id", "sub_id", "operation", "actor", "request_id", "before", "after", "created_at"},
+	}
+
+	for _, entry := range data.History {
+		history.rows = append(history.rows, []string{
+			strconv.FormatInt(entry.ID, 10), formatID(entry.SubID), string(entry.Operation), entry.Actor, entry.RequestID,
+			string(entry.Before), string(entry.After), formatTime(entry.CreatedAt),
+		})
+	}
+
+	budgets := archiveFile{
+		name:   "budgets",
+		data:   data.Budgets,
+		header: []string{"id", "service_name", "category", "amount", "thresholds", "created_at"},
+	}
+
+	for _, budget := range data.Budgets {
+		thresholds := make([]string, 0, len(budget.Thresholds))
+		for _, threshold := range budget.Thresholds {
+			thresholds = append(thresholds, strconv.Itoa(threshold))
+		}
+
+		budgets.rows = append(budgets.rows, []string{
+			budget.ID.String(), budget.ServiceName, budget.Category, strconv.FormatInt(budget.Amount, 10),
+			strings.Join(thresholds, ";"), formatTime(budget.CreatedAt),
+		})
+	}
+
+	notifications := archiveFile{
+		name:   "notifications",
+		data:   data.Notifications,
+		header: []string{"key", "sent_at"},
+	}
+
+	for _, notification := range data.Notifications {
+		notifications.rows = append(notifications.rows, []string{notification.Key, formatTime(notification.SentAt)})
+	}
+
+	return []archiveFile{subs, history, budgets, notifications}
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	"subs-service/pkg/worker"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobService struct {
+	jobsRepo repository.JobsRepo
+}
+
+func NewJobService(jobsRepo repository.JobsRepo) *JobService {
+	return &JobService{
+		jobsRepo: jobsRepo,
+	}
+}
+
+func (s *JobService) GetJob(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	const op = "JobService.GetJob"
+
+	job, err := s.jobsRepo.GetJob(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return job, nil
+}
+
+func (s *JobService) CancelJob(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	const op = "JobService.CancelJob"
+
+	job, err := s.jobsRepo.CancelJob(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return job, nil
+}
+
+// JobHandlerFunc runs the job and returns its result, which is stored as JSON. The context carries the tenant
+// of the job and is cancelled, once the job is cancelled or times out.
+type JobHandlerFunc func(ctx context.Context, job *domain.Job, progress JobProgressFunc) (any, error)
+
+// JobProgressFunc reports progress of the job in percents.
+type JobProgressFunc func(ctx context.Context, percent int) error
+
+// JobRunner runs jobs by handlers registered for their types. Failed jobs are retried
+// with exponential backoff and fail after MaxAttempts.
+type JobRunner struct {
+	jobQueueRepo repository.JobQueueRepo
+	handlers     map[string]JobHandlerFunc
+	cfg          config.JobsConfig
+}
+
+func NewJobRunner(jobQueueRepo repository.JobQueueRepo, cfg config.JobsConfig) *JobRunner {
+	return &JobRunner{
+		jobQueueRepo: jobQueueRepo,
+		handlers:     make(map[string]JobHandlerFunc),
+		cfg:          cfg,
+	}
+}
+
+// Register must be called before Run, jobs of types without handlers are left pending.
+func (r *JobRunner) Register(jobType string, handler JobHandlerFunc) {
+	r.handlers[jobType] = handler
+}
+
+// Run starts Workers workers, which run due jobs every Interval, and purges finished jobs until ctx is done.
+func (r *JobRunner) Run(ctx context.Context) {
+	types := make([]string, 0, len(r.handlers))
+	for jobType := range r.handlers {
+		types = append(types, jobType)
+	}
+
+	for i := range r.cfg.Workers {
+		go worker.RunPeriodically(ctx, fmt.Sprintf("jobs-%d", i+1), r.cfg.Interval, func(ctx context.Context) error {
+			return r.runDue(ctx, types)
+		})
+	}
+
+	worker.RunPeriodically(ctx, "jobs-purge", r.cfg.PurgeInterval, r.purge)
+}
+
+func (r *JobRunner) runDue(ctx context.Context, types []string) error {
+	const op = "JobRunner.runDue"
+
+	for ctx.Err() == nil {
+		job, err := r.jobQueueRepo.ClaimJob(ctx, types, r.cfg.Lease)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if job == nil {
+			return nil
+		}
+
+		if err = r.run(ctx, job); err != nil && !errors.Is(err, repository.ErrJobNotRunning) {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// run returns error only if job's state failed to be saved.
+func (r *JobRunner) run(ctx context.Context, job *domain.Job) error {
+	// Jobs reclaimed after the lease was over may crash runners, so their attempts are limited too.
+	if job.Attempts > r.cfg.MaxAttempts {
+		return r.jobQueueRepo.FailJob(ctx, job.ID, job.Attempts, "runner stopped during the last attempt", time.Time{})
+	}
+
+	jobCtx, cancel := context.WithTimeout(domain.WithTenant(ctx, job.TenantID), r.cfg.Timeout)
+	defer cancel()
+
+	go r.keepLease(jobCtx, cancel, job)
+
+	progress := func(ctx context.Context, percent int) error {
+		return r.jobQueueRepo.SetJobProgress(ctx, job.ID, job.Attempts, min(max(percent, 0), 100))
+	}
+
+	result, err := r.handle(jobCtx, job, progress)
+	if err == nil {
+		var data []byte
+		if data, err = json.Marshal(result); err == nil {
+			return r.jobQueueRepo.CompleteJob(ctx, job.ID, job.Attempts, data)
+		}
+	}
+
+	var retryAt time.Time
+	if job.Attempts < r.cfg.MaxAttempts {
+		retryAt = time.Now().Add(backoff(job.Attempts, r.cfg.RetryBackoff, r.cfg.MaxRetryBackoff))
+	} else {
+		log.Printf("[WARN] Job %s (%s) failed after %d attempts: %s", job.ID, job.Type, job.Attempts, err.Error())
+	}
+
+	return r.jobQueueRepo.FailJob(ctx, job.ID, job.Attempts, err.Error(), retryAt)
+}
+
+// handle recovers panics of the handler, so that they fail the job instead of the runner.
+func (r *JobRunner) handle(ctx context.Context, job *domain.Job, progress JobProgressFunc) (result any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job handler panicked: %v", p)
+		}
+	}()
+
+	return r.handlers[job.Type](ctx, job, progress)
+}
+
+// keepLease extends the lease of the running job until ctx is done and stops the handler,
+// once the job is cancelled or reclaimed.
+func (r *JobRunner) keepLease(ctx context.Context, cancel context.CancelFunc, job *domain.Job) {
+	ticker := time.NewTicker(r.cfg.Lease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := r.jobQueueRepo.ExtendLease(ctx, job.ID, job.Attempts, r.cfg.Lease)
+			if errors.Is(err, repository.ErrJobNotRunning) {
+				log.Printf("[INFO] Job %s (%s) is not running anymore, stopping it", job.ID, job.Type)
+				cancel()
+
+				return
+			}
+
+			if err != nil && ctx.Err() == nil {
+				log.Printf("[ERROR] Failed to extend lease of job %s: %s", job.ID, err.Error())
+			}
+		}
+	}
+}
+
+func (r *JobRunner) purge(ctx context.Context) error {
+	const op = "JobRunner.purge"
+
+	purged, err := r.jobQueueRepo.PurgeJobs(ctx, time.Now().Add(-r.cfg.Retention))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if purged != 0 {
+		log.Printf("[INFO] Purged %d finished jobs", purged)
+	}
+
+	return nil
+}
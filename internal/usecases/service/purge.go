@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"subs-service/internal/config"
+	"subs-service/internal/repository"
+	"time"
+)
+
+// Purger permanently deletes subscriptions, which were deleted more than retention period ago.
+type Purger struct {
+	subRepo repository.SubsRepo
+	cfg     config.PurgeConfig
+}
+
+func NewPurger(subRepo repository.SubsRepo, cfg config.PurgeConfig) *Purger {
+	return &Purger{
+		subRepo: subRepo,
+		cfg:     cfg,
+	}
+}
+
+func (p *Purger) Purge(ctx context.Context) error {
+	const op = "Purger.Purge"
+
+	purged, err := p.subRepo.PurgeDeletedSubs(ctx, time.Now().Add(-p.cfg.Retention))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if purged != 0 {
+		log.Printf("[INFO] Purged %d deleted subscriptions", purged)
+	}
+
+	return nil
+}
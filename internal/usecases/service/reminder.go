@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	"subs-service/pkg/notify"
+	"time"
+)
+
+// Reminder notifies users about upcoming charges and ends of their subscriptions
+// within the lead time. Every reminder is sent once, even if the service is restarted.
+type Reminder struct {
+	remindersRepo     repository.RemindersRepo
+	notificationsRepo repository.NotificationsRepo
+	notifier          notify.Notifier
+	cfg               config.ReminderConfig
+}
+
+func NewReminder(
+	remindersRepo repository.RemindersRepo,
+	notificationsRepo repository.NotificationsRepo,
+	notifier notify.Notifier,
+	cfg config.ReminderConfig,
+) *Reminder {
+	return &Reminder{
+		remindersRepo:     remindersRepo,
+		notificationsRepo: notificationsRepo,
+		notifier:          notifier,
+		cfg:               cfg,
+	}
+}
+
+func (r *Reminder) Remind(ctx context.Context) error {
+	const op = "Reminder.Remind"
+
+	reminders, err := r.remindersRepo.ListReminders(ctx, time.Now().Add(r.cfg.LeadTime))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var sent int
+	var errs []error
+
+	for _, reminder := range reminders {
+		var claimed bool
+
+		if claimed, err = r.notificationsRepo.ClaimNotification(ctx, reminder); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if !claimed {
+			continue
+		}
+
+		// Failed reminder is released to be retried next time.
+		if err = r.notifier.Notify(ctx, reminderNotification(reminder)); err != nil {
+			errs = append(errs, err)
+
+			if err = r.notificationsRepo.ReleaseNotification(ctx, reminder.Key()); err != nil {
+				errs = append(errs, err)
+			}
+
+			continue
+		}
+
+		sent++
+	}
+
+	if sent != 0 {
+		log.Printf("[INFO] Sent %d reminders", sent)
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf("%s: %w", op, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func reminderNotification(reminder *domain.Reminder) *notify.Notification {
+	subject := "Upcoming subscription charge"
+	if reminder.Kind == domain.ReminderEnd {
+		subject = "Subscription ends soon"
+	}
+
+	return &notify.Notification{
+		Key:     reminder.Key(),
+		UserID:  reminder.UserID.String(),
+		Subject: subject,
+		Text:    reminder.Text(),
+		Data:    reminder,
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	"subs-service/pkg/pubsub"
+
+	"github.com/google/uuid"
+)
+
+// StreamService delivers changes of subscriptions made by any instance of the service to the streams
+// of this instance: changes are received from the repository and fanned out by the hub.
+type StreamService struct {
+	subEventsRepo repository.SubEventsRepo
+	hub           *pubsub.Hub
+	cfg           config.StreamConfig
+}
+
+func NewStreamService(subEventsRepo repository.SubEventsRepo, cfg config.StreamConfig) *StreamService {
+	return &StreamService{
+		subEventsRepo: subEventsRepo,
+		hub:           pubsub.NewHub(cfg.BufferSize),
+		cfg:           cfg,
+	}
+}
+
+// Run publishes changes until ctx is done, then all streams are closed.
+func (s *StreamService) Run(ctx context.Context) {
+	defer s.hub.Close()
+
+	s.subEventsRepo.ListenSubEvents(ctx, s.hub.MarkGap, func(event *domain.SubEvent) {
+		data, err := json.Marshal(event)
+		if err != nil {
+			log.Printf("[ERROR] Failed to encode subscription event: %s", err.Error())
+			return
+		}
+
+		s.hub.Publish(pubsub.Event{
+			ID:    event.ID,
+			Topic: streamTopic(event.TenantID, event.UserID),
+			Type:  string(event.Event),
+			Data:  data,
+		})
+	})
+}
+
+func (s *StreamService) SubscribeSubEvents(
+	tenant string, userID uuid.UUID, lastEventID int64,
+) (*pubsub.Subscription, []pubsub.Event, bool) {
+	return s.hub.Subscribe(streamTopic(tenant, userID), lastEventID, s.cfg.ClientBufferSize)
+}
+
+// streamTopic separates users of different tenants, as user ids are not unique across tenants.
+func streamTopic(tenant string, userID uuid.UUID) string {
+	return tenant + "/" + userID.String()
+}
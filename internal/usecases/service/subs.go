@@ -3,26 +3,31 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
+	"subs-service/internal/config"
 	"subs-service/internal/domain"
 	"subs-service/internal/repository"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type SubService struct {
 	subRepo repository.SubsRepo
+	cfg     config.DuplicatesConfig
 }
 
-func NewSubService(subRepo repository.SubsRepo) *SubService {
+func NewSubService(subRepo repository.SubsRepo, cfg config.DuplicatesConfig) *SubService {
 	return &SubService{
 		subRepo: subRepo,
+		cfg:     cfg,
 	}
 }
 
-func (s *SubService) GetSub(ctx context.Context, id uuid.UUID) (*domain.Sub, error) {
+func (s *SubService) GetSub(ctx context.Context, id uuid.UUID, opts domain.GetOpts) (*domain.Sub, error) {
 	const op = "SubService.GetSub"
 
-	sub, err := s.subRepo.GetSub(ctx, id)
+	sub, err := s.subRepo.GetSub(ctx, id, opts)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -33,7 +38,7 @@ func (s *SubService) GetSub(ctx context.Context, id uuid.UUID) (*domain.Sub, err
 func (s *SubService) PostSub(ctx context.Context, sub *domain.Sub) (*domain.Sub, error) {
 	const op = "SubService.PostSub"
 
-	id, err := s.subRepo.PostSub(ctx, sub)
+	id, err := s.subRepo.PostSub(ctx, sub, domain.PostOpts{RejectOverlaps: s.cfg.Strict})
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -43,10 +48,10 @@ func (s *SubService) PostSub(ctx context.Context, sub *domain.Sub) (*domain.Sub,
 	return sub, nil
 }
 
-func (s *SubService) PutSub(ctx context.Context, id uuid.UUID, sub *domain.Sub) (*domain.Sub, error) {
+func (s *SubService) PutSub(ctx context.Context, id uuid.UUID, sub *domain.Sub, opts domain.PutOpts) (*domain.Sub, error) {
 	const op = "SubService.PutSub"
 
-	if err := s.subRepo.PutSub(ctx, id, sub); err != nil {
+	if err := s.subRepo.PutSub(ctx, id, sub, opts); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -65,6 +70,73 @@ func (s *SubService) DeleteSub(ctx context.Context, id uuid.UUID) (uuid.UUID, er
 	return id, nil
 }
 
+func (s *SubService) RestoreSub(ctx context.Context, id uuid.UUID) (*domain.Sub, error) {
+	const op = "SubService.RestoreSub"
+
+	sub, err := s.subRepo.RestoreSub(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sub, nil
+}
+
+func (s *SubService) AddPriceChange(ctx context.Context, change *domain.PriceChange) (*domain.Sub, error) {
+	const op = "SubService.AddPriceChange"
+
+	sub, err := s.subRepo.AddPriceChange(ctx, change)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sub, nil
+}
+
+func (s *SubService) ListPriceChanges(ctx context.Context, subID uuid.UUID) ([]*domain.PriceChange, error) {
+	const op = "SubService.ListPriceChanges"
+
+	changes, err := s.subRepo.ListPriceChanges(ctx, subID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return changes, nil
+}
+
+func (s *SubService) PauseSub(ctx context.Context, pause *domain.Pause) (*domain.Pause, error) {
+	const op = "SubService.PauseSub"
+
+	pause, err := s.subRepo.PauseSub(ctx, pause)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pause, nil
+}
+
+// ResumeSub resumes billing starting from the current month.
+func (s *SubService) ResumeSub(ctx context.Context, id uuid.UUID) (*domain.Sub, error) {
+	const op = "SubService.ResumeSub"
+
+	sub, err := s.subRepo.ResumeSub(ctx, id, domain.StartOfMonth(time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sub, nil
+}
+
+func (s *SubService) ListPauses(ctx context.Context, subID uuid.UUID) ([]*domain.Pause, error) {
+	const op = "SubService.ListPauses"
+
+	pauses, err := s.subRepo.ListPauses(ctx, subID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pauses, nil
+}
+
 func (s *SubService) ListSubs(ctx context.Context, opts domain.FilterOpts) ([]*domain.Sub, error) {
 	const op = "SubService.ListSubs"
 
@@ -85,7 +157,191 @@ func (s *SubService) GetSummary(ctx context.Context, opts domain.FilterOpts) (*d
 	}
 
 	sum.ServiceName = opts.ServiceName
+	sum.Category = opts.Category
+	sum.Tags = opts.Tags
+	sum.GroupBy = opts.GroupBy
 	sum.UserID = opts.UserID
 
+	if !opts.From.IsZero() {
+		sum.From = opts.From.Format(domain.TimeLayout)
+		sum.To = opts.To.Format(domain.TimeLayout)
+	}
+
 	return sum, nil
 }
+
+// GetForecast starts from the next month, since the current one is already charged.
+// Months without charges are present in the forecast with zero total.
+func (s *SubService) GetForecast(ctx context.Context, userID uuid.UUID, months int) (*domain.Forecast, error) {
+	const op = "SubService.GetForecast"
+
+	from := domain.StartOfMonth(time.Now()).AddDate(0, 1, 0)
+
+	spends, err := s.subRepo.GetForecast(ctx, userID, from, months)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	forecast := domain.Forecast{
+		UserID: userID,
+		Months: make([]*domain.MonthForecast, 0, months),
+	}
+
+	for i := range months {
+		month := from.AddDate(0, i, 0)
+		monthForecast := &domain.MonthForecast{
+			Month:    month.Format(domain.TimeLayout),
+			Services: []*domain.ServiceSpend{},
+		}
+
+		// Spends are ordered by month.
+		for len(spends) != 0 && spends[0].Month.Equal(month) {
+			monthForecast.Total += spends[0].Amount
+			monthForecast.Services = append(monthForecast.Services, spends[0])
+			spends = spends[1:]
+		}
+
+		forecast.Total += monthForecast.Total
+		forecast.Months = append(forecast.Months, monthForecast)
+	}
+
+	return &forecast, nil
+}
+
+func (s *SubService) ListDuplicates(ctx context.Context, userID uuid.UUID) (*domain.DuplicatesReport, error) {
+	const op = "SubService.ListDuplicates"
+
+	duplicates, err := s.subRepo.ListDuplicates(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	report := domain.DuplicatesReport{
+		UserID:     userID,
+		Duplicates: duplicates,
+	}
+
+	for _, duplicate := range duplicates {
+		report.WastedSpend += duplicate.WastedSpend
+	}
+
+	return &report, nil
+}
+
+func (s *SubService) ListTags(ctx context.Context, userID uuid.UUID) ([]*domain.Tag, error) {
+	const op = "SubService.ListTags"
+
+	tags, err := s.subRepo.ListTags(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tags, nil
+}
+
+func (s *SubService) RenameTag(ctx context.Context, userID uuid.UUID, from, to string) (*domain.Tag, error) {
+	const op = "SubService.RenameTag"
+
+	tag, err := s.subRepo.RenameTag(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag, nil
+}
+
+func (s *SubService) MergeTags(ctx context.Context, userID uuid.UUID, sources []string, target string) (*domain.Tag, error) {
+	const op = "SubService.MergeTags"
+
+	tag, err := s.subRepo.MergeTags(ctx, userID, sources, target)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag, nil
+}
+
+func (s *SubService) PutMember(ctx context.Context, member *domain.Member) (*domain.Member, error) {
+	const op = "SubService.PutMember"
+
+	member, err := s.subRepo.PutMember(ctx, member)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return member, nil
+}
+
+func (s *SubService) DeleteMember(ctx context.Context, subID, userID uuid.UUID) error {
+	const op = "SubService.DeleteMember"
+
+	if err := s.subRepo.DeleteMember(ctx, subID, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *SubService) ListMembers(ctx context.Context, subID uuid.UUID) ([]*domain.Member, error) {
+	const op = "SubService.ListMembers"
+
+	members, err := s.subRepo.ListMembers(ctx, subID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return members, nil
+}
+
+// GetSettlement nets debts of each pair of users within a month, so that only one of them owes the other.
+// Months without debts are present in the settlement with no debts.
+func (s *SubService) GetSettlement(ctx context.Context, userID uuid.UUID, from, to time.Time) (*domain.Settlement, error) {
+	const op = "SubService.GetSettlement"
+
+	debts, err := s.subRepo.GetSettlement(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	settlement := domain.Settlement{
+		UserID: userID,
+		From:   from.Format(domain.TimeLayout),
+		To:     to.Format(domain.TimeLayout),
+		Months: []*domain.MonthSettlement{},
+	}
+
+	for month := from; !month.After(to); month = month.AddDate(0, 1, 0) {
+		monthSettlement := &domain.MonthSettlement{
+			Month: month.Format(domain.TimeLayout),
+			Debts: []*domain.Debt{},
+		}
+
+		// Debts are ordered by month, the debt of a pair is kept in the direction it is met first.
+		net := make(map[[2]uuid.UUID]*domain.Debt)
+
+		for len(debts) != 0 && debts[0].Month.Equal(month) {
+			debt := debts[0]
+			debts = debts[1:]
+
+			if pairDebt, ok := net[[2]uuid.UUID{debt.Creditor, debt.Debtor}]; ok {
+				pairDebt.Amount -= debt.Amount
+				continue
+			}
+
+			net[[2]uuid.UUID{debt.Debtor, debt.Creditor}] = debt
+			monthSettlement.Debts = append(monthSettlement.Debts, debt)
+		}
+
+		monthSettlement.Debts = slices.DeleteFunc(monthSettlement.Debts, func(debt *domain.Debt) bool {
+			if debt.Amount < 0 {
+				debt.Debtor, debt.Creditor, debt.Amount = debt.Creditor, debt.Debtor, -debt.Amount
+			}
+
+			return debt.Amount == 0
+		})
+
+		settlement.Months = append(settlement.Months, monthSettlement)
+	}
+
+	return &settlement, nil
+}
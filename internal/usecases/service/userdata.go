@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type UserDataService struct {
+	userDataRepo repository.UserDataRepo
+	// invalidate removes cached results, which the erased data of the user affect.
+	invalidate func(ctx context.Context, userID uuid.UUID)
+}
+
+func NewUserDataService(
+	userDataRepo repository.UserDataRepo,
+	invalidate func(ctx context.Context, userID uuid.UUID),
+) *UserDataService {
+	return &UserDataService{
+		userDataRepo: userDataRepo,
+		invalidate:   invalidate,
+	}
+}
+
+func (s *UserDataService) RequestExport(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error) {
+	const op = "UserDataService.RequestExport"
+
+	export, err := s.userDataRepo.PostExport(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return export, nil
+}
+
+func (s *UserDataService) GetExport(ctx context.Context, userID, id uuid.UUID) (*domain.DataExport, error) {
+	const op = "UserDataService.GetExport"
+
+	export, err := s.userDataRepo.GetExport(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return export, nil
+}
+
+func (s *UserDataService) GetExportArchive(ctx context.Context, userID uuid.UUID) (*domain.DataExport, []byte, error) {
+	const op = "UserDataService.GetExportArchive"
+
+	export, archive, err := s.userDataRepo.GetExportArchive(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return export, archive, nil
+}
+
+func (s *UserDataService) EraseUserData(ctx context.Context, userID uuid.UUID) error {
+	const op = "UserDataService.EraseUserData"
+
+	if err := s.userDataRepo.EraseUserData(ctx, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(ctx, userID)
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type WebhookService struct {
+	webhooksRepo repository.WebhooksRepo
+}
+
+func NewWebhookService(webhooksRepo repository.WebhooksRepo) *WebhookService {
+	return &WebhookService{
+		webhooksRepo: webhooksRepo,
+	}
+}
+
+func (s *WebhookService) PostWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	const op = "WebhookService.PostWebhook"
+
+	created, err := s.webhooksRepo.PostWebhook(ctx, webhook)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+func (s *WebhookService) GetWebhook(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	const op = "WebhookService.GetWebhook"
+
+	webhook, err := s.webhooksRepo.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]*domain.Webhook, error) {
+	const op = "WebhookService.ListWebhooks"
+
+	webhooks, err := s.webhooksRepo.ListWebhooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhooks, nil
+}
+
+func (s *WebhookService) PutWebhook(ctx context.Context, id uuid.UUID, webhook *domain.Webhook) (*domain.Webhook, error) {
+	const op = "WebhookService.PutWebhook"
+
+	updated, err := s.webhooksRepo.PutWebhook(ctx, id, webhook)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	const op = "WebhookService.DeleteWebhook"
+
+	if err := s.webhooksRepo.DeleteWebhook(ctx, id); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *WebhookService) ListDeliveries(
+	ctx context.Context, opts domain.DeliveryFilterOpts,
+) ([]*domain.WebhookDelivery, error) {
+	const op = "WebhookService.ListDeliveries"
+
+	deliveries, err := s.webhooksRepo.ListDeliveries(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+func (s *WebhookService) RedeliverDelivery(
+	ctx context.Context, webhookID uuid.UUID, id int64,
+) (*domain.WebhookDelivery, error) {
+	const op = "WebhookService.RedeliverDelivery"
+
+	delivery, err := s.webhooksRepo.RedeliverDelivery(ctx, webhookID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return delivery, nil
+}
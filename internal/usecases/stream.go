@@ -0,0 +1,13 @@
+package usecases
+
+import (
+	"subs-service/pkg/pubsub"
+
+	"github.com/google/uuid"
+)
+
+type StreamService interface {
+	// SubscribeSubEvents subscribes to changes of subscriptions of the tenant's user after lastEventID (if it is positive).
+	// Complete is false if some of the missed events are no longer available.
+	SubscribeSubEvents(tenant string, userID uuid.UUID, lastEventID int64) (sub *pubsub.Subscription, replay []pubsub.Event, complete bool)
+}
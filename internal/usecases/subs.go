@@ -3,15 +3,36 @@ package usecases
 import (
 	"context"
 	"subs-service/internal/domain"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type SubService interface {
-	GetSub(ctx context.Context, id uuid.UUID) (*domain.Sub, error)
+	GetSub(ctx context.Context, id uuid.UUID, opts domain.GetOpts) (*domain.Sub, error)
 	PostSub(ctx context.Context, sub *domain.Sub) (*domain.Sub, error)
-	PutSub(ctx context.Context, id uuid.UUID, sub *domain.Sub) (*domain.Sub, error)
+	PutSub(ctx context.Context, id uuid.UUID, sub *domain.Sub, opts domain.PutOpts) (*domain.Sub, error)
 	DeleteSub(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	RestoreSub(ctx context.Context, id uuid.UUID) (*domain.Sub, error)
+	AddPriceChange(ctx context.Context, change *domain.PriceChange) (*domain.Sub, error)
+	ListPriceChanges(ctx context.Context, subID uuid.UUID) ([]*domain.PriceChange, error)
+	PauseSub(ctx context.Context, pause *domain.Pause) (*domain.Pause, error)
+	ResumeSub(ctx context.Context, id uuid.UUID) (*domain.Sub, error)
+	ListPauses(ctx context.Context, subID uuid.UUID) ([]*domain.Pause, error)
 	ListSubs(ctx context.Context, opts domain.FilterOpts) ([]*domain.Sub, error)
 	GetSummary(ctx context.Context, opts domain.FilterOpts) (*domain.Summary, error)
+	// GetForecast projects user's spend for each of the next months starting from the next one.
+	GetForecast(ctx context.Context, userID uuid.UUID, months int) (*domain.Forecast, error)
+	// ListDuplicates reports user's subscriptions to the same service with overlapping periods.
+	ListDuplicates(ctx context.Context, userID uuid.UUID) (*domain.DuplicatesReport, error)
+	ListTags(ctx context.Context, userID uuid.UUID) ([]*domain.Tag, error)
+	// RenameTag and MergeTags change tags across all subscriptions of the user.
+	RenameTag(ctx context.Context, userID uuid.UUID, from, to string) (*domain.Tag, error)
+	MergeTags(ctx context.Context, userID uuid.UUID, sources []string, target string) (*domain.Tag, error)
+	// PutMember shares subscription with the user or changes their share.
+	PutMember(ctx context.Context, member *domain.Member) (*domain.Member, error)
+	DeleteMember(ctx context.Context, subID, userID uuid.UUID) error
+	ListMembers(ctx context.Context, subID uuid.UUID) ([]*domain.Member, error)
+	// GetSettlement reports who owes whom for shared subscriptions for each month of the period.
+	GetSettlement(ctx context.Context, userID uuid.UUID, from, to time.Time) (*domain.Settlement, error)
 }
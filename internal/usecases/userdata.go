@@ -0,0 +1,18 @@
+package usecases
+
+import (
+	"context"
+	"subs-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type UserDataService interface {
+	// RequestExport starts an export of all user's data, the archive is built in the background.
+	RequestExport(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error)
+	GetExport(ctx context.Context, userID, id uuid.UUID) (*domain.DataExport, error)
+	// GetExportArchive returns ZIP archive of the latest completed export of the user.
+	GetExportArchive(ctx context.Context, userID uuid.UUID) (*domain.DataExport, []byte, error)
+	// EraseUserData deletes or anonymizes all user's data.
+	EraseUserData(ctx context.Context, userID uuid.UUID) error
+}
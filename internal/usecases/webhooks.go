@@ -0,0 +1,19 @@
+package usecases
+
+import (
+	"context"
+	"subs-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type WebhookService interface {
+	PostWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	GetWebhook(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]*domain.Webhook, error)
+	PutWebhook(ctx context.Context, id uuid.UUID, webhook *domain.Webhook) (*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+
+	ListDeliveries(ctx context.Context, opts domain.DeliveryFilterOpts) ([]*domain.WebhookDelivery, error)
+	RedeliverDelivery(ctx context.Context, webhookID uuid.UUID, id int64) (*domain.WebhookDelivery, error)
+}
@@ -1,14 +1,284 @@
 CREATE EXTENSION IF NOT EXISTS pgcrypto;
 
+-- Catalog of services, subscriptions are linked to it by their names (canonical names are aliases too)
+CREATE TABLE services (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    name            varchar(100) NOT NULL,
+    category        varchar(50) NOT NULL DEFAULT '',
+    currency        char(3) NOT NULL DEFAULT 'RUB',
+    website         text NOT NULL DEFAULT '',
+    created_at      timestamptz NOT NULL DEFAULT now()
+);
+
+-- Aliases are stored in lower case without surrounding spaces
+CREATE TABLE service_aliases (
+    alias           varchar(100) PRIMARY KEY,
+    service_id      uuid NOT NULL REFERENCES services (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_service_aliases_service ON service_aliases (service_id);
+
+-- Data of partner apps (tenants) are isolated by row-level security policies, see the end of the file.
+-- The catalog is shared by all tenants.
 CREATE TABLE subs (
     id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id       varchar(64) NOT NULL,
     user_id         uuid NOT NULL,
 
     service_name    varchar(100) NOT NULL,
-    price           int8 CHECK (price BETWEEN 1 AND 100000),
+    service_id      uuid REFERENCES services (id) ON DELETE SET NULL,
+    price           int8 CHECK (price > 0),
     start_date      date NOT NULL,
-    end_date        date
+    end_date        date,
+    trial_months    int NOT NULL DEFAULT 0 CHECK (trial_months >= 0),
+    promo_price     int8 CHECK (promo_price > 0),
+    category        varchar(50) NOT NULL DEFAULT '',
+
+    deleted_at      timestamptz
+);
+
+CREATE INDEX idx_id_pagination ON subs (tenant_id, user_id, id);
+CREATE INDEX idx_svc_name_filter ON subs (tenant_id, user_id, service_name);
+CREATE INDEX idx_svc_id_filter ON subs (tenant_id, user_id, service_id);
+CREATE INDEX idx_deleted_at ON subs (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_category_filter ON subs (tenant_id, user_id, category);
+
+-- User-defined tags, subscriptions share tags of their user
+CREATE TABLE tags (
+    id              bigserial PRIMARY KEY,
+    tenant_id       varchar(64) NOT NULL,
+    user_id         uuid NOT NULL,
+    name            varchar(50) NOT NULL,
+
+    UNIQUE (tenant_id, user_id, name)
+);
+
+CREATE TABLE sub_tags (
+    tenant_id       varchar(64) NOT NULL,
+    sub_id          uuid NOT NULL REFERENCES subs (id) ON DELETE CASCADE,
+    tag_id          bigint NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+
+    PRIMARY KEY (sub_id, tag_id)
+);
+
+CREATE INDEX idx_sub_tags_tag ON sub_tags (tag_id);
+
+CREATE TABLE sub_prices (
+    tenant_id       varchar(64) NOT NULL,
+    sub_id          uuid NOT NULL REFERENCES subs (id) ON DELETE CASCADE,
+    effective_from  date NOT NULL,
+    price           int8 NOT NULL CHECK (price > 0),
+
+    PRIMARY KEY (sub_id, effective_from)
+);
+
+CREATE TABLE sub_pauses (
+    id              bigserial PRIMARY KEY,
+    tenant_id       varchar(64) NOT NULL,
+    sub_id          uuid NOT NULL REFERENCES subs (id) ON DELETE CASCADE,
+    from_month      date NOT NULL,
+    to_month        date CHECK (to_month >= from_month)
+);
+
+CREATE INDEX idx_pauses_sub ON sub_pauses (sub_id, from_month);
+
+-- Members of shared subscriptions pay either fixed amounts or shares by weights, the owner pays the rest
+CREATE TABLE sub_members (
+    tenant_id       varchar(64) NOT NULL,
+    sub_id          uuid NOT NULL REFERENCES subs (id) ON DELETE CASCADE,
+    user_id         uuid NOT NULL,
+    weight          int CHECK (weight > 0),
+    amount          int8 CHECK (amount > 0),
+    created_at      timestamptz NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (sub_id, user_id),
+    CHECK ((weight IS NULL) <> (amount IS NULL))
+);
+
+CREATE INDEX idx_sub_members_user ON sub_members (tenant_id, user_id);
+
+-- Buckets of the rate limiter are shared by all tenants, as they are keyed by clients,
+-- which are limited on routes outside of tenants as well.
+CREATE TABLE rate_limit_buckets (
+    key             text PRIMARY KEY,
+    tokens          float8 NOT NULL,
+    updated_at      timestamptz NOT NULL DEFAULT now(),
+    expires_at      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_rate_limit_expiration ON rate_limit_buckets (expires_at);
+
+-- Entries of erased users are anonymized, the erasure itself is recorded without subscription
+CREATE TABLE subs_audit (
+    id              bigserial PRIMARY KEY,
+    tenant_id       varchar(64) NOT NULL,
+    sub_id          uuid,
+    user_id         uuid NOT NULL,
+
+    actor           text NOT NULL,
+    request_id      text NOT NULL DEFAULT '',
+    operation       text NOT NULL CHECK (operation IN ('create', 'update', 'delete', 'restore', 'purge', 'pause', 'resume', 'erase')),
+    before          jsonb,
+    after           jsonb,
+    created_at      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_audit_sub ON subs_audit (sub_id, id);
+CREATE INDEX idx_audit_created_at ON subs_audit (created_at);
+CREATE INDEX idx_audit_user ON subs_audit (tenant_id, user_id, id);
+
+CREATE TABLE sent_notifications (
+    key             text PRIMARY KEY,
+    tenant_id       varchar(64) NOT NULL,
+    user_id         uuid NOT NULL,
+    sub_id          uuid NOT NULL,
+    sent_at         timestamptz NOT NULL DEFAULT now()
 );
 
-CREATE INDEX idx_id_pagination ON subs (user_id, id);
-CREATE INDEX idx_svc_name_filter ON subs (user_id, service_name);
+CREATE INDEX idx_sent_notifications_user ON sent_notifications (tenant_id, user_id);
+
+CREATE TABLE webhooks (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id       varchar(64) NOT NULL,
+    url             text NOT NULL,
+    secret          text NOT NULL,
+    events          text[] NOT NULL DEFAULT '{}',
+    created_at      timestamptz NOT NULL DEFAULT now()
+);
+
+-- Transactional outbox: events are written along with subscriptions' changes
+CREATE TABLE webhook_events (
+    id              bigserial PRIMARY KEY,
+    tenant_id       varchar(64) NOT NULL,
+    event           text NOT NULL,
+    sub_id          uuid NOT NULL,
+    user_id         uuid NOT NULL,
+    payload         jsonb NOT NULL,
+    created_at      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_webhook_events_created_at ON webhook_events (created_at);
+
+CREATE TABLE webhook_deliveries (
+    id              bigserial PRIMARY KEY,
+    tenant_id       varchar(64) NOT NULL,
+    webhook_id      uuid NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id        bigint NOT NULL REFERENCES webhook_events (id) ON DELETE CASCADE,
+
+    status          text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts        int NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    last_error      text NOT NULL DEFAULT '',
+    delivered_at    timestamptz,
+
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_event ON webhook_deliveries (event_id);
+
+-- Secret tokens of users' calendar feeds, only hashes of tokens are stored
+CREATE TABLE calendar_tokens (
+    tenant_id       varchar(64) NOT NULL,
+    user_id         uuid NOT NULL,
+    token_hash      bytea NOT NULL,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+-- Monthly budgets: overall (service_name and category are NULL), per service or per category,
+-- thresholds are percents of amount
+CREATE TABLE budgets (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id       varchar(64) NOT NULL,
+    user_id         uuid NOT NULL,
+    service_name    varchar(100),
+    category        varchar(50),
+    amount          int8 NOT NULL CHECK (amount > 0),
+    thresholds      int[] NOT NULL,
+    created_at      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_budgets_user ON budgets (tenant_id, user_id, created_at);
+
+-- Alerts are recorded once a month for every reached threshold of budget
+CREATE TABLE budget_alerts (
+    id              bigserial PRIMARY KEY,
+    tenant_id       varchar(64) NOT NULL,
+    budget_id       uuid NOT NULL REFERENCES budgets (id) ON DELETE CASCADE,
+    month           date NOT NULL,
+    threshold       int NOT NULL,
+    amount          int8 NOT NULL,
+    spend           int8 NOT NULL,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+
+    UNIQUE (budget_id, month, threshold)
+);
+
+-- Background jobs of long-running operations, handlers of types are registered by the service.
+-- Failed jobs are retried with backoff, running jobs are reclaimed once their lease is over
+CREATE TABLE jobs (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id       varchar(64) NOT NULL,
+    type            text NOT NULL,
+    payload         jsonb NOT NULL DEFAULT '{}',
+
+    status          text NOT NULL DEFAULT 'pending'
+                        CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled')),
+    progress        int NOT NULL DEFAULT 0 CHECK (progress BETWEEN 0 AND 100),
+    result          jsonb,
+    error           text NOT NULL DEFAULT '',
+    attempts        int NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    lease_until     timestamptz,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    updated_at      timestamptz NOT NULL DEFAULT now(),
+    completed_at    timestamptz
+);
+
+CREATE INDEX idx_jobs_due ON jobs (next_attempt_at) WHERE status IN ('pending', 'running');
+CREATE INDEX idx_jobs_completed_at ON jobs (completed_at) WHERE completed_at IS NOT NULL;
+
+-- Exports of all user's data, archives are built by jobs and are deleted along with them
+CREATE TABLE data_exports (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id       varchar(64) NOT NULL,
+    user_id         uuid NOT NULL,
+    job_id          uuid NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    archive         bytea,
+    created_at      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_data_exports_user ON data_exports (tenant_id, user_id, created_at);
+CREATE INDEX idx_data_exports_job ON data_exports (job_id);
+
+-- Requests of the API switch to the role within their transactions along with setting of app.tenant_id,
+-- so that policies restrict them to rows of their tenant. Background workers run as the owner of tables
+-- and are not restricted.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'subs_tenant') THEN
+        CREATE ROLE subs_tenant NOLOGIN;
+    END IF;
+END
+$$;
+
+GRANT subs_tenant TO CURRENT_USER;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO subs_tenant;
+GRANT USAGE ON ALL SEQUENCES IN SCHEMA public TO subs_tenant;
+
+DO $$
+DECLARE
+    t text;
+BEGIN
+    FOR t IN SELECT table_name FROM information_schema.columns
+        WHERE table_schema = 'public' AND column_name = 'tenant_id'
+    LOOP
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format(
+            'CREATE POLICY tenant_isolation ON %I TO subs_tenant USING (tenant_id = current_setting(''app.tenant_id'', true))', t
+        );
+    END LOOP;
+END
+$$;
@@ -0,0 +1,850 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: subs/v1/subs.proto
+
+package subsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Sub struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId      string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServiceName string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Price       int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	StartDate   string                 `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// Empty for subscriptions without end.
+	EndDate string `protobuf:"bytes,6,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// First trial_months months are charged by promo_price, which is zero for free trials.
+	TrialMonths int32 `protobuf:"varint,7,opt,name=trial_months,json=trialMonths,proto3" json:"trial_months,omitempty"`
+	PromoPrice  int64 `protobuf:"varint,8,opt,name=promo_price,json=promoPrice,proto3" json:"promo_price,omitempty"`
+	// Output only.
+	TrialEnd string `protobuf:"bytes,9,opt,name=trial_end,json=trialEnd,proto3" json:"trial_end,omitempty"`
+	// Output only, RFC 3339.
+	DeletedAt string `protobuf:"bytes,10,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Output only: active, paused, ended or scheduled.
+	Status string `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
+	// Defaults to the category of the catalog service.
+	Category      string   `protobuf:"bytes,12,opt,name=category,proto3" json:"category,omitempty"`
+	Tags          []string `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sub) Reset() {
+	*x = Sub{}
+	mi := &file_subs_v1_subs_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sub) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sub) ProtoMessage() {}
+
+func (x *Sub) ProtoReflect() protoreflect.Message {
+	mi := &file_subs_v1_subs_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sub.ProtoReflect.Descriptor instead.
+func (*Sub) Descriptor() ([]byte, []int) {
+	return file_subs_v1_subs_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Sub) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Sub) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Sub) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *Sub) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Sub) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *Sub) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *Sub) GetTrialMonths() int32 {
+	if x != nil {
+		return x.TrialMonths
+	}
+	return 0
+}
+
+func (x *Sub) GetPromoPrice() int64 {
+	if x != nil {
+		return x.PromoPrice
+	}
+	return 0
+}
+
+func (x *Sub) GetTrialEnd() string {
+	if x != nil {
+		return x.TrialEnd
+	}
+	return ""
+}
+
+func (x *Sub) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *Sub) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Sub) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Sub) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type GetSubRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Admins only.
+	IncludeDeleted bool `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetSubRequest) Reset() {
+	*x = GetSubRequest{}
+	mi := &file_subs_v1_subs_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubRequest) ProtoMessage() {}
+
+func (x *GetSubRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subs_v1_subs_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubRequest.ProtoReflect.Descriptor instead.
+func (*GetSubRequest) Descriptor() ([]byte, []int) {
+	return file_subs_v1_subs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetSubRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetSubRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type PostSubRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sub           *Sub                   `protobuf:"bytes,1,opt,name=sub,proto3" json:"sub,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostSubRequest) Reset() {
+	*x = PostSubRequest{}
+	mi := &file_subs_v1_subs_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostSubRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostSubRequest) ProtoMessage() {}
+
+func (x *PostSubRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subs_v1_subs_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostSubRequest.ProtoReflect.Descriptor instead.
+func (*PostSubRequest) Descriptor() ([]byte, []int) {
+	return file_subs_v1_subs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PostSubRequest) GetSub() *Sub {
+	if x != nil {
+		return x.Sub
+	}
+	return nil
+}
+
+type PutSubRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sub   *Sub                   `protobuf:"bytes,2,opt,name=sub,proto3" json:"sub,omitempty"`
+	// If set, the new price is recorded as a price change starting from that month,
+	// otherwise the price is overwritten for the whole subscription's period.
+	PriceEffectiveFrom string `protobuf:"bytes,3,opt,name=price_effective_from,json=priceEffectiveFrom,proto3" json:"price_effective_from,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PutSubRequest) Reset() {
+	*x = PutSubRequest{}
+	mi := &file_subs_v1_subs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutSubRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutSubRequest) ProtoMessage() {}
+
+func (x *PutSubRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subs_v1_subs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutSubRequest.ProtoReflect.Descriptor instead.
+func (*PutSubRequest) Descriptor() ([]byte, []int) {
+	return file_subs_v1_subs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PutSubRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PutSubRequest) GetSub() *Sub {
+	if x != nil {
+		return x.Sub
+	}
+	return nil
+}
+
+func (x *PutSubRequest) GetPriceEffectiveFrom() string {
+	if x != nil {
+		return x.PriceEffectiveFrom
+	}
+	return ""
+}
+
+type DeleteSubRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSubRequest) Reset() {
+	*x = DeleteSubRequest{}
+	mi := &file_subs_v1_subs_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSubRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSubRequest) ProtoMessage() {}
+
+func (x *DeleteSubRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subs_v1_subs_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSubRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSubRequest) Descriptor() ([]byte, []int) {
+	return file_subs_v1_subs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteSubRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteSubResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSubResponse) Reset() {
+	*x = DeleteSubResponse{}
+	mi := &file_subs_v1_subs_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSubResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSubResponse) ProtoMessage() {}
+
+func (x *DeleteSubResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subs_v1_subs_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSubResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSubResponse) Descriptor() ([]byte, []int) {
+	return file_subs_v1_subs_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteSubResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListSubsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UserId      string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServiceName string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	PageSize    int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Id of the last subscription of the previous page.
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Only subscriptions, which trial ends within that many days.
+	TrialEndsWithin int32 `protobuf:"varint,5,opt,name=trial_ends_within,json=trialEndsWithin,proto3" json:"trial_ends_within,omitempty"`
+	// Admins only.
+	IncludeDeleted bool `protobuf:"varint,6,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListSubsRequest) Reset() {
+	*x = ListSubsRequest{}
+	mi := &file_subs_v1_subs_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubsRequest) ProtoMessage() {}
+
+func (x *ListSubsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subs_v1_subs_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubsRequest) Descriptor() ([]byte, []int) {
+	return file_subs_v1_subs_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListSubsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListSubsRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ListSubsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSubsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListSubsRequest) GetTrialEndsWithin() int32 {
+	if x != nil {
+		return x.TrialEndsWithin
+	}
+	return 0
+}
+
+func (x *ListSubsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type ListSubsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Subs  []*Sub                 `protobuf:"bytes,1,rep,name=subs,proto3" json:"subs,omitempty"`
+	// Empty if there are no more pages.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubsResponse) Reset() {
+	*x = ListSubsResponse{}
+	mi := &file_subs_v1_subs_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubsResponse) ProtoMessage() {}
+
+func (x *ListSubsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subs_v1_subs_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubsResponse.ProtoReflect.Descriptor instead.
+func (*ListSubsResponse) Descriptor() ([]byte, []int) {
+	return file_subs_v1_subs_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListSubsResponse) GetSubs() []*Sub {
+	if x != nil {
+		return x.Subs
+	}
+	return nil
+}
+
+func (x *ListSubsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetSummaryRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UserId      string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServiceName string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Optional period of summary (both inclusive), both bounds must be set together.
+	From string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	// Admins only.
+	IncludeDeleted bool `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetSummaryRequest) Reset() {
+	*x = GetSummaryRequest{}
+	mi := &file_subs_v1_subs_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSummaryRequest) ProtoMessage() {}
+
+func (x *GetSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subs_v1_subs_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_subs_v1_subs_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetSummaryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetSummaryRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *GetSummaryRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetSummaryRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GetSummaryRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type Summary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	TotalPrice    int64                  `protobuf:"varint,5,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Summary) Reset() {
+	*x = Summary{}
+	mi := &file_subs_v1_subs_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Summary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Summary) ProtoMessage() {}
+
+func (x *Summary) ProtoReflect() protoreflect.Message {
+	mi := &file_subs_v1_subs_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Summary.ProtoReflect.Descriptor instead.
+func (*Summary) Descriptor() ([]byte, []int) {
+	return file_subs_v1_subs_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Summary) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Summary) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *Summary) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Summary) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Summary) GetTotalPrice() int64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+var File_subs_v1_subs_proto protoreflect.FileDescriptor
+
+const file_subs_v1_subs_proto_rawDesc = "" +
+	"\n" +
+	"\x12subs/v1/subs.proto\x12\asubs.v1\"\xe9\x02\n" +
+	"\x03Sub\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x03R\x05price\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x06 \x01(\tR\aendDate\x12!\n" +
+	"\ftrial_months\x18\a \x01(\x05R\vtrialMonths\x12\x1f\n" +
+	"\vpromo_price\x18\b \x01(\x03R\n" +
+	"promoPrice\x12\x1b\n" +
+	"\ttrial_end\x18\t \x01(\tR\btrialEnd\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\n" +
+	" \x01(\tR\tdeletedAt\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\x12\x1a\n" +
+	"\bcategory\x18\f \x01(\tR\bcategory\x12\x12\n" +
+	"\x04tags\x18\r \x03(\tR\x04tags\"H\n" +
+	"\rGetSubRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\"0\n" +
+	"\x0ePostSubRequest\x12\x1e\n" +
+	"\x03sub\x18\x01 \x01(\v2\f.subs.v1.SubR\x03sub\"q\n" +
+	"\rPutSubRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\x03sub\x18\x02 \x01(\v2\f.subs.v1.SubR\x03sub\x120\n" +
+	"\x14price_effective_from\x18\x03 \x01(\tR\x12priceEffectiveFrom\"\"\n" +
+	"\x10DeleteSubRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"#\n" +
+	"\x11DeleteSubResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xde\x01\n" +
+	"\x0fListSubsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12*\n" +
+	"\x11trial_ends_within\x18\x05 \x01(\x05R\x0ftrialEndsWithin\x12'\n" +
+	"\x0finclude_deleted\x18\x06 \x01(\bR\x0eincludeDeleted\"\\\n" +
+	"\x10ListSubsResponse\x12 \n" +
+	"\x04subs\x18\x01 \x03(\v2\f.subs.v1.SubR\x04subs\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x9c\x01\n" +
+	"\x11GetSummaryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\x12'\n" +
+	"\x0finclude_deleted\x18\x05 \x01(\bR\x0eincludeDeleted\"\x8a\x01\n" +
+	"\aSummary\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\x12\x1f\n" +
+	"\vtotal_price\x18\x05 \x01(\x03R\n" +
+	"totalPrice2\xe0\x02\n" +
+	"\vSubsService\x12.\n" +
+	"\x06GetSub\x12\x16.subs.v1.GetSubRequest\x1a\f.subs.v1.Sub\x120\n" +
+	"\aPostSub\x12\x17.subs.v1.PostSubRequest\x1a\f.subs.v1.Sub\x12.\n" +
+	"\x06PutSub\x12\x16.subs.v1.PutSubRequest\x1a\f.subs.v1.Sub\x12B\n" +
+	"\tDeleteSub\x12\x19.subs.v1.DeleteSubRequest\x1a\x1a.subs.v1.DeleteSubResponse\x12?\n" +
+	"\bListSubs\x12\x18.subs.v1.ListSubsRequest\x1a\x19.subs.v1.ListSubsResponse\x12:\n" +
+	"\n" +
+	"GetSummary\x12\x1a.subs.v1.GetSummaryRequest\x1a\x10.subs.v1.SummaryB%Z#subs-service/pkg/api/subs/v1;subsv1b\x06proto3"
+
+var (
+	file_subs_v1_subs_proto_rawDescOnce sync.Once
+	file_subs_v1_subs_proto_rawDescData []byte
+)
+
+func file_subs_v1_subs_proto_rawDescGZIP() []byte {
+	file_subs_v1_subs_proto_rawDescOnce.Do(func() {
+		file_subs_v1_subs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_subs_v1_subs_proto_rawDesc), len(file_subs_v1_subs_proto_rawDesc)))
+	})
+	return file_subs_v1_subs_proto_rawDescData
+}
+
+var file_subs_v1_subs_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_subs_v1_subs_proto_goTypes = []any{
+	(*Sub)(nil),               // 0: subs.v1.Sub
+	(*GetSubRequest)(nil),     // 1: subs.v1.GetSubRequest
+	(*PostSubRequest)(nil),    // 2: subs.v1.PostSubRequest
+	(*PutSubRequest)(nil),     // 3: subs.v1.PutSubRequest
+	(*DeleteSubRequest)(nil),  // 4: subs.v1.DeleteSubRequest
+	(*DeleteSubResponse)(nil), // 5: subs.v1.DeleteSubResponse
+	(*ListSubsRequest)(nil),   // 6: subs.v1.ListSubsRequest
+	(*ListSubsResponse)(nil),  // 7: subs.v1.ListSubsResponse
+	(*GetSummaryRequest)(nil), // 8: subs.v1.GetSummaryRequest
+	(*Summary)(nil),           // 9: subs.v1.Summary
+}
+var file_subs_v1_subs_proto_depIdxs = []int32{
+	0, // 0: subs.v1.PostSubRequest.sub:type_name -> subs.v1.Sub
+	0, // 1: subs.v1.PutSubRequest.sub:type_name -> subs.v1.Sub
+	0, // 2: subs.v1.ListSubsResponse.subs:type_name -> subs.v1.Sub
+	1, // 3: subs.v1.SubsService.GetSub:input_type -> subs.v1.GetSubRequest
+	2, // 4: subs.v1.SubsService.PostSub:input_type -> subs.v1.PostSubRequest
+	3, // 5: subs.v1.SubsService.PutSub:input_type -> subs.v1.PutSubRequest
+	4, // 6: subs.v1.SubsService.DeleteSub:input_type -> subs.v1.DeleteSubRequest
+	6, // 7: subs.v1.SubsService.ListSubs:input_type -> subs.v1.ListSubsRequest
+	8, // 8: subs.v1.SubsService.GetSummary:input_type -> subs.v1.GetSummaryRequest
+	0, // 9: subs.v1.SubsService.GetSub:output_type -> subs.v1.Sub
+	0, // 10: subs.v1.SubsService.PostSub:output_type -> subs.v1.Sub
+	0, // 11: subs.v1.SubsService.PutSub:output_type -> subs.v1.Sub
+	5, // 12: subs.v1.SubsService.DeleteSub:output_type -> subs.v1.DeleteSubResponse
+	7, // 13: subs.v1.SubsService.ListSubs:output_type -> subs.v1.ListSubsResponse
+	9, // 14: subs.v1.SubsService.GetSummary:output_type -> subs.v1.Summary
+	9, // [9:15] is the sub-list for method output_type
+	3, // [3:9] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_subs_v1_subs_proto_init() }
+func file_subs_v1_subs_proto_init() {
+	if File_subs_v1_subs_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_subs_v1_subs_proto_rawDesc), len(file_subs_v1_subs_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_subs_v1_subs_proto_goTypes,
+		DependencyIndexes: file_subs_v1_subs_proto_depIdxs,
+		MessageInfos:      file_subs_v1_subs_proto_msgTypes,
+	}.Build()
+	File_subs_v1_subs_proto = out.File
+	file_subs_v1_subs_proto_goTypes = nil
+	file_subs_v1_subs_proto_depIdxs = nil
+}
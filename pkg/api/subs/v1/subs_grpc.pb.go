@@ -0,0 +1,317 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: subs/v1/subs.proto
+
+package subsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SubsService_GetSub_FullMethodName     = "/subs.v1.SubsService/GetSub"
+	SubsService_PostSub_FullMethodName    = "/subs.v1.SubsService/PostSub"
+	SubsService_PutSub_FullMethodName     = "/subs.v1.SubsService/PutSub"
+	SubsService_DeleteSub_FullMethodName  = "/subs.v1.SubsService/DeleteSub"
+	SubsService_ListSubs_FullMethodName   = "/subs.v1.SubsService/ListSubs"
+	SubsService_GetSummary_FullMethodName = "/subs.v1.SubsService/GetSummary"
+)
+
+// SubsServiceClient is the client API for SubsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SubsService mirrors the REST API of subscriptions. Months are formatted as MM-YYYY,
+// end date of a subscription is exclusive.
+type SubsServiceClient interface {
+	GetSub(ctx context.Context, in *GetSubRequest, opts ...grpc.CallOption) (*Sub, error)
+	PostSub(ctx context.Context, in *PostSubRequest, opts ...grpc.CallOption) (*Sub, error)
+	PutSub(ctx context.Context, in *PutSubRequest, opts ...grpc.CallOption) (*Sub, error)
+	DeleteSub(ctx context.Context, in *DeleteSubRequest, opts ...grpc.CallOption) (*DeleteSubResponse, error)
+	ListSubs(ctx context.Context, in *ListSubsRequest, opts ...grpc.CallOption) (*ListSubsResponse, error)
+	GetSummary(ctx context.Context, in *GetSummaryRequest, opts ...grpc.CallOption) (*Summary, error)
+}
+
+type subsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSubsServiceClient(cc grpc.ClientConnInterface) SubsServiceClient {
+	return &subsServiceClient{cc}
+}
+
+func (c *subsServiceClient) GetSub(ctx context.Context, in *GetSubRequest, opts ...grpc.CallOption) (*Sub, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sub)
+	err := c.cc.Invoke(ctx, SubsService_GetSub_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subsServiceClient) PostSub(ctx context.Context, in *PostSubRequest, opts ...grpc.CallOption) (*Sub, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sub)
+	err := c.cc.Invoke(ctx, SubsService_PostSub_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subsServiceClient) PutSub(ctx context.Context, in *PutSubRequest, opts ...grpc.CallOption) (*Sub, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sub)
+	err := c.cc.Invoke(ctx, SubsService_PutSub_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subsServiceClient) DeleteSub(ctx context.Context, in *DeleteSubRequest, opts ...grpc.CallOption) (*DeleteSubResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSubResponse)
+	err := c.cc.Invoke(ctx, SubsService_DeleteSub_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subsServiceClient) ListSubs(ctx context.Context, in *ListSubsRequest, opts ...grpc.CallOption) (*ListSubsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubsResponse)
+	err := c.cc.Invoke(ctx, SubsService_ListSubs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subsServiceClient) GetSummary(ctx context.Context, in *GetSummaryRequest, opts ...grpc.CallOption) (*Summary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Summary)
+	err := c.cc.Invoke(ctx, SubsService_GetSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SubsServiceServer is the server API for SubsService service.
+// All implementations must embed UnimplementedSubsServiceServer
+// for forward compatibility.
+//
+// SubsService mirrors the REST API of subscriptions. Months are formatted as MM-YYYY,
+// end date of a subscription is exclusive.
+type SubsServiceServer interface {
+	GetSub(context.Context, *GetSubRequest) (*Sub, error)
+	PostSub(context.Context, *PostSubRequest) (*Sub, error)
+	PutSub(context.Context, *PutSubRequest) (*Sub, error)
+	DeleteSub(context.Context, *DeleteSubRequest) (*DeleteSubResponse, error)
+	ListSubs(context.Context, *ListSubsRequest) (*ListSubsResponse, error)
+	GetSummary(context.Context, *GetSummaryRequest) (*Summary, error)
+	mustEmbedUnimplementedSubsServiceServer()
+}
+
+// UnimplementedSubsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSubsServiceServer struct{}
+
+func (UnimplementedSubsServiceServer) GetSub(context.Context, *GetSubRequest) (*Sub, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSub not implemented")
+}
+func (UnimplementedSubsServiceServer) PostSub(context.Context, *PostSubRequest) (*Sub, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostSub not implemented")
+}
+func (UnimplementedSubsServiceServer) PutSub(context.Context, *PutSubRequest) (*Sub, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutSub not implemented")
+}
+func (UnimplementedSubsServiceServer) DeleteSub(context.Context, *DeleteSubRequest) (*DeleteSubResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSub not implemented")
+}
+func (UnimplementedSubsServiceServer) ListSubs(context.Context, *ListSubsRequest) (*ListSubsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubs not implemented")
+}
+func (UnimplementedSubsServiceServer) GetSummary(context.Context, *GetSummaryRequest) (*Summary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSummary not implemented")
+}
+func (UnimplementedSubsServiceServer) mustEmbedUnimplementedSubsServiceServer() {}
+func (UnimplementedSubsServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeSubsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SubsServiceServer will
+// result in compilation errors.
+type UnsafeSubsServiceServer interface {
+	mustEmbedUnimplementedSubsServiceServer()
+}
+
+func RegisterSubsServiceServer(s grpc.ServiceRegistrar, srv SubsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSubsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SubsService_ServiceDesc, srv)
+}
+
+func _SubsService_GetSub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubsServiceServer).GetSub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubsService_GetSub_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubsServiceServer).GetSub(ctx, req.(*GetSubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubsService_PostSub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostSubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubsServiceServer).PostSub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubsService_PostSub_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubsServiceServer).PostSub(ctx, req.(*PostSubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubsService_PutSub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutSubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubsServiceServer).PutSub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubsService_PutSub_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubsServiceServer).PutSub(ctx, req.(*PutSubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubsService_DeleteSub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubsServiceServer).DeleteSub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubsService_DeleteSub_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubsServiceServer).DeleteSub(ctx, req.(*DeleteSubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubsService_ListSubs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubsServiceServer).ListSubs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubsService_ListSubs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubsServiceServer).ListSubs(ctx, req.(*ListSubsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubsService_GetSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubsServiceServer).GetSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubsService_GetSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubsServiceServer).GetSummary(ctx, req.(*GetSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SubsService_ServiceDesc is the grpc.ServiceDesc for SubsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SubsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "subs.v1.SubsService",
+	HandlerType: (*SubsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSub",
+			Handler:    _SubsService_GetSub_Handler,
+		},
+		{
+			MethodName: "PostSub",
+			Handler:    _SubsService_PostSub_Handler,
+		},
+		{
+			MethodName: "PutSub",
+			Handler:    _SubsService_PutSub_Handler,
+		},
+		{
+			MethodName: "DeleteSub",
+			Handler:    _SubsService_DeleteSub_Handler,
+		},
+		{
+			MethodName: "ListSubs",
+			Handler:    _SubsService_ListSubs_Handler,
+		},
+		{
+			MethodName: "GetSummary",
+			Handler:    _SubsService_GetSummary_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "subs/v1/subs.proto",
+}
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+type Stats struct {
+	Size      int
+	Capacity  int
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// LRU keeps up to size values for ttl, the least recently used values are evicted first.
+type LRU[K comparable, V any] struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	items   map[K]*list.Element
+	order   *list.List
+	version uint64
+	stats   Stats
+}
+
+func NewLRU[K comparable, V any](size int, ttl time.Duration) *LRU[K, V] {
+	return &LRU[K, V]{
+		size:  size,
+		ttl:   ttl,
+		items: make(map[K]*list.Element, size),
+		order: list.New(),
+	}
+}
+
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		e := elem.Value.(*entry[K, V])
+
+		if time.Now().Before(e.expiresAt) {
+			c.order.MoveToFront(elem)
+			c.stats.Hits++
+
+			return e.value, true
+		}
+
+		c.remove(elem)
+	}
+
+	c.stats.Misses++
+
+	var zero V
+	return zero, false
+}
+
+// Version changes every time values are removed by RemoveFunc.
+func (c *LRU[K, V]) Version() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.version
+}
+
+// Add skips the value, if any values were removed since version, since the value
+// may have been computed before the change, which removed them.
+func (c *LRU[K, V]) Add(key K, value V, version uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if version != c.version || c.size <= 0 {
+		return
+	}
+
+	e := &entry[K, V]{key: key, value: value, expiresAt: time.Now().Add(c.ttl)}
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value = e
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	c.items[key] = c.order.PushFront(e)
+
+	if c.order.Len() > c.size {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// RemoveFunc removes values, which keys match, and returns their number.
+func (c *LRU[K, V]) RemoveFunc(match func(key K) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+
+	var removed int
+
+	for elem := c.order.Front(); elem != nil; {
+		next := elem.Next()
+
+		if match(elem.Value.(*entry[K, V]).key) {
+			c.remove(elem)
+			removed++
+		}
+
+		elem = next
+	}
+
+	return removed
+}
+
+func (c *LRU[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Size = c.order.Len()
+	stats.Capacity = c.size
+
+	return stats
+}
+
+func (c *LRU[K, V]) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReplicaConfig overrides the address of the primary config (including the primary's DSN),
+// other connection parameters and pool settings are shared. DSN of the replica replaces the primary's one.
+type ReplicaConfig struct {
+	DSN  string `yaml:"dsn"`
+	Host string `yaml:"host"`
+	Port string `yaml:"port"`
+}
+
+// Cluster routes reads to replicas (round robin) and everything else to the primary.
+type Cluster struct {
+	primary  *pgxpool.Pool
+	replicas []*pgxpool.Pool
+	next     atomic.Uint64
+}
+
+type primaryKey struct{}
+
+// WithPrimary forces reads within ctx to be served by the primary,
+// e.g. to let a client read its own recent writes despite replication lag.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// UsesPrimary reports whether reads within ctx are forced to be served by the primary.
+func UsesPrimary(ctx context.Context) bool {
+	pinned, _ := ctx.Value(primaryKey{}).(bool)
+	return pinned
+}
+
+func NewCluster(cfg Config) (*Cluster, error) {
+	const method = "postgres.NewCluster"
+
+	primary, err := NewPostgresPool(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+
+	c := &Cluster{primary: primary}
+
+	for i, replica := range cfg.Replicas {
+		pool, err := newReplicaPool(cfg, replica)
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("%s: replica %d: %w", method, i, err)
+		}
+
+		c.replicas = append(c.replicas, pool)
+	}
+
+	return c, nil
+}
+
+// newReplicaPool overrides the address of the primary's DSN in the parsed config, since the DSN
+// takes precedence over host and port of the config.
+func newReplicaPool(cfg Config, replica ReplicaConfig) (*pgxpool.Pool, error) {
+	replicaCfg := cfg
+
+	if len(replica.DSN) != 0 {
+		replicaCfg.DSN = replica.DSN
+	}
+
+	if len(replica.Host) != 0 {
+		replicaCfg.Host = replica.Host
+	}
+
+	if len(replica.Port) != 0 {
+		replicaCfg.Port = replica.Port
+	}
+
+	poolCfg, err := replicaCfg.poolConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(replica.DSN) == 0 && len(cfg.DSN) != 0 {
+		if err = overrideAddress(poolCfg, replica); err != nil {
+			return nil, err
+		}
+	}
+
+	return connect(poolCfg, replicaCfg)
+}
+
+// overrideAddress replaces the address of all hosts of the config (e.g. fallbacks of sslmode=prefer),
+// so that the replica never falls back to the primary.
+func overrideAddress(poolCfg *pgxpool.Config, replica ReplicaConfig) error {
+	connCfg := &poolCfg.ConnConfig.Config
+
+	var port uint16
+	if len(replica.Port) != 0 {
+		parsed, err := strconv.ParseUint(replica.Port, 10, 16)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidPort, replica.Port)
+		}
+
+		port = uint16(parsed)
+	}
+
+	override := func(host *string, hostPort *uint16) {
+		if len(replica.Host) != 0 {
+			*host = replica.Host
+		}
+
+		if port != 0 {
+			*hostPort = port
+		}
+	}
+
+	override(&connCfg.Host, &connCfg.Port)
+	for _, fallback := range connCfg.Fallbacks {
+		override(&fallback.Host, &fallback.Port)
+	}
+
+	return nil
+}
+
+func (c *Cluster) Primary() *pgxpool.Pool {
+	return c.primary
+}
+
+func (c *Cluster) Reader(ctx context.Context) *pgxpool.Pool {
+	if len(c.replicas) == 0 || UsesPrimary(ctx) {
+		return c.primary
+	}
+
+	return c.replicas[c.next.Add(1)%uint64(len(c.replicas))]
+}
+
+func (c *Cluster) Close() {
+	c.primary.Close()
+
+	for _, replica := range c.replicas {
+		replica.Close()
+	}
+}
@@ -2,41 +2,179 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var (
+	ErrNoConnectionData = errors.New("either dsn or host must be set")
+	ErrInvalidPort      = errors.New("invalid port")
+)
+
 type Config struct {
-	Host 				string			`yaml:"host" env:"POSTGRES_HOST" env-required:"true"`
-	Port 				string			`yaml:"port" env:"POSTGRES_PORT" env-required:"true"`
-	DBName 				string			`yaml:"db" env:"POSTGRES_DB" env-required:"true"`
-	User 				string			`yaml:"user" env:"POSTGRES_USER" env-required:"true"`
-	Password 			string			`yaml:"password" env:"POSTGRES_PASSWORD" env-required:"true"`
-	ConnectionTimeout	time.Duration	`yaml:"connection_timeout" env-default:"300ms"`
+	// DSN overrides all connection parameters below (but not the pool settings).
+	DSN string `yaml:"dsn" env:"POSTGRES_DSN"`
+
+	Host        string `yaml:"host" env:"POSTGRES_HOST"`
+	Port        string `yaml:"port" env:"POSTGRES_PORT" env-default:"5432"`
+	DBName      string `yaml:"db" env:"POSTGRES_DB"`
+	User        string `yaml:"user" env:"POSTGRES_USER"`
+	Password    string `yaml:"password" env:"POSTGRES_PASSWORD"`
+	SSLMode     string `yaml:"sslmode" env:"POSTGRES_SSLMODE" env-default:"disable"`
+	SSLRootCert string `yaml:"sslrootcert" env:"POSTGRES_SSLROOTCERT"`
+
+	ApplicationName  string        `yaml:"application_name" env:"POSTGRES_APPLICATION_NAME" env-default:"subs-service"`
+	StatementTimeout time.Duration `yaml:"statement_timeout" env:"POSTGRES_STATEMENT_TIMEOUT" env-default:"0s"`
+
+	MaxConns          int32         `yaml:"max_conns" env:"POSTGRES_MAX_CONNS" env-default:"0"`
+	MinConns          int32         `yaml:"min_conns" env:"POSTGRES_MIN_CONNS" env-default:"0"`
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime" env-default:"0s"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time" env-default:"0s"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period" env-default:"0s"`
+
+	// Read replicas, see Cluster.
+	Replicas []ReplicaConfig `yaml:"replicas"`
+
+	ConnectionTimeout time.Duration `yaml:"connection_timeout" env-default:"300ms"`
+	ConnectAttempts   int           `yaml:"connect_attempts" env:"POSTGRES_CONNECT_ATTEMPTS" env-default:"10"`
+	RetryBackoff      time.Duration `yaml:"retry_backoff" env-default:"200ms"`
+	MaxRetryBackoff   time.Duration `yaml:"max_retry_backoff" env-default:"5s"`
 }
 
-func NewPostgresPool(cfg Config) (*pgxpool.Pool, error) {
-	const method = "postgres.NewPostgresPool"
+// quote escapes value for a keyword/value connection string.
+func quote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+
+	return "'" + value + "'"
+}
+
+func (cfg *Config) connString() (string, error) {
+	if len(cfg.DSN) != 0 {
+		return cfg.DSN, nil
+	}
+
+	if len(cfg.Host) == 0 {
+		return "", ErrNoConnectionData
+	}
+
+	params := []string{
+		"host=" + quote(cfg.Host),
+		"port=" + quote(cfg.Port),
+		"dbname=" + quote(cfg.DBName),
+		"user=" + quote(cfg.User),
+		"password=" + quote(cfg.Password),
+		"sslmode=" + quote(cfg.SSLMode),
+	}
+
+	if len(cfg.SSLRootCert) != 0 {
+		params = append(params, "sslrootcert="+quote(cfg.SSLRootCert))
+	}
+
+	return strings.Join(params, " "), nil
+}
+
+func (cfg *Config) poolConfig() (*pgxpool.Config, error) {
+	connString, err := cfg.connString()
+	if err != nil {
+		return nil, err
+	}
+
+	poolCfg, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+
+	params := poolCfg.ConnConfig.RuntimeParams
+
+	if _, ok := params["application_name"]; !ok && len(cfg.ApplicationName) != 0 {
+		params["application_name"] = cfg.ApplicationName
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectionTimeout)
-	defer cancel()
+	if cfg.StatementTimeout > 0 {
+		params["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+
+	// Zero values keep pgx defaults (or the ones set by DSN).
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+
+	if cfg.MinConns > 0 {
+		poolCfg.MinConns = cfg.MinConns
+	}
+
+	if cfg.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	}
 
-	pginfo := fmt.Sprintf(
-		"host=%s port=%s dbname=%s user=%s password=%s sslmode=disable",
-		cfg.Host, cfg.Port, cfg.DBName, cfg.User, cfg.Password,
-	)
+	if cfg.MaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+
+	if cfg.HealthCheckPeriod > 0 {
+		poolCfg.HealthCheckPeriod = cfg.HealthCheckPeriod
+	}
+
+	return poolCfg, nil
+}
+
+func NewPostgresPool(cfg Config) (*pgxpool.Pool, error) {
+	const method = "postgres.NewPostgresPool"
 
-	pool, err := pgxpool.New(ctx, pginfo)
+	poolCfg, err := cfg.poolConfig()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", method, err)
 	}
 
-	err = pool.Ping(ctx)
+	pool, err := connect(poolCfg, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", method, err)
 	}
 
 	return pool, nil
 }
+
+func connect(poolCfg *pgxpool.Config, cfg Config) (*pgxpool.Pool, error) {
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ping(pool, cfg); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// ping checks the connection with exponential backoff, since database may be not ready yet
+// (e.g. when both are started by docker compose).
+func ping(pool *pgxpool.Pool, cfg Config) error {
+	backoff := cfg.RetryBackoff
+
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectionTimeout)
+		err := pool.Ping(ctx)
+		cancel()
+
+		if err == nil || attempt >= cfg.ConnectAttempts {
+			return err
+		}
+
+		log.Printf(
+			"[WARN] PostgreSQL is unavailable (attempt %d/%d), retrying in %s: %s",
+			attempt, cfg.ConnectAttempts, backoff, err.Error(),
+		)
+
+		time.Sleep(backoff)
+		backoff = min(2*backoff, cfg.MaxRetryBackoff)
+	}
+}
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Listen calls fn for every notification sent to the channel until ctx is done.
+// Lost connection is reestablished after the backoff, notifications sent meanwhile are lost,
+// so onListen is called each time listening is (re)started.
+func Listen(
+	ctx context.Context, pool *pgxpool.Pool, channel string, backoff time.Duration,
+	onListen func(), fn func(payload string),
+) {
+	for {
+		err := listen(ctx, pool, channel, onListen, fn)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("[ERROR] Listening to %s failed: %s", channel, err.Error())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+func listen(ctx context.Context, pool *pgxpool.Pool, channel string, onListen func(), fn func(payload string)) error {
+	const op = "postgres.listen"
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Listening connection is not returned to the pool, as it would keep receiving notifications.
+	listener := conn.Hijack()
+	defer listener.Close(context.Background())
+
+	if _, err = listener.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Printf("[INFO] Listening to %s notifications", channel)
+	onListen()
+
+	for {
+		notification, waitErr := listener.WaitForNotification(ctx)
+		if waitErr != nil {
+			return fmt.Errorf("%s: %w", op, waitErr)
+		}
+
+		fn(notification.Payload)
+	}
+}
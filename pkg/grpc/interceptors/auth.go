@@ -0,0 +1,32 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+	"subs-service/pkg/http/middleware"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Auth authenticates calls by static API keys passed in the metadata key named as the HTTP header.
+func Auth(cfg middleware.AuthConfig) grpc.UnaryServerInterceptor {
+	authenticator := middleware.NewAuthenticator(cfg)
+	key := strings.ToLower(cfg.Header)
+
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var apiKey string
+		if values := metadata.ValueFromIncomingContext(ctx, key); len(values) != 0 {
+			apiKey = values[0]
+		}
+
+		ctx, ok := authenticator.Authenticate(ctx, apiKey)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+		}
+
+		return handler(ctx, req)
+	}
+}
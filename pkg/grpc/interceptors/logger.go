@@ -0,0 +1,29 @@
+package interceptors
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func Logger(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	addr := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	log.Printf(
+		"[gRPC] | %s | %s | %s | %s",
+		addr, status.Code(err).String(), info.FullMethod, time.Since(start).String(),
+	)
+
+	return resp, err
+}
@@ -0,0 +1,41 @@
+package interceptors
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"subs-service/pkg/http/middleware"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ReadYourWrites pins a client to the primary database for cfg.Window after its last write the same way
+// as the HTTP API does. There are no cookies in gRPC, so the deadline is handed to the client in the header
+// metadata named as the HTTP header, which has to be sent back in the request metadata.
+func ReadYourWrites(
+	cfg middleware.ReadYourWritesConfig,
+	groupFunc GroupFunc,
+	pin func(ctx context.Context) context.Context,
+) grpc.UnaryServerInterceptor {
+	key := strings.ToLower(cfg.HeaderName)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		now := time.Now()
+
+		if groupFunc(info.FullMethod) == middleware.GroupWrite {
+			// Failure to send the header does not affect the call itself.
+			_ = grpc.SetHeader(ctx, metadata.Pairs(key, strconv.FormatInt(now.Add(cfg.Window).Unix(), 10)))
+
+			return handler(pin(ctx), req)
+		}
+
+		if values := metadata.ValueFromIncomingContext(ctx, key); len(values) != 0 &&
+			middleware.PinnedAt(values[0], now, cfg.Window) {
+			ctx = pin(ctx)
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,61 @@
+package interceptors
+
+import (
+	"context"
+	"log"
+	"math"
+	"strconv"
+	"subs-service/pkg/http/middleware"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// GroupFunc returns the name of the group, whose limit applies to the method.
+type GroupFunc func(fullMethod string) string
+
+// RateLimit limits calls the same way and in the same buckets as the HTTP API does, must follow Auth,
+// so that authenticated clients are identified by their subject. Limits are reported in the response
+// header metadata named as the HTTP headers.
+func RateLimit(limiter *middleware.RateLimiter, groupFunc GroupFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		group := groupFunc(info.FullMethod)
+
+		limit, ok := limiter.Limit(group)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		var addr string
+		if p, ok := peer.FromContext(ctx); ok {
+			addr = p.Addr.String()
+		}
+
+		res, err := limiter.Take(ctx, group, middleware.ClientKey(ctx, addr), limit)
+		if err != nil {
+			// Failing open: the limiter must not make the service unavailable.
+			log.Printf("[ERROR] Rate limiter failure: %s", err.Error())
+			return handler(ctx, req)
+		}
+
+		md := metadata.Pairs(
+			"ratelimit-limit", strconv.Itoa(res.Limit),
+			"ratelimit-remaining", strconv.Itoa(res.Remaining),
+		)
+
+		if !res.Allowed {
+			md.Set("retry-after", strconv.Itoa(int(math.Ceil(res.RetryAfter.Seconds()))))
+			_ = grpc.SetHeader(ctx, md)
+
+			return nil, status.Error(codes.ResourceExhausted, "too many requests")
+		}
+
+		// Failure to send the header does not affect the call itself.
+		_ = grpc.SetHeader(ctx, md)
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,36 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	requestIDKey = "x-request-id"
+)
+
+type requestIDCtxKey struct{}
+
+// RequestID takes the request id from the x-request-id metadata or generates a new one,
+// the id is sent back in the response header.
+func RequestID(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var id string
+	if values := metadata.ValueFromIncomingContext(ctx, requestIDKey); len(values) != 0 && len(values[0]) != 0 {
+		id = values[0]
+	} else {
+		id = uuid.NewString()
+	}
+
+	// Failure to send the header does not affect the call itself.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+
+	return handler(context.WithValue(ctx, requestIDCtxKey{}, id), req)
+}
+
+func GetReqID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey{}).(string)
+	return id
+}
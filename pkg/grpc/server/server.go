@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+type GRPCConfig struct {
+	Enabled bool   `yaml:"enabled" env:"GRPC_ENABLED" env-default:"true"`
+	Address string `yaml:"address" env:"GRPC_ADDRESS" env-default:"0.0.0.0:9090"`
+
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"GRPC_SHUTDOWN_TIMEOUT" env-default:"10s"`
+}
+
+// CreateServer serves until ctx is done, then the server is gracefully stopped.
+// Calls still running after the shutdown timeout are cancelled.
+func CreateServer(ctx context.Context, s *grpc.Server, cfg GRPCConfig) error {
+	const op = "server.CreateServer"
+
+	lis, err := net.Listen("tcp", cfg.Address)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		stopped := make(chan struct{})
+		go func() {
+			s.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(cfg.ShutdownTimeout):
+			log.Printf("[ERROR] Failed to shut down gRPC server gracefully: shutdown timeout exceeded")
+			s.Stop()
+		}
+	}()
+
+	if err = s.Serve(lis); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
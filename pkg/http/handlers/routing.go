@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	pkgMiddleware "subs-service/pkg/http/middleware"
 
@@ -11,7 +12,7 @@ import (
 
 const (
 	SwaggerPath = "/swagger/*"
-	HealthPath = "/health"
+	HealthPath  = "/health"
 )
 
 type RouterOption func(r chi.Router)
@@ -24,6 +25,18 @@ func RouteHandlers(r chi.Router, apiPath string, opts ...RouterOption) {
 	})
 }
 
+// WithGroup applies opts to a new inline group, so that middlewares
+// registered by them do not affect routes outside the group.
+func WithGroup(opts ...RouterOption) RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			for _, opt := range opts {
+				opt(r)
+			}
+		})
+	}
+}
+
 func WithLogger() RouterOption {
 	return func(r chi.Router) {
 		r.Use(pkgMiddleware.Logger)
@@ -36,6 +49,18 @@ func WithRecovery() RouterOption {
 	}
 }
 
+func WithRequestID() RouterOption {
+	return func(r chi.Router) {
+		r.Use(middleware.RequestID)
+	}
+}
+
+func WithAuth(cfg pkgMiddleware.AuthConfig) RouterOption {
+	return func(r chi.Router) {
+		r.Use(pkgMiddleware.Auth(cfg))
+	}
+}
+
 func WithSwagger() RouterOption {
 	return func(r chi.Router) {
 		r.Get(SwaggerPath, httpSwagger.WrapHandler)
@@ -43,9 +68,30 @@ func WithSwagger() RouterOption {
 }
 
 func WithHealthHandler() RouterOption {
-	return func (r chi.Router) {
+	return func(r chi.Router) {
 		r.Get(HealthPath, func(w http.ResponseWriter, _ *http.Request) {
 			w.WriteHeader(http.StatusOK)
 		})
 	}
 }
+
+// WithRateLimiter does nothing if limiter is nil (rate limiting is disabled).
+func WithRateLimiter(limiter *pkgMiddleware.RateLimiter) RouterOption {
+	return func(r chi.Router) {
+		if limiter != nil {
+			r.Use(limiter.Handler)
+		}
+	}
+}
+
+func WithReadYourWrites(
+	cfg pkgMiddleware.ReadYourWritesConfig,
+	groupFunc pkgMiddleware.GroupFunc,
+	pin func(ctx context.Context) context.Context,
+) RouterOption {
+	return func(r chi.Router) {
+		if cfg.Enabled {
+			r.Use(pkgMiddleware.ReadYourWrites(cfg, groupFunc, pin))
+		}
+	}
+}
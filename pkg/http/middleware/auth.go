@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+)
+
+// APIKey bound to a tenant gives access to that tenant only, see ResolveTenant.
+type APIKey struct {
+	Key     string `yaml:"key"`
+	Subject string `yaml:"subject"`
+	Admin   bool   `yaml:"admin"`
+	Tenant  string `yaml:"tenant"`
+}
+
+type AuthConfig struct {
+	Enabled bool     `yaml:"enabled" env:"AUTH_ENABLED" env-default:"false"`
+	Header  string   `yaml:"header" env-default:"X-API-Key"`
+	Keys    []APIKey `yaml:"keys"`
+
+	// TrustAllAsAdmin grants admin rights to all requests, while authentication is disabled (e.g. for tests).
+	TrustAllAsAdmin bool `yaml:"trust_all_as_admin" env:"AUTH_TRUST_ALL_AS_ADMIN" env-default:"false"`
+}
+
+// Authenticator looks up static API keys, it is shared by all APIs of the service.
+type Authenticator struct {
+	cfg  AuthConfig
+	keys map[[sha256.Size]byte]APIKey
+}
+
+func NewAuthenticator(cfg AuthConfig) *Authenticator {
+	keys := make(map[[sha256.Size]byte]APIKey, len(cfg.Keys))
+	for _, key := range cfg.Keys {
+		keys[sha256.Sum256([]byte(key.Key))] = key
+	}
+
+	return &Authenticator{
+		cfg:  cfg,
+		keys: keys,
+	}
+}
+
+// Authenticate stores the key's subject and tenant in the context, ok is false for unknown keys.
+// With authentication disabled requests with unknown keys are anonymous ones without admin rights,
+// unless all requests are trusted as admin ones.
+func (a *Authenticator) Authenticate(ctx context.Context, apiKey string) (context.Context, bool) {
+	if !a.cfg.Enabled && a.cfg.TrustAllAsAdmin {
+		return WithAdmin(ctx), true
+	}
+
+	key, ok := a.keys[sha256.Sum256([]byte(apiKey))]
+	if !ok || len(key.Key) == 0 {
+		return ctx, !a.cfg.Enabled
+	}
+
+	ctx = WithSubject(ctx, key.Subject)
+	if key.Admin {
+		ctx = WithAdmin(ctx)
+	}
+
+	if len(key.Tenant) != 0 {
+		ctx = WithTenantClaim(ctx, key.Tenant)
+	}
+
+	return ctx, true
+}
+
+// Auth authenticates requests by static API keys passed in the configured header.
+func Auth(cfg AuthConfig) func(http.Handler) http.Handler {
+	authenticator := NewAuthenticator(cfg)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, ok := authenticator.Authenticate(r.Context(), r.Header.Get(cfg.Header))
+			if !ok {
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !IsAdmin(r.Context()) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireOperator allows admins, which are not bound to a tenant, to manage data shared by all tenants.
+func RequireOperator(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, bound := TenantClaimFromContext(r.Context()); bound || !IsAdmin(r.Context()) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
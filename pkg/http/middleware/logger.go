@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"crypto/tls"
+	"fmt"
 	"log"
 	"net/http"
 	"time"
@@ -16,8 +18,16 @@ func Logger(next http.Handler) http.Handler {
 		next.ServeHTTP(ww, r)
 
 		log.Printf(
-			"[HTTP/1.1] | %s | %d | %s | %s | %s",
-			r.RemoteAddr, ww.Status(), r.Method, r.URL.Path, time.Since(start).String(),
+			"[%s] | %s | %d | %s | %s | %s",
+			protocol(r), r.RemoteAddr, ww.Status(), r.Method, r.URL.Path, time.Since(start).String(),
 		)
 	})
 }
+
+func protocol(r *http.Request) string {
+	if r.TLS == nil {
+		return r.Proto
+	}
+
+	return fmt.Sprintf("%s %s", r.Proto, tls.VersionName(r.TLS.Version))
+}
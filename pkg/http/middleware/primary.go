@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type ReadYourWritesConfig struct {
+	Enabled    bool          `yaml:"enabled" env:"READ_YOUR_WRITES_ENABLED" env-default:"false"`
+	Window     time.Duration `yaml:"window" env-default:"5s"`
+	CookieName string        `yaml:"cookie_name" env-default:"primary_until"`
+	HeaderName string        `yaml:"header_name" env-default:"X-Primary-Until"`
+}
+
+type markWriteKey struct{}
+
+// ReadYourWrites pins a client to the primary database for cfg.Window after its last write.
+// The deadline (unix time) is handed to the client in a cookie and in a response header,
+// which may be sent back as a request header by clients that do not keep cookies.
+// Reads of pinned clients are marked with pin. Requests are classified by groupFunc (MethodGroup if nil),
+// requests of other groups, which may write as well (e.g. GraphQL mutations), pin the client by MarkWrite.
+func ReadYourWrites(
+	cfg ReadYourWritesConfig,
+	groupFunc GroupFunc,
+	pin func(ctx context.Context) context.Context,
+) func(http.Handler) http.Handler {
+	if groupFunc == nil {
+		groupFunc = MethodGroup
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			now := time.Now()
+
+			if groupFunc(r) == GroupWrite {
+				setPinned(w, cfg, now)
+				next.ServeHTTP(w, r.WithContext(pin(r.Context())))
+
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), markWriteKey{}, func(ctx context.Context) context.Context {
+				setPinned(w, cfg, time.Now())
+				return pin(ctx)
+			})
+
+			if PinnedAt(pinnedUntil(r, cfg), now, cfg.Window) {
+				ctx = pin(ctx)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// MarkWrite pins the client of the request, which writes data despite its group isn't the write one,
+// the returned context is pinned the same way as write requests are. It must be called before
+// the response is written and returns ctx as is, if read-your-writes is disabled.
+func MarkWrite(ctx context.Context) context.Context {
+	if mark, ok := ctx.Value(markWriteKey{}).(func(ctx context.Context) context.Context); ok {
+		return mark(ctx)
+	}
+
+	return ctx
+}
+
+func setPinned(w http.ResponseWriter, cfg ReadYourWritesConfig, now time.Time) {
+	until := now.Add(cfg.Window)
+	value := strconv.FormatInt(until.Unix(), 10)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     cfg.CookieName,
+		Value:    value,
+		Path:     "/",
+		Expires:  until,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	w.Header().Set(cfg.HeaderName, value)
+}
+
+func pinnedUntil(r *http.Request, cfg ReadYourWritesConfig) string {
+	value := r.Header.Get(cfg.HeaderName)
+
+	if cookie, err := r.Cookie(cfg.CookieName); len(value) == 0 && err == nil {
+		value = cookie.Value
+	}
+
+	return value
+}
+
+// PinnedAt checks whether the deadline (unix time) handed to the client is still ahead at now.
+// Deadlines are sent back by clients, so those beyond window are ignored, otherwise a client
+// could pin its reads to the primary forever.
+func PinnedAt(value string, now time.Time, window time.Duration) bool {
+	unix, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	until := time.Unix(unix, 0)
+
+	return until.After(now) && !until.After(now.Add(window))
+}
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"subs-service/pkg/ratelimit"
+	"time"
+)
+
+const (
+	GroupRead    = "read"
+	GroupWrite   = "write"
+	GroupSummary = "summary"
+
+	StoreMemory   = "memory"
+	StorePostgres = "postgres"
+)
+
+type RateLimitConfig struct {
+	Enabled bool                       `yaml:"enabled" env:"RATE_LIMIT_ENABLED" env-default:"false"`
+	Store   string                     `yaml:"store" env:"RATE_LIMIT_STORE" env-default:"memory"`
+	Groups  map[string]ratelimit.Limit `yaml:"groups"`
+}
+
+// GroupFunc returns the name of the route group, whose limit applies to the request.
+type GroupFunc func(r *http.Request) string
+
+// MethodGroup treats safe methods as reads and everything else as writes.
+func MethodGroup(r *http.Request) string {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return GroupRead
+	default:
+		return GroupWrite
+	}
+}
+
+type RateLimiter struct {
+	store     ratelimit.Store
+	limits    map[string]ratelimit.Limit
+	groupFunc GroupFunc
+}
+
+func NewRateLimiter(store ratelimit.Store, cfg RateLimitConfig, groupFunc GroupFunc) *RateLimiter {
+	limits := make(map[string]ratelimit.Limit, len(cfg.Groups))
+
+	for group, limit := range cfg.Groups {
+		if !limit.Valid() {
+			log.Printf("[WARN] Rate limit for group %q is invalid and will be ignored", group)
+			continue
+		}
+
+		limits[group] = limit
+	}
+
+	if groupFunc == nil {
+		groupFunc = MethodGroup
+	}
+
+	return &RateLimiter{
+		store:     store,
+		limits:    limits,
+		groupFunc: groupFunc,
+	}
+}
+
+// Handler limits requests per client within each route group. A client is identified
+// by the authenticated subject or, if the request isn't authenticated, by its IP address.
+func (l *RateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		group := l.groupFunc(r)
+
+		limit, ok := l.Limit(group)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		res, err := l.Take(r.Context(), group, ClientKey(r.Context(), r.RemoteAddr), limit)
+		if err != nil {
+			// Failing open: the limiter must not make the service unavailable.
+			log.Printf("[ERROR] Rate limiter failure: %s", err.Error())
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Requests, seconds(limit.Period)))
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(seconds(res.Reset)))
+
+		if !res.Allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(seconds(res.RetryAfter)))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Limit returns the limit of the group, false is returned if the group isn't limited.
+func (l *RateLimiter) Limit(group string) (ratelimit.Limit, bool) {
+	limit, ok := l.limits[group]
+	return limit, ok
+}
+
+// Take takes a token from the bucket of the client within the group, so that other APIs
+// (e.g. gRPC) share buckets with the HTTP API.
+func (l *RateLimiter) Take(ctx context.Context, group, client string, limit ratelimit.Limit) (*ratelimit.Result, error) {
+	return l.store.Take(ctx, fmt.Sprintf("%s:%s", group, client), limit)
+}
+
+// ClientKey identifies the client by the authenticated subject, which stands in for the API key,
+// so keys of the same subject share buckets, or by the IP address of remoteAddr.
+func ClientKey(ctx context.Context, remoteAddr string) string {
+	if subject, ok := SubjectFromContext(ctx); ok {
+		return "sub:" + subject
+	}
+
+	// Unvalidated API keys are ignored, otherwise clients could get a new bucket with every random key.
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	return "ip:" + host
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
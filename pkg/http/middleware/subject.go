@@ -0,0 +1,38 @@
+package middleware
+
+import "context"
+
+type subjectKey struct{}
+
+type adminKey struct{}
+
+type tenantClaimKey struct{}
+
+// WithSubject stores the authenticated subject (e.g. user id) in the request context.
+func WithSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, subjectKey{}, subject)
+}
+
+func SubjectFromContext(ctx context.Context) (string, bool) {
+	subject, ok := ctx.Value(subjectKey{}).(string)
+	return subject, ok && len(subject) != 0
+}
+
+func WithAdmin(ctx context.Context) context.Context {
+	return context.WithValue(ctx, adminKey{}, true)
+}
+
+func IsAdmin(ctx context.Context) bool {
+	admin, _ := ctx.Value(adminKey{}).(bool)
+	return admin
+}
+
+// WithTenantClaim stores the tenant the authenticated subject is bound to in the request context.
+func WithTenantClaim(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantClaimKey{}, tenant)
+}
+
+func TenantClaimFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantClaimKey{}).(string)
+	return tenant, ok && len(tenant) != 0
+}
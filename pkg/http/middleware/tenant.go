@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+var (
+	ErrBadTenant      = errors.New("bad tenant id, must be 1-64 latin letters, digits, '-' or '_'")
+	ErrTenantMismatch = errors.New("tenant doesn't match the tenant of the api key")
+
+	tenantPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+)
+
+// TenantConfig sets the header the tenant of requests is passed in,
+// requests without tenant belong to the default one.
+type TenantConfig struct {
+	Header  string `yaml:"header" env-default:"X-Tenant-ID"`
+	Default string `yaml:"default" env:"TENANT_DEFAULT" env-default:"default"`
+}
+
+// ResolveTenant resolves the tenant of authenticated request: api key bound to a tenant
+// can't access other tenants, otherwise the tenant is passed in the header.
+func ResolveTenant(ctx context.Context, header string, cfg TenantConfig) (string, error) {
+	claim, bound := TenantClaimFromContext(ctx)
+
+	switch {
+	case bound && len(header) != 0 && header != claim:
+		return "", ErrTenantMismatch
+	case bound:
+		return claim, nil
+	case len(header) == 0:
+		return cfg.Default, nil
+	case !tenantPattern.MatchString(header):
+		return "", ErrBadTenant
+	}
+
+	return header, nil
+}
@@ -1,6 +1,10 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"time"
 )
@@ -10,17 +14,63 @@ type HTTPConfig struct {
 	ReadTimeout  time.Duration `yaml:"read_timeout" env:"READ_TIMEOUT" env-required:"true"`
 	WriteTimeout time.Duration `yaml:"write_timeout" env:"WRITE_TIMEOUT" env-required:"true"`
 	IdleTimeout  time.Duration `yaml:"idle_timeout" env:"IDLE_TIMEOUT" env-required:"true"`
+
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" env-default:"10s"`
+
+	// H2C enables HTTP/2 with prior knowledge over plaintext connections (for internal traffic).
+	H2C bool      `yaml:"h2c" env:"HTTP_H2C" env-default:"false"`
+	TLS TLSConfig `yaml:"tls"`
 }
 
-func CreateServer(handler http.Handler, cfg HTTPConfig) error {
-    s := &http.Server{
-        Addr: cfg.Address,
-        ReadTimeout: cfg.ReadTimeout,
-        WriteTimeout: cfg.WriteTimeout,
-        IdleTimeout: cfg.IdleTimeout,
+// CreateServer serves until ctx is done, then the server is gracefully shut down.
+func CreateServer(ctx context.Context, handler http.Handler, cfg HTTPConfig) error {
+	const op = "server.CreateServer"
+
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+
+	s := &http.Server{
+		Addr:         cfg.Address,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+		Protocols:    &protocols,
+
+		Handler: handler,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancel()
+
+		if err := s.Shutdown(shutdownCtx); err != nil {
+			log.Printf("[ERROR] Failed to shut down server gracefully: %s", err.Error())
+		}
+	}()
+
+	if !cfg.TLS.Enabled {
+		protocols.SetUnencryptedHTTP2(cfg.H2C)
+		return ignoreClosed(s.ListenAndServe())
+	}
+
+	tlsCfg, err := NewTLSConfig(cfg.TLS)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	protocols.SetHTTP2(true)
+	s.TLSConfig = tlsCfg
+
+	// Certificates are provided by TLSConfig, so file names are left empty.
+	return ignoreClosed(s.ListenAndServeTLS("", ""))
+}
 
-        Handler: handler,
-    }
+func ignoreClosed(err error) error {
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
 
-    return s.ListenAndServe()
+	return err
 }
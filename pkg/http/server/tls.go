@@ -0,0 +1,174 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownTLSVersion = errors.New("unknown TLS version")
+	ErrNoCertificates    = errors.New("no certificates found in CA bundle")
+
+	tlsVersions = map[string]uint16{
+		"1.0": tls.VersionTLS10,
+		"1.1": tls.VersionTLS11,
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
+)
+
+type TLSConfig struct {
+	Enabled        bool          `yaml:"enabled" env:"TLS_ENABLED" env-default:"false"`
+	CertFile       string        `yaml:"cert_file" env:"TLS_CERT_FILE"`
+	KeyFile        string        `yaml:"key_file" env:"TLS_KEY_FILE"`
+	MinVersion     string        `yaml:"min_version" env:"TLS_MIN_VERSION" env-default:"1.2"`
+	ClientCAFile   string        `yaml:"client_ca_file" env:"TLS_CLIENT_CA_FILE"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"TLS_RELOAD_INTERVAL" env-default:"30s"`
+}
+
+// NewTLSConfig builds the server TLS config, which reloads certificates as they change on disk,
+// so that other servers of the service (e.g. gRPC) are secured the same way as the HTTP server.
+func NewTLSConfig(cfg TLSConfig) (*tls.Config, error) {
+	const op = "server.NewTLSConfig"
+
+	reloader, err := newCertReloader(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &tls.Config{
+		MinVersion:         reloader.minVersion,
+		GetConfigForClient: reloader.GetConfigForClient,
+	}, nil
+}
+
+// certReloader rebuilds TLS config once certificate, key or client CA files
+// are changed on disk. Files are checked lazily on handshakes, but no more often
+// than once per ReloadInterval.
+type certReloader struct {
+	cfg        TLSConfig
+	minVersion uint16
+
+	mu        sync.RWMutex
+	tlsCfg    *tls.Config
+	modTimes  map[string]time.Time
+	lastCheck time.Time
+}
+
+func newCertReloader(cfg TLSConfig) (*certReloader, error) {
+	const op = "newCertReloader"
+
+	minVersion, ok := tlsVersions[cfg.MinVersion]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: %s", op, ErrUnknownTLSVersion, cfg.MinVersion)
+	}
+
+	cr := &certReloader{
+		cfg:        cfg,
+		minVersion: minVersion,
+	}
+
+	if err := cr.reload(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return cr, nil
+}
+
+func (cr *certReloader) files() []string {
+	files := []string{cr.cfg.CertFile, cr.cfg.KeyFile}
+	if len(cr.cfg.ClientCAFile) != 0 {
+		files = append(files, cr.cfg.ClientCAFile)
+	}
+
+	return files
+}
+
+func (cr *certReloader) reload() error {
+	modTimes := make(map[string]time.Time)
+
+	for _, file := range cr.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+
+		modTimes[file] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(cr.cfg.CertFile, cr.cfg.KeyFile)
+	if err != nil {
+		return err
+	}
+
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   cr.minVersion,
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+
+	if len(cr.cfg.ClientCAFile) != 0 {
+		bundle, err := os.ReadFile(cr.cfg.ClientCAFile)
+		if err != nil {
+			return err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bundle) {
+			return ErrNoCertificates
+		}
+
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	cr.mu.Lock()
+	cr.tlsCfg = tlsCfg
+	cr.modTimes = modTimes
+	cr.lastCheck = time.Now()
+	cr.mu.Unlock()
+
+	return nil
+}
+
+func (cr *certReloader) changed() bool {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	if time.Since(cr.lastCheck) < cr.cfg.ReloadInterval {
+		return false
+	}
+
+	cr.lastCheck = time.Now()
+
+	for file, modTime := range cr.modTimes {
+		if info, err := os.Stat(file); err == nil && !info.ModTime().Equal(modTime) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (cr *certReloader) GetConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	if cr.changed() {
+		// Keeping the previous certificate if the new one is broken
+		// (e.g. files are being replaced right now).
+		if err := cr.reload(); err != nil {
+			log.Printf("[ERROR] Failed to reload TLS certificates: %s", err.Error())
+		} else {
+			log.Printf("[INFO] TLS certificates were reloaded")
+		}
+	}
+
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+
+	return cr.tlsCfg, nil
+}
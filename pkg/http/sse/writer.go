@@ -0,0 +1,82 @@
+package sse
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Writer writes Server-Sent Events, every written event is flushed to the client immediately.
+type Writer struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// NewWriter writes stream's headers. Write deadline of the server is disabled for the stream,
+// so that it is kept open until the client disconnects.
+func NewWriter(w http.ResponseWriter) (*Writer, error) {
+	const op = "sse.NewWriter"
+
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Disables response buffering of nginx.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	s := &Writer{w: w, rc: rc}
+	if err := s.rc.Flush(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s, nil
+}
+
+// Retry sets the client's reconnection delay.
+func (s *Writer) Retry(interval time.Duration) error {
+	return s.write(fmt.Sprintf("retry: %d\n\n", interval.Milliseconds()))
+}
+
+// Event writes an event, empty id and event are omitted.
+func (s *Writer) Event(id, event string, data []byte) error {
+	var b strings.Builder
+
+	if len(id) != 0 {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+
+	if len(event) != 0 {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+
+	for line := range strings.Lines(string(data)) {
+		fmt.Fprintf(&b, "data: %s\n", strings.TrimSuffix(line, "\n"))
+	}
+
+	if len(data) == 0 {
+		b.WriteString("data:\n")
+	}
+
+	b.WriteString("\n")
+
+	return s.write(b.String())
+}
+
+// Comment writes a comment ignored by clients, it is used as a heartbeat.
+func (s *Writer) Comment(text string) error {
+	return s.write(fmt.Sprintf(": %s\n\n", text))
+}
+
+func (s *Writer) write(chunk string) error {
+	if _, err := s.w.Write([]byte(chunk)); err != nil {
+		return err
+	}
+
+	return s.rc.Flush()
+}
@@ -0,0 +1,129 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+
+	// maxLineLength is the limit of content lines in octets, longer lines are folded.
+	maxLineLength = 75
+)
+
+// Calendar is an RFC 5545 calendar of all-day events.
+type Calendar struct {
+	ProdID string
+	Name   string
+	// RefreshInterval hints clients how often to reload the calendar (if positive).
+	RefreshInterval time.Duration
+	Events          []Event
+}
+
+// Event is an all-day event, which may recur by RRule excluding ExDates.
+type Event struct {
+	UID         string
+	Stamp       time.Time
+	Date        time.Time
+	Summary     string
+	Description string
+	RRule       string
+	ExDates     []time.Time
+}
+
+// Date formats the date as a DATE value, e.g. for UNTIL of a recurrence rule.
+func Date(t time.Time) string {
+	return t.Format(dateLayout)
+}
+
+// Encode writes the calendar with CRLF line breaks and folded long lines.
+func (c *Calendar) Encode() []byte {
+	var b bytes.Buffer
+
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+c.ProdID)
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+
+	if len(c.Name) != 0 {
+		writeLine(&b, "X-WR-CALNAME:"+escape(c.Name))
+	}
+
+	if c.RefreshInterval > 0 {
+		duration := fmt.Sprintf("PT%dM", int(c.RefreshInterval.Minutes()))
+		writeLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:"+duration)
+		writeLine(&b, "X-PUBLISHED-TTL:"+duration)
+	}
+
+	for i := range c.Events {
+		c.Events[i].encode(&b)
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+
+	return b.Bytes()
+}
+
+func (e *Event) encode(b *bytes.Buffer) {
+	writeLine(b, "BEGIN:VEVENT")
+	writeLine(b, "UID:"+e.UID)
+	writeLine(b, "DTSTAMP:"+e.Stamp.UTC().Format(dateTimeLayout))
+	writeLine(b, "DTSTART;VALUE=DATE:"+Date(e.Date))
+	writeLine(b, "SUMMARY:"+escape(e.Summary))
+
+	if len(e.Description) != 0 {
+		writeLine(b, "DESCRIPTION:"+escape(e.Description))
+	}
+
+	if len(e.RRule) != 0 {
+		writeLine(b, "RRULE:"+e.RRule)
+	}
+
+	if len(e.ExDates) != 0 {
+		dates := make([]string, 0, len(e.ExDates))
+		for _, date := range e.ExDates {
+			dates = append(dates, Date(date))
+		}
+
+		writeLine(b, "EXDATE;VALUE=DATE:"+strings.Join(dates, ","))
+	}
+
+	writeLine(b, "TRANSP:TRANSPARENT")
+	writeLine(b, "END:VEVENT")
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escape escapes TEXT values.
+func escape(text string) string {
+	return escaper.Replace(text)
+}
+
+// writeLine folds the line into lines of at most maxLineLength octets without splitting UTF-8 characters,
+// continuation lines start with a space.
+func writeLine(b *bytes.Buffer, line string) {
+	limit := maxLineLength
+
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+
+		line = line[cut:]
+		// The leading space of continuation lines counts towards the limit.
+		limit = maxLineLength - 1
+	}
+
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
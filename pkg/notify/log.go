@@ -0,0 +1,18 @@
+package notify
+
+import (
+	"context"
+	"log"
+)
+
+// LogNotifier only writes notifications to the log.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(_ context.Context, notification *Notification) error {
+	log.Printf("[INFO] Notification %s for user %s: %s", notification.Key, notification.UserID, notification.Text)
+	return nil
+}
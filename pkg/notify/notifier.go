@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	TypeLog     = "log"
+	TypeWebhook = "webhook"
+	TypeSMTP    = "smtp"
+)
+
+// Notification is a message for a user. Key identifies the notification,
+// so that receivers are able to deduplicate it.
+type Notification struct {
+	Key     string `json:"key"`
+	UserID  string `json:"user_id"`
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	Data    any    `json:"data,omitempty"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, n *Notification) error
+}
+
+type Config struct {
+	Type    string        `yaml:"type" env:"NOTIFIER_TYPE" env-default:"log"`
+	Webhook WebhookConfig `yaml:"webhook"`
+	SMTP    SMTPConfig    `yaml:"smtp"`
+}
+
+func NewNotifier(cfg Config) (Notifier, error) {
+	const op = "notify.NewNotifier"
+
+	switch cfg.Type {
+	case TypeLog:
+		return NewLogNotifier(), nil
+	case TypeWebhook:
+		return NewWebhookNotifier(cfg.Webhook), nil
+	case TypeSMTP:
+		return NewSMTPNotifier(cfg.SMTP), nil
+	default:
+		return nil, fmt.Errorf("%s: unknown notifier type %q", op, cfg.Type)
+	}
+}
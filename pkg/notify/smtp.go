@@ -0,0 +1,145 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+type SMTPConfig struct {
+	Address  string        `yaml:"address" env:"SMTP_ADDRESS"`
+	Username string        `yaml:"username" env:"SMTP_USERNAME"`
+	Password string        `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string        `yaml:"from" env:"SMTP_FROM"`
+	To       []string      `yaml:"to" env:"SMTP_TO" env-separator:","`
+	Timeout  time.Duration `yaml:"timeout" env-default:"10s"`
+}
+
+// SMTPNotifier sends notifications as plain text emails to the fixed recipients, since the service
+// doesn't know addresses of users. So it is a digest for operators, which tells users apart by
+// the X-User-ID header, rather than a channel to users. Authentication is used only if username is set,
+// as servers without TLS (e.g. local fake ones) usually do not support it.
+type SMTPNotifier struct {
+	cfg    SMTPConfig
+	host   string
+	auth   smtp.Auth
+	dialer net.Dialer
+}
+
+func NewSMTPNotifier(cfg SMTPConfig) *SMTPNotifier {
+	host, _, _ := net.SplitHostPort(cfg.Address)
+
+	var auth smtp.Auth
+
+	if len(cfg.Username) != 0 {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, host)
+	}
+
+	return &SMTPNotifier{
+		cfg:  cfg,
+		host: host,
+		auth: auth,
+	}
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, notification *Notification) error {
+	const op = "SMTPNotifier.Notify"
+
+	if n.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.cfg.Timeout)
+		defer cancel()
+	}
+
+	if err := n.send(ctx, n.message(notification)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (n *SMTPNotifier) message(notification *Notification) []byte {
+	var msg bytes.Buffer
+
+	fmt.Fprintf(&msg, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.cfg.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "X-Notification-Key: %s\r\n", notification.Key)
+	fmt.Fprintf(&msg, "X-User-ID: %s\r\n", notification.UserID)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(notification.Text)
+	msg.WriteString("\r\n")
+
+	return msg.Bytes()
+}
+
+// send drives the session by hand instead of smtp.SendMail, which can't be canceled
+// and has no deadlines, so a stalled server would block the caller forever.
+func (n *SMTPNotifier) send(ctx context.Context, msg []byte) error {
+	conn, err := n.dialer.DialContext(ctx, "tcp", n.cfg.Address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Zero deadline, if ctx has none, means no deadline.
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		return err
+	}
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: n.host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+
+	if n.auth != nil {
+		if err = client.Auth(n.auth); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(n.cfg.From); err != nil {
+		return err
+	}
+
+	for _, to := range n.cfg.To {
+		if err = client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(msg); err != nil {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
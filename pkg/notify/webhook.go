@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type WebhookConfig struct {
+	URL     string        `yaml:"url" env:"NOTIFIER_WEBHOOK_URL"`
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+}
+
+// WebhookNotifier posts notifications as JSON to the configured URL.
+type WebhookNotifier struct {
+	client *http.Client
+	url    string
+}
+
+func NewWebhookNotifier(cfg WebhookConfig) *WebhookNotifier {
+	return &WebhookNotifier{
+		client: &http.Client{Timeout: cfg.Timeout},
+		url:    cfg.URL,
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, notification *Notification) error {
+	const op = "WebhookNotifier.Notify"
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: unexpected response status %s", op, resp.Status)
+	}
+
+	return nil
+}
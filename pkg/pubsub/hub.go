@@ -0,0 +1,160 @@
+package pubsub
+
+import (
+	"sync"
+)
+
+// Event is published to a topic. IDs are expected to grow, so that subscribers
+// are able to resume after the last received event.
+type Event struct {
+	ID    int64
+	Topic string
+	Type  string
+	Data  []byte
+}
+
+// Hub fans events out to subscribers of their topics and keeps the last events
+// in a ring buffer to replay them to resuming subscribers.
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[string]map[*Subscription]struct{}
+	buffer []Event
+	next   int
+	full   bool
+	// evicted is the greatest ID of events dropped from the buffer.
+	evicted int64
+	// gap is set while events might have been missed by the hub (initially and after MarkGap).
+	gap    bool
+	closed bool
+}
+
+// Subscription receives events until it is closed by the subscriber, by the hub being closed
+// or by the hub, if the subscriber does not keep up with events.
+type Subscription struct {
+	C <-chan Event
+
+	ch    chan Event
+	topic string
+	hub   *Hub
+	once  sync.Once
+}
+
+func NewHub(bufferSize int) *Hub {
+	return &Hub{
+		subs:   make(map[string]map[*Subscription]struct{}),
+		buffer: make([]Event, max(bufferSize, 1)),
+		gap:    true,
+	}
+}
+
+func (h *Hub) Publish(event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	if h.gap {
+		h.evicted = max(h.evicted, event.ID-1)
+		h.gap = false
+	}
+
+	if h.full {
+		h.evicted = max(h.evicted, h.buffer[h.next].ID)
+	}
+
+	h.buffer[h.next] = event
+	h.next = (h.next + 1) % len(h.buffer)
+	h.full = h.full || h.next == 0
+
+	for sub := range h.subs[event.Topic] {
+		select {
+		case sub.ch <- event:
+		default:
+			// Slow subscriber is dropped to resume later instead of blocking everyone.
+			h.remove(sub)
+		}
+	}
+}
+
+// Subscribe replays buffered events of the topic after lastID (if it is positive) and subscribes
+// to the following ones atomically, so that no events are missed in between.
+// Complete is false if some events after lastID were already evicted from the buffer or missed by the hub.
+func (h *Hub) Subscribe(topic string, lastID int64, size int) (sub *Subscription, replay []Event, complete bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan Event, size)
+	sub = &Subscription{C: ch, ch: ch, topic: topic, hub: h}
+
+	if h.closed {
+		close(ch)
+		return sub, nil, false
+	}
+
+	if h.subs[topic] == nil {
+		h.subs[topic] = make(map[*Subscription]struct{})
+	}
+
+	h.subs[topic][sub] = struct{}{}
+
+	if lastID <= 0 {
+		return sub, nil, true
+	}
+
+	start, count := 0, h.next
+	if h.full {
+		start, count = h.next, len(h.buffer)
+	}
+
+	for i := range count {
+		event := h.buffer[(start+i)%len(h.buffer)]
+		if event.ID > lastID && event.Topic == topic {
+			replay = append(replay, event)
+		}
+	}
+
+	return sub, replay, !h.gap && lastID >= h.evicted
+}
+
+// MarkGap tells the hub that events might have been missed by it, so resuming subscribers
+// are not able to receive all the events after their last ones until the next event is published.
+func (h *Hub) MarkGap() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.gap = true
+}
+
+// Close closes all subscriptions, no events are published after that.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+
+	for _, subs := range h.subs {
+		for sub := range subs {
+			h.remove(sub)
+		}
+	}
+}
+
+func (h *Hub) remove(sub *Subscription) {
+	sub.once.Do(func() {
+		delete(h.subs[sub.topic], sub)
+		if len(h.subs[sub.topic]) == 0 {
+			delete(h.subs, sub.topic)
+		}
+
+		close(sub.ch)
+	})
+}
+
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s)
+}
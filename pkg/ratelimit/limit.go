@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit describes a token bucket: Requests tokens are refilled evenly over Period
+// and at most Burst tokens (Requests by default) can be accumulated.
+type Limit struct {
+	Requests int           `yaml:"requests"`
+	Period   time.Duration `yaml:"period"`
+	Burst    int           `yaml:"burst"`
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (*Result, error)
+}
+
+func (l Limit) Valid() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+
+	return l.Requests
+}
+
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+func (l Limit) durationFor(tokens float64) time.Duration {
+	return time.Duration(math.Ceil(tokens / l.rate() * float64(time.Second)))
+}
+
+// take refills the bucket for the elapsed time and tries to take a single token from it.
+func (l Limit) take(tokens float64, elapsed time.Duration) (float64, *Result) {
+	capacity := float64(l.Capacity())
+	tokens = math.Min(capacity, tokens+max(elapsed, 0).Seconds()*l.rate())
+
+	res := Result{Limit: l.Capacity()}
+
+	if tokens >= 1 {
+		tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = l.durationFor(1 - tokens)
+	}
+
+	res.Remaining = int(tokens)
+	res.Reset = l.durationFor(capacity - tokens)
+
+	return tokens, &res
+}
@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"subs-service/pkg/database/postgres"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresStore keeps buckets in the rate_limit_buckets table, so that
+// all service replicas share the same quotas.
+type PostgresStore struct {
+	pool *pgxpool.Pool
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewPostgresStore(pool *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{
+		pool:      pool,
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, limit Limit) (*Result, error) {
+	const op = "PostgresStore.Take"
+
+	s.sweep(ctx)
+
+	insertQuery :=
+		`INSERT INTO rate_limit_buckets (key, tokens, updated_at)
+			VALUES ($1, $2, clock_timestamp()) ON CONFLICT (key) DO NOTHING`
+
+	selectQuery :=
+		`SELECT tokens, EXTRACT(EPOCH FROM clock_timestamp() - updated_at)::float8
+			FROM rate_limit_buckets WHERE key = $1 FOR UPDATE`
+
+	updateQuery :=
+		`UPDATE rate_limit_buckets SET tokens = $1, updated_at = clock_timestamp(),
+			expires_at = clock_timestamp() + $2::interval WHERE key = $3`
+
+	var res *Result
+
+	err := postgres.WithTx(ctx, s.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, insertQuery, key, float64(limit.Capacity())); err != nil {
+			return err
+		}
+
+		var tokens, elapsed float64
+		if err := tx.QueryRow(ctx, selectQuery, key).Scan(&tokens, &elapsed); err != nil {
+			return err
+		}
+
+		tokens, res = limit.take(tokens, time.Duration(elapsed*float64(time.Second)))
+
+		_, err := tx.Exec(ctx, updateQuery, tokens, res.Reset, key)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
+
+func (s *PostgresStore) sweep(ctx context.Context) {
+	s.mu.Lock()
+	if time.Since(s.lastSweep) < sweepInterval {
+		s.mu.Unlock()
+		return
+	}
+
+	s.lastSweep = time.Now()
+	s.mu.Unlock()
+
+	if _, err := s.pool.Exec(ctx, "DELETE FROM rate_limit_buckets WHERE expires_at < now()"); err != nil {
+		log.Printf("[WARN] Failed to sweep rate limit buckets: %s", err.Error())
+	}
+}
@@ -0,0 +1,28 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RunPeriodically calls fn every interval until ctx is done. Errors are logged,
+// so that a single failure does not stop the worker.
+func RunPeriodically(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("[INFO] Worker %s started (interval %s)", name, interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("[INFO] Worker %s stopped", name)
+			return
+		case <-ticker.C:
+			if err := fn(ctx); err != nil {
+				log.Printf("[ERROR] Worker %s failed: %s", name, err.Error())
+			}
+		}
+	}
+}
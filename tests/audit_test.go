@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type AuditEntry struct {
+	ID        int64           `json:"id"`
+	SubID     string          `json:"sub_id"`
+	Actor     string          `json:"actor"`
+	RequestID string          `json:"request_id"`
+	Operation string          `json:"operation"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+}
+
+type ListAuditResponse struct {
+	Entries       []AuditEntry `json:"entries"`
+	NextPageToken string       `json:"next_page_token"`
+}
+
+func TestAuditAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+	userID := uuid.New().String()
+	startedAt := time.Now().Add(-time.Minute)
+
+	newSub := Sub{
+		UserID:      userID,
+		ServiceName: "Spotify",
+		Price:       300,
+		StartDate:   time.Now().Format(TimeLayout),
+	}
+
+	body, _ := json.Marshal(newSub)
+	resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var createdSub Sub
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&createdSub))
+	resp.Body.Close()
+
+	newSub.Price = 400
+	body, _ = json.Marshal(newSub)
+	req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/subs/%s", apiBaseURL, createdSub.ID), bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	req, _ = http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/subs/%s", apiBaseURL, createdSub.ID), nil)
+	req.Header.Set("X-Request-Id", "audit-test-delete")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	t.Run("GET /subs/{id}/history - Get Subscription History", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/subs/%s/history", apiBaseURL, createdSub.ID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var history []AuditEntry
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+		require.Len(t, history, 3)
+
+		assert.Equal(t, "create", history[0].Operation)
+		assert.Empty(t, history[0].Before)
+		assert.Equal(t, "update", history[1].Operation)
+		assert.NotEmpty(t, history[1].Before)
+		assert.NotEmpty(t, history[1].After)
+		assert.Equal(t, "delete", history[2].Operation)
+		assert.Empty(t, history[2].After)
+		assert.Equal(t, "audit-test-delete", history[2].RequestID)
+	})
+
+	t.Run("GET /audit - Query Audit Log", func(t *testing.T) {
+		url := fmt.Sprintf("%s/audit?user_id=%s&from=%s&page_size=2",
+			apiBaseURL, userID, startedAt.UTC().Format(time.RFC3339))
+		resp, err := http.Get(url)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var listResp ListAuditResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&listResp))
+		assert.Len(t, listResp.Entries, 2)
+		assert.NotEmpty(t, listResp.NextPageToken)
+	})
+}
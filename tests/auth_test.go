@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"subs-service/pkg/http/middleware"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuth(t *testing.T) {
+	// The running service trusts all requests as admin ones, so the configurations are checked in-process.
+	status := func(t *testing.T, cfg middleware.AuthConfig, guard func(http.Handler) http.Handler, apiKey string) int {
+		cfg.Header = "X-API-Key"
+		cfg.Keys = []middleware.APIKey{
+			{Key: "admin-key", Subject: "admin", Admin: true},
+			{Key: "user-key", Subject: "user"},
+			{Key: "partner-admin-key", Subject: "partner-admin", Admin: true, Tenant: "partner"},
+		}
+
+		handler := middleware.Auth(cfg)(guard(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})))
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		if len(apiKey) != 0 {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	adminStatus := func(t *testing.T, cfg middleware.AuthConfig, apiKey string) int {
+		return status(t, cfg, middleware.RequireAdmin, apiKey)
+	}
+
+	t.Run("Failure - 403 Forbidden (anonymous request with authentication disabled)", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, adminStatus(t, middleware.AuthConfig{}, ""))
+		assert.Equal(t, http.StatusForbidden, adminStatus(t, middleware.AuthConfig{}, "unknown-key"))
+		assert.Equal(t, http.StatusForbidden, adminStatus(t, middleware.AuthConfig{}, "user-key"))
+	})
+
+	t.Run("Success - admin key with authentication disabled", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, adminStatus(t, middleware.AuthConfig{}, "admin-key"))
+	})
+
+	t.Run("Success - all requests are trusted as admin ones", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, adminStatus(t, middleware.AuthConfig{TrustAllAsAdmin: true}, ""))
+	})
+
+	t.Run("Failure - trust is ignored with authentication enabled", func(t *testing.T) {
+		cfg := middleware.AuthConfig{Enabled: true, TrustAllAsAdmin: true}
+
+		assert.Equal(t, http.StatusUnauthorized, adminStatus(t, cfg, ""))
+		assert.Equal(t, http.StatusForbidden, adminStatus(t, cfg, "user-key"))
+		assert.Equal(t, http.StatusOK, adminStatus(t, cfg, "admin-key"))
+	})
+
+	t.Run("Failure - 403 Forbidden (operator routes with admin key bound to tenant)", func(t *testing.T) {
+		cfg := middleware.AuthConfig{Enabled: true}
+
+		assert.Equal(t, http.StatusOK, adminStatus(t, cfg, "partner-admin-key"))
+		assert.Equal(t, http.StatusForbidden, status(t, cfg, middleware.RequireOperator, "partner-admin-key"))
+		assert.Equal(t, http.StatusForbidden, status(t, cfg, middleware.RequireOperator, "user-key"))
+		assert.Equal(t, http.StatusOK, status(t, cfg, middleware.RequireOperator, "admin-key"))
+	})
+}
@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Budget struct {
+	ID          string `json:"id,omitempty"`
+	UserID      string `json:"user_id,omitempty"`
+	ServiceName string `json:"service_name,omitempty"`
+	Amount      int64  `json:"amount"`
+	Thresholds  []int  `json:"thresholds,omitempty"`
+}
+
+type BudgetStatus struct {
+	Budget      Budget  `json:"budget"`
+	Month       string  `json:"month"`
+	Spend       int64   `json:"spend"`
+	Remaining   int64   `json:"remaining"`
+	Utilization float64 `json:"utilization"`
+	Reached     []int   `json:"reached_thresholds"`
+}
+
+func postBudget(t *testing.T, apiBaseURL string, budget Budget) (int, Budget) {
+	t.Helper()
+
+	body, _ := json.Marshal(budget)
+	resp, err := http.Post(apiBaseURL+"/budgets", "application/json", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var created Budget
+	if resp.StatusCode == http.StatusCreated {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	}
+
+	return resp.StatusCode, created
+}
+
+func getBudgetStatus(t *testing.T, apiBaseURL, id string) BudgetStatus {
+	t.Helper()
+
+	resp, err := http.Get(fmt.Sprintf("%s/budgets/%s/status", apiBaseURL, id))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var status BudgetStatus
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+
+	return status
+}
+
+func TestBudgetsAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+	userID := uuid.New().String()
+
+	newSub := Sub{
+		UserID:      userID,
+		ServiceName: "Netflix",
+		Price:       900,
+		StartDate:   "01-2020",
+	}
+
+	body, _ := json.Marshal(newSub)
+	resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var overall Budget
+
+	t.Run("Success - create overall budget with default thresholds", func(t *testing.T) {
+		var code int
+		code, overall = postBudget(t, apiBaseURL, Budget{UserID: userID, Amount: 1000})
+
+		require.Equal(t, http.StatusCreated, code)
+		assert.NotEmpty(t, overall.ID)
+		assert.Equal(t, []int{80, 100}, overall.Thresholds)
+	})
+
+	t.Run("Success - status of overall budget", func(t *testing.T) {
+		status := getBudgetStatus(t, apiBaseURL, overall.ID)
+
+		assert.Equal(t, int64(900), status.Spend)
+		assert.Equal(t, int64(100), status.Remaining)
+		assert.InEpsilon(t, 90.0, status.Utilization, 0.001)
+		assert.Equal(t, []int{80}, status.Reached)
+	})
+
+	t.Run("Success - per service budget counts only its service", func(t *testing.T) {
+		code, budget := postBudget(t, apiBaseURL, Budget{UserID: userID, ServiceName: "Spotify", Amount: 500, Thresholds: []int{50}})
+		require.Equal(t, http.StatusCreated, code)
+
+		status := getBudgetStatus(t, apiBaseURL, budget.ID)
+
+		assert.Zero(t, status.Spend)
+		assert.Empty(t, status.Reached)
+	})
+
+	t.Run("Success - list user's budgets", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/budgets?user_id=%s", apiBaseURL, userID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var budgets []Budget
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&budgets))
+		assert.Len(t, budgets, 2)
+	})
+
+	t.Run("Success - update budget", func(t *testing.T) {
+		body, _ := json.Marshal(Budget{Amount: 800, Thresholds: []int{100}})
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/budgets/%s", apiBaseURL, overall.ID), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		status := getBudgetStatus(t, apiBaseURL, overall.ID)
+		assert.Equal(t, userID, status.Budget.UserID)
+		assert.Equal(t, int64(-100), status.Remaining)
+		assert.Equal(t, []int{100}, status.Reached)
+	})
+
+	t.Run("Failure - 400 Bad Request (invalid budgets)", func(t *testing.T) {
+		for _, budget := range []Budget{
+			{Amount: 1000},
+			{UserID: userID, Amount: 0},
+			{UserID: userID, Amount: 1000, Thresholds: []int{0}},
+		} {
+			code, _ := postBudget(t, apiBaseURL, budget)
+			assert.Equal(t, http.StatusBadRequest, code)
+		}
+	})
+
+	t.Run("Success - delete budget", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/budgets/%s", apiBaseURL, overall.ID), nil)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, err = http.Get(fmt.Sprintf("%s/budgets/%s/status", apiBaseURL, overall.ID))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,251 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CacheStats struct {
+	Enabled bool   `json:"enabled"`
+	Hits    uint64 `json:"hits"`
+	Misses  uint64 `json:"misses"`
+}
+
+func TestCacheAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+	owner, member := uuid.New().String(), uuid.New().String()
+
+	send := func(t *testing.T, method, url string, body any) *http.Response {
+		var data []byte
+		if body != nil {
+			data, _ = json.Marshal(body)
+		}
+
+		req, _ := http.NewRequest(method, url, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	getSummary := func(t *testing.T, userID, etag string) (*http.Response, Summary) {
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/subs/summary?user_id=%s", apiBaseURL, userID), nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var sum Summary
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&sum))
+		}
+
+		return resp, sum
+	}
+
+	getStats := func(t *testing.T) CacheStats {
+		resp, err := http.Get(apiBaseURL + "/cache/stats")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var stats CacheStats
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+
+		return stats
+	}
+
+	sub := Sub{UserID: owner, ServiceName: "Cached " + uuid.NewString()[:8], Price: 1000, StartDate: "01-2025"}
+
+	resp := send(t, http.MethodPost, apiBaseURL+"/subs", sub)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&sub))
+	resp.Body.Close()
+
+	var etag string
+
+	t.Run("Success - summary has ETag and Cache-Control", func(t *testing.T) {
+		resp, sum := getSummary(t, owner, "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		etag = resp.Header.Get("ETag")
+		assert.NotEmpty(t, etag)
+		assert.Contains(t, resp.Header.Get("Cache-Control"), "no-cache")
+		assert.Equal(t, 1000, sum.TotalPrice)
+	})
+
+	t.Run("Success - 304 Not Modified for matching If-None-Match", func(t *testing.T) {
+		resp, _ := getSummary(t, owner, etag)
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+		assert.Equal(t, etag, resp.Header.Get("ETag"))
+
+		resp, _ = getSummary(t, owner, `"other", W/`+etag)
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	})
+
+	t.Run("Success - list has ETag", func(t *testing.T) {
+		url := fmt.Sprintf("%s/subs?user_id=%s", apiBaseURL, owner)
+
+		resp := send(t, http.MethodGet, url, nil)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		listETag := resp.Header.Get("ETag")
+		require.NotEmpty(t, listETag)
+		assert.NotEqual(t, etag, listETag)
+
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("If-None-Match", listETag)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	})
+
+	t.Run("Success - repeated summary is served by the cache", func(t *testing.T) {
+		before := getStats(t)
+		if !before.Enabled {
+			t.Skip("cache is disabled")
+		}
+
+		getSummary(t, owner, "")
+
+		after := getStats(t)
+		assert.Greater(t, after.Hits, before.Hits)
+	})
+
+	t.Run("Success - update of subscription invalidates summary", func(t *testing.T) {
+		sub.Price = 1200
+
+		resp := send(t, http.MethodPut, fmt.Sprintf("%s/subs/%s", apiBaseURL, sub.ID), sub)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, sum := getSummary(t, owner, etag)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, 1200, sum.TotalPrice)
+		assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+	})
+
+	t.Run("Success - changes of shared subscription invalidate members' summaries", func(t *testing.T) {
+		resp := send(t, http.MethodPut, fmt.Sprintf("%s/subs/%s/members/%s", apiBaseURL, sub.ID, member), Member{Weight: 1})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		_, sum := getSummary(t, member, "")
+		assert.Equal(t, 600, sum.TotalPrice)
+
+		sub.Price = 1600
+
+		resp = send(t, http.MethodPut, fmt.Sprintf("%s/subs/%s", apiBaseURL, sub.ID), sub)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		_, sum = getSummary(t, member, "")
+		assert.Equal(t, 800, sum.TotalPrice)
+
+		resp = send(t, http.MethodDelete, fmt.Sprintf("%s/subs/%s", apiBaseURL, sub.ID), nil)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		_, sum = getSummary(t, member, "")
+		assert.Equal(t, 0, sum.TotalPrice)
+	})
+
+	t.Run("Success - erasure of user's data invalidates summaries", func(t *testing.T) {
+		erased, member := uuid.New().String(), uuid.New().String()
+
+		shared := Sub{UserID: erased, ServiceName: "Erased " + uuid.NewString()[:8], Price: 1000, StartDate: "01-2025"}
+
+		resp := send(t, http.MethodPost, apiBaseURL+"/subs", shared)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&shared))
+		resp.Body.Close()
+
+		resp = send(t, http.MethodPut, fmt.Sprintf("%s/subs/%s/members/%s", apiBaseURL, shared.ID, member), Member{Weight: 1})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		// Both summaries are cached before the erasure.
+		etags := map[string]string{}
+
+		for userID, total := range map[string]int{erased: 500, member: 500} {
+			for range 2 {
+				resp, sum := getSummary(t, userID, "")
+				require.Equal(t, http.StatusOK, resp.StatusCode)
+				require.Equal(t, total, sum.TotalPrice)
+
+				etags[userID] = resp.Header.Get("ETag")
+			}
+		}
+
+		resp = send(t, http.MethodDelete, fmt.Sprintf("%s/users/%s", apiBaseURL, erased), nil)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		for userID, etag := range etags {
+			resp, sum := getSummary(t, userID, etag)
+			require.Equal(t, http.StatusOK, resp.StatusCode, userID)
+
+			assert.Equal(t, 0, sum.TotalPrice, userID)
+		}
+	})
+
+	t.Run("Success - change of the catalog invalidates summaries", func(t *testing.T) {
+		userID, suffix := uuid.New().String(), uuid.NewString()[:8]
+		alias := "cached-" + suffix
+
+		code, service := postService(t, apiBaseURL, CatalogService{Name: "Cached Service " + suffix})
+		require.Equal(t, http.StatusCreated, code)
+
+		resp := send(t, http.MethodPost, apiBaseURL+"/subs",
+			Sub{UserID: userID, ServiceName: service.Name, Price: 300, StartDate: "01-2025"})
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		summaryURL := apiBaseURL + "/subs/summary?" + url.Values{"user_id": {userID}, "service_name": {alias}}.Encode()
+
+		getTotal := func(t *testing.T) int {
+			resp := send(t, http.MethodGet, summaryURL, nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var sum Summary
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&sum))
+
+			return sum.TotalPrice
+		}
+
+		// The summary by the alias is cached before the alias is added.
+		for range 2 {
+			require.Equal(t, 0, getTotal(t))
+		}
+
+		service.Aliases = []string{alias}
+
+		resp = send(t, http.MethodPut, fmt.Sprintf("%s/services/%s", apiBaseURL, service.ID), service)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, 300, getTotal(t))
+	})
+}
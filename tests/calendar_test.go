@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FeedToken struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+func TestCalendarAPI(t *testing.T) {
+	baseURL := fmt.Sprintf("http://%s", os.Getenv("HTTP_ADDRESS"))
+	apiBaseURL := baseURL + "/api/v1"
+	userID := uuid.New().String()
+	tokenURL := fmt.Sprintf("%s/users/%s/calendar/token", apiBaseURL, userID)
+
+	newSub := Sub{
+		UserID:      userID,
+		ServiceName: "Netflix",
+		Price:       1000,
+		StartDate:   "07-2025",
+		EndDate:     "07-2099",
+	}
+
+	body, _ := json.Marshal(newSub)
+	resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var createdSub Sub
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&createdSub))
+	resp.Body.Close()
+
+	resp, err = http.Post(tokenURL, "application/json", nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var token FeedToken
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&token))
+	resp.Body.Close()
+
+	require.NotEmpty(t, token.Token)
+
+	t.Run("Success - feed contains charges and end of subscription", func(t *testing.T) {
+		resp, err := http.Get(baseURL + token.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "text/calendar")
+
+		feed, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(feed), "BEGIN:VCALENDAR\r\n")
+		assert.Contains(t, string(feed), fmt.Sprintf("UID:%s-charge@subs-service", createdSub.ID))
+		assert.Contains(t, string(feed), "DTSTART;VALUE=DATE:20250701")
+		assert.Contains(t, string(feed), "RRULE:FREQ=MONTHLY;UNTIL=20990601")
+		assert.Contains(t, string(feed), fmt.Sprintf("UID:%s-end@subs-service", createdSub.ID))
+		assert.Contains(t, string(feed), "charged 1000 monthly")
+	})
+
+	t.Run("Failure - 403 Forbidden (invalid token)", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/users/%s/calendar.ics?token=invalid", apiBaseURL, userID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("Failure - 403 Forbidden (token of another user)", func(t *testing.T) {
+		url := fmt.Sprintf("%s/users/%s/calendar.ics?token=%s", apiBaseURL, uuid.New(), token.Token)
+
+		resp, err := http.Get(url)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("Success - revoked token is rejected", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, tokenURL, nil)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp, err = http.Get(baseURL + token.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("Failure - 404 Not Found (no token to revoke)", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, tokenURL, nil)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CatalogService struct {
+	ID       string   `json:"id,omitempty"`
+	Name     string   `json:"name"`
+	Aliases  []string `json:"aliases"`
+	Category string   `json:"category,omitempty"`
+	Currency string   `json:"currency,omitempty"`
+	Website  string   `json:"website,omitempty"`
+}
+
+func postService(t *testing.T, apiBaseURL string, service CatalogService) (int, CatalogService) {
+	t.Helper()
+
+	body, _ := json.Marshal(service)
+	resp, err := http.Post(apiBaseURL+"/services", "application/json", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var created CatalogService
+	if resp.StatusCode == http.StatusCreated {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	}
+
+	return resp.StatusCode, created
+}
+
+func TestCatalogAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+	userID := uuid.New().String()
+
+	// Catalog is shared by all users, so names are unique for every run.
+	suffix := uuid.New().String()[:8]
+	name := "Netflix " + suffix
+	alias := "nflx-" + suffix
+
+	var service CatalogService
+
+	t.Run("Success - add service", func(t *testing.T) {
+		var code int
+		code, service = postService(t, apiBaseURL, CatalogService{
+			Name:     name,
+			Aliases:  []string{alias, strings.ToUpper(alias)},
+			Category: "streaming",
+			Website:  "https://www.netflix.com",
+		})
+
+		require.Equal(t, http.StatusCreated, code)
+		assert.Equal(t, []string{alias}, service.Aliases)
+		assert.Equal(t, "RUB", service.Currency)
+	})
+
+	t.Run("Failure - 409 Conflict (alias of another service)", func(t *testing.T) {
+		code, _ := postService(t, apiBaseURL, CatalogService{Name: "Other " + suffix, Aliases: []string{alias}})
+		assert.Equal(t, http.StatusConflict, code)
+	})
+
+	t.Run("Failure - 400 Bad Request (invalid currency)", func(t *testing.T) {
+		code, _ := postService(t, apiBaseURL, CatalogService{Name: "Other " + suffix, Currency: "rubles"})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	var created Sub
+
+	t.Run("Success - subscription is linked by alias", func(t *testing.T) {
+		body, _ := json.Marshal(Sub{
+			UserID:      userID,
+			ServiceName: " " + strings.ToUpper(alias) + " ",
+			Price:       700,
+			StartDate:   "01-2025",
+		})
+
+		resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+
+		assert.Equal(t, name, created.ServiceName)
+		assert.Equal(t, service.ID, created.ServiceID)
+	})
+
+	t.Run("Success - summary by any name of the service", func(t *testing.T) {
+		for _, serviceName := range []string{name, alias} {
+			query := url.Values{"user_id": {userID}, "service_name": {serviceName}}
+
+			resp, err := http.Get(apiBaseURL + "/subs/summary?" + query.Encode())
+			require.NoError(t, err)
+
+			var summary Summary
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&summary))
+			resp.Body.Close()
+
+			assert.Equal(t, 700, summary.TotalPrice, serviceName)
+		}
+	})
+
+	t.Run("Success - delete service unlinks subscriptions", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/services/%s", apiBaseURL, service.ID), nil)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, err = http.Get(fmt.Sprintf("%s/subs/%s", apiBaseURL, created.ID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var sub Sub
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&sub))
+
+		assert.Equal(t, name, sub.ServiceName)
+		assert.Empty(t, sub.ServiceID)
+	})
+
+	t.Run("Failure - 404 Not Found (deleted service)", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/services/%s", apiBaseURL, service.ID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
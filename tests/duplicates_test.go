@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Duplicate struct {
+	ServiceName string `json:"service_name"`
+	Subs        []Sub  `json:"subs"`
+	OverlapFrom string `json:"overlap_from"`
+	OverlapTo   string `json:"overlap_to"`
+	WastedSpend int64  `json:"wasted_spend"`
+}
+
+type DuplicatesReport struct {
+	UserID      string      `json:"user_id"`
+	WastedSpend int64       `json:"wasted_spend"`
+	Duplicates  []Duplicate `json:"duplicates"`
+}
+
+func TestDuplicatesAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+	userID := uuid.New().String()
+
+	// Names are matched without case and surrounding spaces, end dates are exclusive.
+	subs := []Sub{
+		{UserID: userID, ServiceName: "Netflix", Price: 500, StartDate: "01-2020"},
+		{UserID: userID, ServiceName: " netflix ", Price: 300, StartDate: "06-2020", EndDate: "09-2020"},
+		{UserID: userID, ServiceName: "Spotify", Price: 200, StartDate: "01-2020", EndDate: "03-2020"},
+		{UserID: userID, ServiceName: "Spotify", Price: 200, StartDate: "03-2020"},
+	}
+
+	for _, sub := range subs {
+		body, _ := json.Marshal(sub)
+		resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	t.Run("Success - overlapping subscriptions with wasted spend", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/subs/duplicates?user_id=%s", apiBaseURL, userID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var report DuplicatesReport
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+
+		require.Len(t, report.Duplicates, 1)
+		assert.Equal(t, int64(900), report.WastedSpend)
+
+		duplicate := report.Duplicates[0]
+		assert.Equal(t, "06-2020", duplicate.OverlapFrom)
+		assert.Equal(t, "09-2020", duplicate.OverlapTo)
+		assert.Equal(t, int64(900), duplicate.WastedSpend)
+		assert.Len(t, duplicate.Subs, 2)
+	})
+
+	t.Run("Success - no duplicates", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/subs/duplicates?user_id=%s", apiBaseURL, uuid.New()))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var report DuplicatesReport
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+
+		assert.Empty(t, report.Duplicates)
+		assert.Zero(t, report.WastedSpend)
+	})
+
+	t.Run("Failure - 400 Bad Request (invalid user id)", func(t *testing.T) {
+		resp, err := http.Get(apiBaseURL + "/subs/duplicates?user_id=abc")
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ServiceSpend struct {
+	ServiceName string `json:"service_name"`
+	Amount      int64  `json:"amount"`
+}
+
+type MonthForecast struct {
+	Month    string         `json:"month"`
+	Total    int64          `json:"total"`
+	Services []ServiceSpend `json:"services"`
+}
+
+type Forecast struct {
+	UserID string          `json:"user_id"`
+	Total  int64           `json:"total"`
+	Months []MonthForecast `json:"months"`
+}
+
+func TestForecastAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+	userID := uuid.New().String()
+
+	now := time.Now().UTC()
+	month := func(offset int) string {
+		return time.Date(now.Year(), now.Month()+time.Month(offset), 1, 0, 0, 0, 0, time.UTC).Format("01-2006")
+	}
+
+	// Ends after the next month, so it is charged only once in the forecast.
+	subs := []Sub{
+		{UserID: userID, ServiceName: "Netflix", Price: 500, StartDate: "01-2020", EndDate: month(2)},
+		{UserID: userID, ServiceName: "Spotify", Price: 200, StartDate: month(2)},
+	}
+
+	for _, sub := range subs {
+		body, _ := json.Marshal(sub)
+		resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	t.Run("Success - per month totals with services breakdown", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/subs/forecast?user_id=%s&months=3", apiBaseURL, userID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var forecast Forecast
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&forecast))
+
+		require.Len(t, forecast.Months, 3)
+		assert.Equal(t, int64(900), forecast.Total)
+
+		assert.Equal(t, month(1), forecast.Months[0].Month)
+		assert.Equal(t, int64(500), forecast.Months[0].Total)
+		assert.Equal(t, []ServiceSpend{{ServiceName: "Netflix", Amount: 500}}, forecast.Months[0].Services)
+
+		assert.Equal(t, month(2), forecast.Months[1].Month)
+		assert.Equal(t, int64(200), forecast.Months[1].Total)
+		assert.Equal(t, []ServiceSpend{{ServiceName: "Spotify", Amount: 200}}, forecast.Months[1].Services)
+
+		assert.Equal(t, int64(200), forecast.Months[2].Total)
+	})
+
+	t.Run("Success - no subscriptions", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/subs/forecast?user_id=%s&months=2", apiBaseURL, uuid.New()))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var forecast Forecast
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&forecast))
+
+		require.Len(t, forecast.Months, 2)
+		assert.Zero(t, forecast.Total)
+		assert.Empty(t, forecast.Months[0].Services)
+	})
+
+	t.Run("Failure - 400 Bad Request (invalid months)", func(t *testing.T) {
+		for _, months := range []string{"0", "-1", "abc", "1000"} {
+			resp, err := http.Get(fmt.Sprintf("%s/subs/forecast?user_id=%s&months=%s", apiBaseURL, userID, months))
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, months)
+		}
+	})
+}
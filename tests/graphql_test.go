@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Extensions map[string]any `json:"extensions"`
+}
+
+type GraphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []GraphQLError  `json:"errors"`
+}
+
+func postGraphQL(t *testing.T, url, query string, variables map[string]any) (int, GraphQLResponse) {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]any{"query": query, "variables": variables})
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var res GraphQLResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+
+	return resp.StatusCode, res
+}
+
+func TestGraphQLAPI(t *testing.T) {
+	graphqlURL := fmt.Sprintf("http://%s/api/v1/graphql", os.Getenv("HTTP_ADDRESS"))
+	userID := uuid.New().String()
+
+	createMutation := `mutation($input: SubInput!) { createSub(input: $input) { id serviceName price } }`
+
+	var ids []string
+
+	t.Run("Mutation createSub - Success", func(t *testing.T) {
+		for _, name := range []string{"Netflix", "Spotify", "Okko"} {
+			code, res := postGraphQL(t, graphqlURL, createMutation, map[string]any{"input": map[string]any{
+				"userId":      userID,
+				"serviceName": name,
+				"price":       100,
+				"startDate":   "01-2025",
+				"endDate":     "03-2025",
+			}})
+			require.Equal(t, http.StatusOK, code)
+			require.Empty(t, res.Errors)
+
+			var data struct {
+				CreateSub struct {
+					ID string `json:"id"`
+				} `json:"createSub"`
+			}
+			require.NoError(t, json.Unmarshal(res.Data, &data))
+
+			ids = append(ids, data.CreateSub.ID)
+		}
+	})
+
+	t.Run("Mutation createSub - BAD_USER_INPUT (bad price)", func(t *testing.T) {
+		_, res := postGraphQL(t, graphqlURL, createMutation, map[string]any{"input": map[string]any{
+			"userId":      userID,
+			"serviceName": "Netflix",
+			"price":       -1,
+			"startDate":   "01-2025",
+		}})
+
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, "BAD_USER_INPUT", res.Errors[0].Extensions["code"])
+	})
+
+	t.Run("Query subs and summaries in one round-trip", func(t *testing.T) {
+		query := `query($userId: ID!, $after: String) {
+			subs(userId: $userId, first: 2, after: $after) {
+				edges { cursor node { id serviceName } }
+				pageInfo { hasNextPage endCursor }
+			}
+			total: summary(userId: $userId) { totalPrice }
+			netflix: summary(userId: $userId, serviceName: "Netflix", from: "01-2025", to: "12-2025") { totalPrice }
+			january: summary(userId: $userId, from: "01-2025", to: "01-2025") { totalPrice }
+		}`
+
+		type page struct {
+			Subs struct {
+				Edges []struct {
+					Node struct {
+						ID string `json:"id"`
+					} `json:"node"`
+				} `json:"edges"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"subs"`
+			Netflix struct {
+				TotalPrice int `json:"totalPrice"`
+			} `json:"netflix"`
+			January struct {
+				TotalPrice int `json:"totalPrice"`
+			} `json:"january"`
+		}
+
+		code, res := postGraphQL(t, graphqlURL, query, map[string]any{"userId": userID})
+		require.Equal(t, http.StatusOK, code)
+		require.Empty(t, res.Errors)
+
+		var first page
+		require.NoError(t, json.Unmarshal(res.Data, &first))
+
+		assert.Len(t, first.Subs.Edges, 2)
+		assert.True(t, first.Subs.PageInfo.HasNextPage)
+		assert.Equal(t, 200, first.Netflix.TotalPrice)
+		assert.Equal(t, 300, first.January.TotalPrice)
+
+		_, res = postGraphQL(t, graphqlURL, query, map[string]any{"userId": userID, "after": first.Subs.PageInfo.EndCursor})
+		require.Empty(t, res.Errors)
+
+		var second page
+		require.NoError(t, json.Unmarshal(res.Data, &second))
+
+		assert.Len(t, second.Subs.Edges, 1)
+		assert.False(t, second.Subs.PageInfo.HasNextPage)
+	})
+
+	t.Run("Query sub - NOT_FOUND", func(t *testing.T) {
+		_, res := postGraphQL(t, graphqlURL, `query($id: ID!) { sub(id: $id) { id } }`, map[string]any{"id": uuid.New().String()})
+
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", res.Errors[0].Extensions["code"])
+	})
+
+	t.Run("Query - 400 Bad Request (too complex)", func(t *testing.T) {
+		query := `query($userId: ID!) { subs(userId: $userId, first: 100) {
+			edges { node { id userId serviceName price startDate endDate trialMonths promoPrice trialEnd status } }
+		} }`
+
+		code, res := postGraphQL(t, graphqlURL, query, map[string]any{"userId": userID})
+
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.NotEmpty(t, res.Errors)
+	})
+
+	t.Run("Mutation deleteSub - Success", func(t *testing.T) {
+		for _, id := range ids {
+			_, res := postGraphQL(t, graphqlURL, `mutation($id: ID!) { deleteSub(id: $id) }`, map[string]any{"id": id})
+			assert.Empty(t, res.Errors)
+		}
+	})
+}
@@ -0,0 +1,248 @@
+package tests
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	apiGRPC "subs-service/internal/api/grpc"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/usecases"
+	subsv1 "subs-service/pkg/api/subs/v1"
+	"subs-service/pkg/database/postgres"
+	"subs-service/pkg/grpc/interceptors"
+	"subs-service/pkg/http/middleware"
+	"subs-service/pkg/http/server"
+	"subs-service/pkg/ratelimit"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCAPI(t *testing.T) {
+	conn, err := grpc.NewClient(os.Getenv("GRPC_ADDRESS"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := subsv1.NewSubsServiceClient(conn)
+	userID := uuid.New().String()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var created *subsv1.Sub
+
+	t.Run("PostSub - Success", func(t *testing.T) {
+		created, err = client.PostSub(ctx, &subsv1.PostSubRequest{Sub: &subsv1.Sub{
+			UserId:      userID,
+			ServiceName: "Ivi",
+			Price:       250,
+			StartDate:   "01-2025",
+			EndDate:     "04-2025",
+		}})
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, created.GetId())
+		assert.Equal(t, int64(250), created.GetPrice())
+		assert.Equal(t, "04-2025", created.GetEndDate())
+	})
+
+	t.Run("PostSub - InvalidArgument (bad price)", func(t *testing.T) {
+		_, err := client.PostSub(ctx, &subsv1.PostSubRequest{Sub: &subsv1.Sub{
+			UserId:      userID,
+			ServiceName: "Ivi",
+			Price:       -1,
+			StartDate:   "01-2025",
+		}})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("GetSub - Success", func(t *testing.T) {
+		sub, err := client.GetSub(ctx, &subsv1.GetSubRequest{Id: created.GetId()})
+		require.NoError(t, err)
+
+		assert.Equal(t, created.GetServiceName(), sub.GetServiceName())
+	})
+
+	t.Run("GetSub - NotFound", func(t *testing.T) {
+		_, err := client.GetSub(ctx, &subsv1.GetSubRequest{Id: uuid.New().String()})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("PutSub - Success", func(t *testing.T) {
+		sub, err := client.PutSub(ctx, &subsv1.PutSubRequest{Id: created.GetId(), Sub: &subsv1.Sub{
+			UserId:      userID,
+			ServiceName: "Ivi",
+			Price:       300,
+			StartDate:   "01-2025",
+			EndDate:     "04-2025",
+		}})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(300), sub.GetPrice())
+	})
+
+	t.Run("ListSubs - Success", func(t *testing.T) {
+		res, err := client.ListSubs(ctx, &subsv1.ListSubsRequest{UserId: userID})
+		require.NoError(t, err)
+
+		require.Len(t, res.GetSubs(), 1)
+		assert.Equal(t, created.GetId(), res.GetSubs()[0].GetId())
+	})
+
+	t.Run("GetSummary - Success", func(t *testing.T) {
+		sum, err := client.GetSummary(ctx, &subsv1.GetSummaryRequest{UserId: userID, From: "01-2025", To: "12-2025"})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(900), sum.GetTotalPrice())
+	})
+
+	t.Run("DeleteSub - Success", func(t *testing.T) {
+		res, err := client.DeleteSub(ctx, &subsv1.DeleteSubRequest{Id: created.GetId()})
+		require.NoError(t, err)
+
+		assert.Equal(t, created.GetId(), res.GetId())
+
+		_, err = client.GetSub(ctx, &subsv1.GetSubRequest{Id: created.GetId()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+// pinnedSubService reports in the service name whether the call is pinned to the primary database.
+type pinnedSubService struct {
+	usecases.SubService
+}
+
+func (pinnedSubService) GetSub(ctx context.Context, id uuid.UUID, _ domain.GetOpts) (*domain.Sub, error) {
+	return &domain.Sub{ID: id, ServiceName: strconv.FormatBool(postgres.UsesPrimary(ctx))}, nil
+}
+
+func (pinnedSubService) PostSub(_ context.Context, sub *domain.Sub) (*domain.Sub, error) {
+	sub.ID = uuid.New()
+	return sub, nil
+}
+
+func TestGRPCServerSecurity(t *testing.T) {
+	// The server is configured the same way as the service's one, but in-process, since TLS and limits
+	// of the running service can't be changed by the tests.
+	ca := newTestCert(t, "Test CA", nil, 0)
+	cert := newTestCert(t, "server", ca, x509.ExtKeyUsageServerAuth)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, cert.pem, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, cert.keyPEM(t), 0o600))
+
+	tlsCfg, err := server.NewTLSConfig(server.TLSConfig{
+		Enabled:        true,
+		CertFile:       certFile,
+		KeyFile:        keyFile,
+		MinVersion:     "1.2",
+		ReloadInterval: time.Minute,
+	})
+	require.NoError(t, err)
+
+	limiter := middleware.NewRateLimiter(ratelimit.NewMemoryStore(), middleware.RateLimitConfig{
+		Enabled: true,
+		Groups:  map[string]ratelimit.Limit{middleware.GroupRead: {Requests: 4, Period: time.Minute}},
+	}, nil)
+
+	subServer := apiGRPC.NewSubServer(pinnedSubService{}, config.ServiceConfig{}, config.DataConfig{MaxPrice: 1000, MaxServiceNameLength: 50})
+
+	s := apiGRPC.NewServer(subServer, middleware.AuthConfig{Header: "X-API-Key"}, middleware.TenantConfig{Header: "X-Tenant-ID"},
+		grpc.Creds(credentials.NewTLS(tlsCfg)),
+		grpc.ChainUnaryInterceptor(
+			interceptors.RateLimit(limiter, apiGRPC.MethodGroup),
+			interceptors.ReadYourWrites(middleware.ReadYourWritesConfig{
+				Enabled: true, Window: time.Minute, HeaderName: "X-Primary-Until",
+			}, apiGRPC.MethodGroup, postgres.WithPrimary),
+		),
+	)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() { _ = s.Serve(listener) }()
+	t.Cleanup(s.Stop)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	t.Run("Failure - plaintext clients are rejected", func(t *testing.T) {
+		conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = subsv1.NewSubsServiceClient(conn).GetSub(ctx, &subsv1.GetSubRequest{Id: uuid.NewString()})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(
+		credentials.NewTLS(&tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}),
+	))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := subsv1.NewSubsServiceClient(conn)
+	newSub := &subsv1.Sub{UserId: uuid.NewString(), ServiceName: "Pinned", Price: 100, StartDate: "01-2025"}
+
+	t.Run("Success - reads are pinned to the primary after writes", func(t *testing.T) {
+		var header metadata.MD
+
+		sub, err := client.GetSub(ctx, &subsv1.GetSubRequest{Id: uuid.NewString()})
+		require.NoError(t, err)
+		assert.Equal(t, "false", sub.GetServiceName())
+
+		_, err = client.PostSub(ctx, &subsv1.PostSubRequest{Sub: newSub}, grpc.Header(&header))
+		require.NoError(t, err)
+
+		until := header.Get("x-primary-until")
+		require.Len(t, until, 1)
+
+		sub, err = client.GetSub(metadata.AppendToOutgoingContext(ctx, "x-primary-until", until[0]), &subsv1.GetSubRequest{Id: uuid.NewString()})
+		require.NoError(t, err)
+		assert.Equal(t, "true", sub.GetServiceName())
+
+		// Deadlines beyond the window are forged by the client.
+		forever := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+
+		sub, err = client.GetSub(metadata.AppendToOutgoingContext(ctx, "x-primary-until", forever), &subsv1.GetSubRequest{Id: uuid.NewString()})
+		require.NoError(t, err)
+		assert.Equal(t, "false", sub.GetServiceName())
+	})
+
+	t.Run("Failure - ResourceExhausted once the bucket is exhausted", func(t *testing.T) {
+		var header metadata.MD
+
+		// Three tokens of the read group were taken by the previous subtest.
+		_, err := client.GetSub(ctx, &subsv1.GetSubRequest{Id: uuid.NewString()}, grpc.Header(&header))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0"}, header.Get("ratelimit-remaining"))
+
+		_, err = client.GetSub(ctx, &subsv1.GetSubRequest{Id: uuid.NewString()}, grpc.Header(&header))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.NotEmpty(t, header.Get("retry-after"))
+
+		// Writes are limited separately, the write group has no limit here.
+		_, err = client.PostSub(ctx, &subsv1.PostSubRequest{Sub: newSub})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Job struct {
+	ID       string          `json:"id"`
+	Type     string          `json:"type"`
+	Status   string          `json:"status"`
+	Progress int             `json:"progress"`
+	Result   json.RawMessage `json:"result"`
+	Error    string          `json:"error"`
+}
+
+func TestJobsAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+
+	requestExport := func(t *testing.T) string {
+		resp, err := http.Post(fmt.Sprintf("%s/users/%s/data-export", apiBaseURL, uuid.NewString()), "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		var export struct {
+			ID    string `json:"id"`
+			JobID string `json:"job_id"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&export))
+		require.NotEmpty(t, export.JobID)
+
+		return export.JobID
+	}
+
+	getJob := func(t *testing.T, id string) (Job, int) {
+		resp, err := http.Get(fmt.Sprintf("%s/jobs/%s", apiBaseURL, id))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var job Job
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&job))
+		}
+
+		return job, resp.StatusCode
+	}
+
+	cancelJob := func(t *testing.T, id string) (Job, int) {
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/jobs/%s", apiBaseURL, id), nil)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var job Job
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&job))
+		}
+
+		return job, resp.StatusCode
+	}
+
+	t.Run("Success - job is completed with result", func(t *testing.T) {
+		id := requestExport(t)
+
+		var job Job
+
+		require.Eventually(t, func() bool {
+			var code int
+			job, code = getJob(t, id)
+
+			return code == http.StatusOK && (job.Status == "completed" || job.Status == "failed")
+		}, 30*time.Second, 500*time.Millisecond)
+
+		assert.Equal(t, "data_export", job.Type)
+		assert.Equal(t, "completed", job.Status, job.Error)
+		assert.Equal(t, 100, job.Progress)
+		assert.Contains(t, string(job.Result), "export_id")
+
+		_, code := cancelJob(t, id)
+		assert.Equal(t, http.StatusConflict, code)
+	})
+
+	t.Run("Success - unfinished job is cancelled", func(t *testing.T) {
+		id := requestExport(t)
+
+		// The job may be completed before it is cancelled.
+		job, code := cancelJob(t, id)
+		if code == http.StatusConflict {
+			t.Skip("job was completed before cancellation")
+		}
+
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "cancelled", job.Status)
+
+		job, _ = getJob(t, id)
+		assert.Equal(t, "cancelled", job.Status)
+	})
+
+	t.Run("Failure - 404 Not Found", func(t *testing.T) {
+		_, code := getJob(t, uuid.NewString())
+		assert.Equal(t, http.StatusNotFound, code)
+
+		_, code = cancelJob(t, uuid.NewString())
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("Failure - 400 Bad Request (invalid id)", func(t *testing.T) {
+		_, code := getJob(t, "not-a-uuid")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
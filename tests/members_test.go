@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Member struct {
+	SubID  string `json:"sub_id,omitempty"`
+	UserID string `json:"user_id,omitempty"`
+	Weight int    `json:"weight,omitempty"`
+	Amount int64  `json:"amount,omitempty"`
+}
+
+type Debt struct {
+	Debtor   string `json:"debtor"`
+	Creditor string `json:"creditor"`
+	Amount   int64  `json:"amount"`
+}
+
+type MonthSettlement struct {
+	Month string `json:"month"`
+	Debts []Debt `json:"debts"`
+}
+
+type Settlement struct {
+	UserID string            `json:"user_id"`
+	From   string            `json:"from"`
+	To     string            `json:"to"`
+	Months []MonthSettlement `json:"months"`
+}
+
+func TestMembersAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+	owner, weighted, fixed := uuid.New().String(), uuid.New().String(), uuid.New().String()
+
+	postSub := func(t *testing.T, sub Sub) Sub {
+		body, _ := json.Marshal(sub)
+		resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&sub))
+
+		return sub
+	}
+
+	putMember := func(t *testing.T, subID, userID string, member Member) *http.Response {
+		body, _ := json.Marshal(member)
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/subs/%s/members/%s", apiBaseURL, subID, userID), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	getSummary := func(t *testing.T, userID string) int {
+		resp, err := http.Get(fmt.Sprintf("%s/subs/summary?user_id=%s", apiBaseURL, userID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var sum Summary
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&sum))
+
+		return sum.TotalPrice
+	}
+
+	family := postSub(t, Sub{UserID: owner, ServiceName: "Netflix", Price: 900, StartDate: "01-2020"})
+	music := postSub(t, Sub{UserID: fixed, ServiceName: "Spotify", Price: 150, StartDate: "01-2020", EndDate: "02-2020"})
+
+	for _, member := range []struct {
+		subID  string
+		userID string
+		share  Member
+	}{
+		{family.ID, weighted, Member{Weight: 1}},
+		{family.ID, fixed, Member{Amount: 200}},
+		{music.ID, owner, Member{Weight: 2}},
+	} {
+		resp := putMember(t, member.subID, member.userID, member.share)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	t.Run("Success - list members", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/subs/%s/members", apiBaseURL, family.ID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var members []Member
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&members))
+		assert.ElementsMatch(t, []Member{
+			{SubID: family.ID, UserID: weighted, Weight: 1},
+			{SubID: family.ID, UserID: fixed, Amount: 200},
+		}, members)
+	})
+
+	t.Run("Success - summary counts shares", func(t *testing.T) {
+		// Fixed amount is paid first, the rest is split by weights, the owner has weight 1.
+		assert.Equal(t, 350+100, getSummary(t, owner))
+		assert.Equal(t, 350, getSummary(t, weighted))
+		assert.Equal(t, 200+50, getSummary(t, fixed))
+	})
+
+	t.Run("Success - forecast and budgets count shares", func(t *testing.T) {
+		// Spotify has ended, so only shares of Netflix are charged in the coming months.
+		for userID, share := range map[string]int64{owner: 350, weighted: 350, fixed: 200} {
+			resp, err := http.Get(fmt.Sprintf("%s/subs/forecast?user_id=%s&months=1", apiBaseURL, userID))
+			require.NoError(t, err)
+
+			var forecast Forecast
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&forecast))
+			resp.Body.Close()
+
+			require.Len(t, forecast.Months, 1)
+			assert.Equal(t, []ServiceSpend{{ServiceName: "Netflix", Amount: share}}, forecast.Months[0].Services)
+
+			code, budget := postBudget(t, apiBaseURL, Budget{UserID: userID, Amount: 1000})
+			require.Equal(t, http.StatusCreated, code)
+
+			assert.Equal(t, share, getBudgetStatus(t, apiBaseURL, budget.ID).Spend)
+		}
+	})
+
+	t.Run("Success - settlement nets mutual debts", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/subs/settlement?user_id=%s&from=01-2020&to=02-2020", apiBaseURL, owner))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var settlement Settlement
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&settlement))
+		require.Len(t, settlement.Months, 2)
+
+		assert.Equal(t, "01-2020", settlement.Months[0].Month)
+		assert.ElementsMatch(t, []Debt{
+			{Debtor: weighted, Creditor: owner, Amount: 350},
+			{Debtor: fixed, Creditor: owner, Amount: 200 - 100},
+		}, settlement.Months[0].Debts)
+
+		assert.Equal(t, "02-2020", settlement.Months[1].Month)
+		assert.ElementsMatch(t, []Debt{
+			{Debtor: weighted, Creditor: owner, Amount: 350},
+			{Debtor: fixed, Creditor: owner, Amount: 200},
+		}, settlement.Months[1].Debts)
+	})
+
+	t.Run("Success - delete member", func(t *testing.T) {
+		url := fmt.Sprintf("%s/subs/%s/members/%s", apiBaseURL, family.ID, weighted)
+
+		req, _ := http.NewRequest(http.MethodDelete, url, nil)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Equal(t, 0, getSummary(t, weighted))
+
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("Failure - 400 Bad Request (invalid share or owner as member)", func(t *testing.T) {
+		for _, member := range []Member{{}, {Weight: 1, Amount: 100}, {Weight: 101}, {Amount: -1}} {
+			resp := putMember(t, family.ID, weighted, member)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, member)
+		}
+
+		resp := putMember(t, family.ID, owner, Member{Weight: 1})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Failure - 404 Not Found (unknown subscription)", func(t *testing.T) {
+		resp := putMember(t, uuid.New().String(), weighted, Member{Weight: 1})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
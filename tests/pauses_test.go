@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Pause struct {
+	From string `json:"from"`
+	To   string `json:"to,omitempty"`
+}
+
+func TestPauseAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+	userID := uuid.New().String()
+
+	newSub := Sub{
+		UserID:      userID,
+		ServiceName: "Okko",
+		Price:       100,
+		StartDate:   "01-2025",
+	}
+
+	body, _ := json.Marshal(newSub)
+	resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var createdSub Sub
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&createdSub))
+	resp.Body.Close()
+
+	assert.Equal(t, "active", createdSub.Status)
+
+	pauseURL := fmt.Sprintf("%s/subs/%s/pause", apiBaseURL, createdSub.ID)
+	resumeURL := fmt.Sprintf("%s/subs/%s/resume", apiBaseURL, createdSub.ID)
+
+	t.Run("POST /subs/{id}/pause - Pause Sub", func(t *testing.T) {
+		t.Run("Success - 201 Created", func(t *testing.T) {
+			body, _ := json.Marshal(Pause{From: "02-2025", To: "03-2025"})
+			resp, err := http.Post(pauseURL, "application/json", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		})
+
+		t.Run("Failure - 409 Conflict (overlapping pause)", func(t *testing.T) {
+			body, _ := json.Marshal(Pause{From: "03-2025"})
+			resp, err := http.Post(pauseURL, "application/json", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		})
+
+		t.Run("Failure - 400 Bad Request (before start date)", func(t *testing.T) {
+			body, _ := json.Marshal(Pause{From: "12-2024", To: "12-2024"})
+			resp, err := http.Post(pauseURL, "application/json", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	})
+
+	t.Run("GET /subs/summary - Skip Paused Months", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/subs/summary?user_id=%s&from=01-2025&to=04-2025", apiBaseURL, userID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var summary Summary
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&summary))
+		assert.Equal(t, 100+100, summary.TotalPrice)
+	})
+
+	t.Run("POST /subs/{id}/resume - Resume Sub", func(t *testing.T) {
+		t.Run("Failure - 409 Conflict (not paused)", func(t *testing.T) {
+			resp, err := http.Post(resumeURL, "application/json", nil)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		})
+
+		t.Run("Success - 200 OK", func(t *testing.T) {
+			body, _ := json.Marshal(Pause{From: time.Now().Format("01-2006")})
+			resp, err := http.Post(pauseURL, "application/json", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+			resp, err = http.Get(fmt.Sprintf("%s/subs/%s", apiBaseURL, createdSub.ID))
+			require.NoError(t, err)
+
+			var sub Sub
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&sub))
+			resp.Body.Close()
+			assert.Equal(t, "paused", sub.Status)
+
+			resp, err = http.Post(resumeURL, "application/json", nil)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&sub))
+			assert.Equal(t, "active", sub.Status)
+		})
+	})
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type PriceChange struct {
+	EffectiveFrom string `json:"effective_from"`
+	Price         int    `json:"price"`
+}
+
+func TestPriceHistoryAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+	userID := uuid.New().String()
+
+	newSub := Sub{
+		UserID:      userID,
+		ServiceName: "Kinopoisk",
+		Price:       100,
+		StartDate:   "01-2025",
+	}
+
+	body, _ := json.Marshal(newSub)
+	resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var createdSub Sub
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&createdSub))
+	resp.Body.Close()
+
+	pricesURL := fmt.Sprintf("%s/subs/%s/prices", apiBaseURL, createdSub.ID)
+
+	t.Run("POST /subs/{id}/prices - Add Price Change", func(t *testing.T) {
+		t.Run("Success - 201 Created", func(t *testing.T) {
+			body, _ := json.Marshal(PriceChange{EffectiveFrom: "03-2025", Price: 200})
+			resp, err := http.Post(pricesURL, "application/json", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+			var sub Sub
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&sub))
+			assert.Equal(t, 200, sub.Price)
+		})
+
+		t.Run("Failure - 400 Bad Request (before start date)", func(t *testing.T) {
+			body, _ := json.Marshal(PriceChange{EffectiveFrom: "12-2024", Price: 200})
+			resp, err := http.Post(pricesURL, "application/json", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	})
+
+	t.Run("GET /subs/{id}/prices - List Price Changes", func(t *testing.T) {
+		resp, err := http.Get(pricesURL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var changes []PriceChange
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&changes))
+		assert.Equal(t, []PriceChange{{"01-2025", 100}, {"03-2025", 200}}, changes)
+	})
+
+	t.Run("GET /subs/summary - Period Summary", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/subs/summary?user_id=%s&from=01-2025&to=04-2025", apiBaseURL, userID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var summary Summary
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&summary))
+		assert.Equal(t, 100+100+200+200, summary.TotalPrice)
+	})
+}
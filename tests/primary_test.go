@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	apiHTTP "subs-service/internal/api/http"
+	"subs-service/internal/config"
+	"subs-service/pkg/database/postgres"
+	"subs-service/pkg/http/middleware"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadYourWrites(t *testing.T) {
+	// The running service reads from the primary only, so pinning is checked in-process.
+	cfg := middleware.ReadYourWritesConfig{
+		Enabled: true, Window: time.Minute, CookieName: "primary_until", HeaderName: "X-Primary-Until",
+	}
+	pathCfg := config.PathConfig{API: "/api/v1", GraphQL: "/graphql"}
+
+	handler := middleware.ReadYourWrites(cfg, apiHTTP.RateLimitGroup(pathCfg), postgres.WithPrimary)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			// GraphQL mutations mark their writes on their own.
+			if body, _ := io.ReadAll(r.Body); strings.HasPrefix(string(body), "mutation") {
+				ctx = middleware.MarkWrite(ctx)
+			}
+
+			_, _ = w.Write([]byte(strconv.FormatBool(postgres.UsesPrimary(ctx))))
+		}),
+	)
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	do := func(t *testing.T, method, path, body, until string) (*http.Response, string) {
+		req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		if len(until) != 0 {
+			req.Header.Set(cfg.HeaderName, until)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		pinned, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp, string(pinned)
+	}
+
+	t.Run("Success - writes pin the client", func(t *testing.T) {
+		resp, pinned := do(t, http.MethodPost, "/api/v1/subs", "", "")
+		assert.Equal(t, "true", pinned)
+
+		until := resp.Header.Get(cfg.HeaderName)
+		require.NotEmpty(t, until)
+
+		_, pinned = do(t, http.MethodGet, "/api/v1/subs", "", until)
+		assert.Equal(t, "true", pinned)
+	})
+
+	t.Run("Success - GraphQL queries don't pin the client, mutations do", func(t *testing.T) {
+		resp, pinned := do(t, http.MethodPost, "/api/v1/graphql", "query { subs { totalCount } }", "")
+		assert.Equal(t, "false", pinned)
+		assert.Empty(t, resp.Header.Get(cfg.HeaderName))
+		assert.Empty(t, resp.Cookies())
+
+		resp, pinned = do(t, http.MethodPost, "/api/v1/graphql", "mutation { deleteSub(id: \"1\") }", "")
+		assert.Equal(t, "true", pinned)
+		assert.NotEmpty(t, resp.Header.Get(cfg.HeaderName))
+	})
+
+	t.Run("Failure - deadlines beyond the window are ignored", func(t *testing.T) {
+		_, pinned := do(t, http.MethodGet, "/api/v1/subs", "", "99999999999")
+		assert.Equal(t, "false", pinned)
+
+		_, pinned = do(t, http.MethodGet, "/api/v1/subs", "", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		assert.Equal(t, "false", pinned)
+	})
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"subs-service/pkg/http/middleware"
+	"subs-service/pkg/ratelimit"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	// Buckets of the running service are shared by all tests, so small buckets are exhausted in-process.
+	newServer := func(t *testing.T, auth *middleware.AuthConfig) *httptest.Server {
+		limiter := middleware.NewRateLimiter(ratelimit.NewMemoryStore(), middleware.RateLimitConfig{
+			Enabled: true,
+			Groups: map[string]ratelimit.Limit{
+				middleware.GroupRead:  {Requests: 2, Period: time.Second},
+				middleware.GroupWrite: {Requests: 1, Period: time.Minute},
+			},
+		}, nil)
+
+		var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		handler = limiter.Handler(handler)
+		if auth != nil {
+			handler = middleware.Auth(*auth)(handler)
+		}
+
+		server := httptest.NewServer(handler)
+		t.Cleanup(server.Close)
+
+		return server
+	}
+
+	do := func(t *testing.T, method, url, apiKey string) *http.Response {
+		req, _ := http.NewRequest(method, url, nil)
+		if len(apiKey) != 0 {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		return resp
+	}
+
+	t.Run("Failure - 429 Too Many Requests once the bucket is exhausted", func(t *testing.T) {
+		server := newServer(t, nil)
+
+		for i, remaining := range []string{"1", "0"} {
+			resp := do(t, http.MethodGet, server.URL, "")
+			require.Equal(t, http.StatusOK, resp.StatusCode, i)
+
+			assert.Equal(t, "2;w=1", resp.Header.Get("RateLimit-Policy"))
+			assert.Equal(t, "2", resp.Header.Get("RateLimit-Limit"))
+			assert.Equal(t, remaining, resp.Header.Get("RateLimit-Remaining"))
+			assert.NotEmpty(t, resp.Header.Get("RateLimit-Reset"))
+		}
+
+		resp := do(t, http.MethodGet, server.URL, "")
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+		assert.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+		assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+
+		// Unvalidated API keys don't give clients new buckets.
+		resp = do(t, http.MethodGet, server.URL, uuid.NewString())
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	})
+
+	t.Run("Success - groups are limited separately", func(t *testing.T) {
+		server := newServer(t, nil)
+
+		for range 2 {
+			require.Equal(t, http.StatusOK, do(t, http.MethodGet, server.URL, "").StatusCode)
+		}
+
+		require.Equal(t, http.StatusTooManyRequests, do(t, http.MethodGet, server.URL, "").StatusCode)
+
+		resp := do(t, http.MethodPost, server.URL, "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "1;w=60", resp.Header.Get("RateLimit-Policy"))
+
+		assert.Equal(t, http.StatusTooManyRequests, do(t, http.MethodPost, server.URL, "").StatusCode)
+	})
+
+	t.Run("Success - tokens are refilled over the period", func(t *testing.T) {
+		server := newServer(t, nil)
+
+		for range 2 {
+			require.Equal(t, http.StatusOK, do(t, http.MethodGet, server.URL, "").StatusCode)
+		}
+
+		resp := do(t, http.MethodGet, server.URL, "")
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+		retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		require.NoError(t, err)
+
+		time.Sleep(time.Duration(retryAfter) * time.Second)
+
+		assert.Equal(t, http.StatusOK, do(t, http.MethodGet, server.URL, "").StatusCode)
+	})
+
+	t.Run("Success - authenticated subjects have their own buckets", func(t *testing.T) {
+		server := newServer(t, &middleware.AuthConfig{
+			Enabled: true,
+			Header:  "X-API-Key",
+			Keys: []middleware.APIKey{
+				{Key: "first-key", Subject: "first"},
+				{Key: "second-key", Subject: "second"},
+			},
+		})
+
+		for _, key := range []string{"first-key", "second-key"} {
+			for range 2 {
+				require.Equal(t, http.StatusOK, do(t, http.MethodGet, server.URL, key).StatusCode, key)
+			}
+
+			assert.Equal(t, http.StatusTooManyRequests, do(t, http.MethodGet, server.URL, key).StatusCode, key)
+		}
+	})
+
+	t.Run("Success - service reports limits", func(t *testing.T) {
+		apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+
+		resp := do(t, http.MethodGet, fmt.Sprintf("%s/subs?user_id=%s", apiBaseURL, uuid.NewString()), "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.NotEmpty(t, resp.Header.Get("RateLimit-Limit"))
+		assert.NotEmpty(t, resp.Header.Get("RateLimit-Remaining"))
+	})
+}
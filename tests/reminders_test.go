@@ -0,0 +1,270 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"strings"
+	"subs-service/internal/config"
+	"subs-service/internal/domain"
+	"subs-service/internal/repository"
+	repo "subs-service/internal/repository/postgres"
+	"subs-service/internal/usecases/service"
+	"subs-service/pkg/database/postgres"
+	"subs-service/pkg/notify"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts every message and keeps it, or stalls without greeting if stall is set.
+type fakeSMTPServer struct {
+	listener net.Listener
+	stall    bool
+
+	mu       sync.Mutex
+	messages []*mail.Message
+	rcpts    [][]string
+}
+
+func newFakeSMTPServer(t *testing.T, stall bool) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeSMTPServer{listener: listener, stall: stall}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, acceptErr := listener.Accept()
+			if acceptErr != nil {
+				return
+			}
+
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	if s.stall {
+		_, _ = conn.Read(make([]byte, 1))
+		return
+	}
+
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 fake ESMTP")
+
+	var rcpts []string
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		switch verb := strings.ToUpper(strings.Fields(line + " ")[0]); verb {
+		case "EHLO", "HELO", "MAIL", "RSET", "NOOP":
+			_ = text.PrintfLine("250 OK")
+		case "RCPT":
+			rcpts = append(rcpts, strings.Trim(strings.TrimPrefix(line[len(verb):], " TO:"), "<>"))
+			_ = text.PrintfLine("250 OK")
+		case "DATA":
+			_ = text.PrintfLine("354 Go ahead")
+
+			data, readErr := text.ReadDotBytes()
+			if readErr != nil {
+				return
+			}
+
+			msg, parseErr := mail.ReadMessage(bytes.NewReader(data))
+			if parseErr != nil {
+				_ = text.PrintfLine("554 %s", parseErr.Error())
+				continue
+			}
+
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.rcpts = append(s.rcpts, rcpts)
+			s.mu.Unlock()
+
+			rcpts = nil
+			_ = text.PrintfLine("250 OK")
+		case "QUIT":
+			_ = text.PrintfLine("221 Bye")
+			return
+		default:
+			_ = text.PrintfLine("502 Not implemented")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) received() ([]*mail.Message, [][]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.messages, s.rcpts
+}
+
+// userReminders keeps reminders of the user only, since the database is shared by all tests.
+type userReminders struct {
+	repository.RemindersRepo
+	userID uuid.UUID
+}
+
+func (r *userReminders) ListReminders(ctx context.Context, before time.Time) ([]*domain.Reminder, error) {
+	reminders, err := r.RemindersRepo.ListReminders(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*domain.Reminder
+
+	for _, reminder := range reminders {
+		if reminder.UserID == r.userID {
+			res = append(res, reminder)
+		}
+	}
+
+	return res, nil
+}
+
+func TestSMTPReminders(t *testing.T) {
+	smtpCfg := func(server *fakeSMTPServer) notify.SMTPConfig {
+		return notify.SMTPConfig{
+			Address: server.listener.Addr().String(),
+			From:    "subs-service@example.com",
+			To:      []string{"first@example.com", "second@example.com"},
+			Timeout: 5 * time.Second,
+		}
+	}
+
+	t.Run("Success - notification is sent as email", func(t *testing.T) {
+		server := newFakeSMTPServer(t, false)
+		notifier := notify.NewSMTPNotifier(smtpCfg(server))
+
+		userID := uuid.NewString()
+
+		require.NoError(t, notifier.Notify(context.Background(), &notify.Notification{
+			Key:     "charge:key",
+			UserID:  userID,
+			Subject: "Предстоящее списание",
+			Text:    "Subscription Test will be charged 100",
+		}))
+
+		messages, rcpts := server.received()
+		require.Len(t, messages, 1)
+
+		assert.Equal(t, []string{"first@example.com", "second@example.com"}, rcpts[0])
+
+		msg := messages[0]
+		assert.Equal(t, "subs-service@example.com", msg.Header.Get("From"))
+		assert.Equal(t, "first@example.com, second@example.com", msg.Header.Get("To"))
+		assert.Equal(t, "charge:key", msg.Header.Get("X-Notification-Key"))
+		assert.Equal(t, userID, msg.Header.Get("X-User-ID"))
+		assert.Equal(t, "text/plain; charset=utf-8", msg.Header.Get("Content-Type"))
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "Предстоящее списание", subject)
+
+		// The fake server decodes the data with line endings normalized to LF.
+		body, err := io.ReadAll(msg.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "Subscription Test will be charged 100\n", string(body))
+	})
+
+	t.Run("Failure - stalled server doesn't block notifier past the timeout", func(t *testing.T) {
+		server := newFakeSMTPServer(t, true)
+
+		cfg := smtpCfg(server)
+		cfg.Timeout = 200 * time.Millisecond
+
+		start := time.Now()
+		err := notify.NewSMTPNotifier(cfg).Notify(context.Background(), &notify.Notification{Key: "stalled"})
+
+		require.Error(t, err)
+		assert.Less(t, time.Since(start), 2*time.Second)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		cfg.Timeout = time.Minute
+		start = time.Now()
+
+		require.Error(t, notify.NewSMTPNotifier(cfg).Notify(ctx, &notify.Notification{Key: "stalled"}))
+		assert.Less(t, time.Since(start), 2*time.Second)
+	})
+
+	t.Run("Success - reminder about the charge is sent once", func(t *testing.T) {
+		apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+		userID := uuid.New()
+
+		// The charge is beyond the lead time of the service, so that its own reminder doesn't claim it first.
+		now := time.Now().UTC()
+		chargeMonth := time.Date(now.Year(), now.Month()+2, 1, 0, 0, 0, 0, time.UTC)
+
+		body, _ := json.Marshal(Sub{
+			UserID:      userID.String(),
+			ServiceName: "Reminded " + uuid.NewString()[:8],
+			Price:       700,
+			StartDate:   chargeMonth.Format("01-2006"),
+		})
+
+		resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		cluster, err := postgres.NewCluster(postgres.Config{
+			DSN:               os.Getenv("POSTGRES_DSN"),
+			ConnectionTimeout: time.Second,
+			ConnectAttempts:   5,
+			RetryBackoff:      200 * time.Millisecond,
+			MaxRetryBackoff:   time.Second,
+		})
+		require.NoError(t, err)
+		defer cluster.Close()
+
+		server := newFakeSMTPServer(t, false)
+
+		reminder := service.NewReminder(
+			&userReminders{RemindersRepo: repo.NewRemindersRepo(cluster), userID: userID},
+			repo.NewNotificationsRepo(cluster),
+			notify.NewSMTPNotifier(smtpCfg(server)),
+			config.ReminderConfig{LeadTime: time.Until(chargeMonth) + 24*time.Hour},
+		)
+
+		// The second run finds the charge claimed in sent_notifications.
+		for range 2 {
+			require.NoError(t, reminder.Remind(context.Background()))
+		}
+
+		messages, _ := server.received()
+		require.Len(t, messages, 1)
+
+		assert.Equal(t, "Upcoming subscription charge", messages[0].Header.Get("Subject"))
+		assert.Equal(t, userID.String(), messages[0].Header.Get("X-User-ID"))
+		assert.True(t, strings.HasPrefix(messages[0].Header.Get("X-Notification-Key"), "charge:"))
+
+		text, err := io.ReadAll(messages[0].Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(text), fmt.Sprintf("will be charged 700 on %s", chargeMonth.Format(time.DateOnly)))
+	})
+}
@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type StreamEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// readEvent skips comments and reads the next event of the stream.
+func readEvent(t *testing.T, scanner *bufio.Scanner) StreamEvent {
+	t.Helper()
+
+	var event StreamEvent
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case len(line) == 0 && (len(event.Event) != 0 || len(event.Data) != 0):
+			return event
+		case strings.HasPrefix(line, "id: "):
+			event.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.Data += strings.TrimPrefix(line, "data: ")
+		}
+	}
+
+	require.NoError(t, scanner.Err())
+	t.Fatal("stream closed")
+
+	return event
+}
+
+func openStream(t *testing.T, url, lastEventID string) (*http.Response, *bufio.Scanner) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	if len(lastEventID) != 0 {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	return resp, bufio.NewScanner(resp.Body)
+}
+
+func TestStreamAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+	userID := uuid.New().String()
+	streamURL := fmt.Sprintf("%s/subs/stream?user_id=%s", apiBaseURL, userID)
+
+	_, scanner := openStream(t, streamURL, "")
+
+	newSub := Sub{
+		UserID:      userID,
+		ServiceName: "Kinopoisk",
+		Price:       300,
+		StartDate:   "01-2025",
+	}
+
+	body, _ := json.Marshal(newSub)
+	resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var createdSub Sub
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&createdSub))
+	resp.Body.Close()
+
+	var created StreamEvent
+
+	t.Run("Created event is streamed", func(t *testing.T) {
+		created = readEvent(t, scanner)
+
+		assert.Equal(t, "sub.created", created.Event)
+		assert.NotEmpty(t, created.ID)
+		assert.Contains(t, created.Data, createdSub.ID)
+	})
+
+	req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/subs/%s", apiBaseURL, createdSub.ID), nil)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	t.Run("Deleted event is streamed", func(t *testing.T) {
+		event := readEvent(t, scanner)
+
+		assert.Equal(t, "sub.deleted", event.Event)
+		assert.Contains(t, event.Data, createdSub.ID)
+	})
+
+	t.Run("Missed events are replayed after Last-Event-ID", func(t *testing.T) {
+		_, resumed := openStream(t, streamURL, created.ID)
+
+		event := readEvent(t, resumed)
+		assert.Equal(t, "sub.deleted", event.Event)
+	})
+
+	t.Run("Failure - 400 Bad Request (invalid user_id)", func(t *testing.T) {
+		resp, err := http.Get(apiBaseURL + "/subs/stream?user_id=invalid")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
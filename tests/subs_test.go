@@ -15,12 +15,19 @@ import (
 )
 
 type Sub struct {
-	ID          string `json:"id,omitempty"`
-	UserID      string `json:"user_id"`
-	ServiceName string `json:"service_name"`
-	Price       int    `json:"price"`
-	StartDate   string `json:"start_date"`
-	EndDate     string `json:"end_date,omitempty"`
+	ID          string   `json:"id,omitempty"`
+	UserID      string   `json:"user_id"`
+	ServiceName string   `json:"service_name"`
+	ServiceID   string   `json:"service_id,omitempty"`
+	Price       int      `json:"price"`
+	StartDate   string   `json:"start_date"`
+	EndDate     string   `json:"end_date,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	TrialMonths int      `json:"trial_months,omitempty"`
+	PromoPrice  int      `json:"promo_price,omitempty"`
+	TrialEnd    string   `json:"trial_end,omitempty"`
+	Status      string   `json:"status,omitempty"`
 }
 
 type ListSubsResponse struct {
@@ -28,10 +35,16 @@ type ListSubsResponse struct {
 	NextPageToken string `json:"next_page_token"`
 }
 
+type SummaryGroup struct {
+	Key        string `json:"key"`
+	TotalPrice int    `json:"total_price"`
+}
+
 type Summary struct {
-	UserID      string `json:"user_id"`
-	ServiceName string `json:"service_name,omitempty"`
-	TotalPrice  int    `json:"total_price"`
+	UserID      string         `json:"user_id"`
+	ServiceName string         `json:"service_name,omitempty"`
+	TotalPrice  int            `json:"total_price"`
+	Groups      []SummaryGroup `json:"groups,omitempty"`
 }
 
 const (
@@ -225,4 +238,44 @@ func TestSubscriptionsAPI(t *testing.T) {
 			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 		})
 	})
+
+	t.Run("POST /subs/{id}/restore - Restore Subscription", func(t *testing.T) {
+		t.Run("Success - 200 OK", func(t *testing.T) {
+			url := fmt.Sprintf("%s/subs/%s/restore", apiBaseURL, createdSubID)
+			req, _ := http.NewRequest(http.MethodPost, url, nil)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var sub Sub
+			err = json.NewDecoder(resp.Body).Decode(&sub)
+			require.NoError(t, err)
+			assert.Equal(t, createdSubID, sub.ID)
+		})
+
+		t.Run("Check restoration - 200 OK", func(t *testing.T) {
+			url := fmt.Sprintf("%s/subs/%s", apiBaseURL, createdSubID)
+			req, _ := http.NewRequest(http.MethodGet, url, nil)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+
+		t.Run("Failure - 404 Not Found (not deleted)", func(t *testing.T) {
+			url := fmt.Sprintf("%s/subs/%s/restore", apiBaseURL, createdSubID)
+			req, _ := http.NewRequest(http.MethodPost, url, nil)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		})
+	})
 }
@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Tag struct {
+	Name string `json:"name"`
+	Subs int    `json:"subs"`
+}
+
+func TestTagsAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+	userID := uuid.New().String()
+	tagsURL := fmt.Sprintf("%s/users/%s/tags", apiBaseURL, userID)
+
+	subs := []Sub{
+		{UserID: userID, ServiceName: "Netflix", Price: 500, StartDate: "01-2020", Category: "streaming", Tags: []string{"video", "family"}},
+		{UserID: userID, ServiceName: "Spotify", Price: 200, StartDate: "01-2020", Category: "streaming", Tags: []string{" music ", "family", "music"}},
+		{UserID: userID, ServiceName: "Notion", Price: 300, StartDate: "01-2020", Category: "productivity", Tags: []string{"work"}},
+		{UserID: userID, ServiceName: "Dropbox", Price: 100, StartDate: "01-2020"},
+	}
+
+	for i, sub := range subs {
+		body, _ := json.Marshal(sub)
+		resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewBuffer(body))
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&subs[i]))
+		resp.Body.Close()
+	}
+
+	listSubs := func(t *testing.T, query string) []Sub {
+		resp, err := http.Get(fmt.Sprintf("%s/subs?user_id=%s&%s", apiBaseURL, userID, query))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var list ListSubsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+
+		return list.Subs
+	}
+
+	getSummary := func(t *testing.T, query string) Summary {
+		resp, err := http.Get(fmt.Sprintf("%s/subs/summary?user_id=%s&%s", apiBaseURL, userID, query))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var sum Summary
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&sum))
+
+		return sum
+	}
+
+	postTags := func(t *testing.T, action string, body any) *http.Response {
+		data, _ := json.Marshal(body)
+		resp, err := http.Post(fmt.Sprintf("%s/%s", tagsURL, action), "application/json", bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	t.Run("Success - tags are normalized", func(t *testing.T) {
+		assert.Equal(t, []string{"family", "music"}, subs[1].Tags)
+		assert.Equal(t, "streaming", subs[1].Category)
+	})
+
+	t.Run("Success - filter by any and all of tags", func(t *testing.T) {
+		assert.Len(t, listSubs(t, "tags=video,music"), 2)
+		assert.Len(t, listSubs(t, "tags=video,family&tags_match=all"), 1)
+		assert.Empty(t, listSubs(t, "tags=video,work&tags_match=all"))
+	})
+
+	t.Run("Success - filter by category", func(t *testing.T) {
+		list := listSubs(t, "category=productivity")
+		require.Len(t, list, 1)
+		assert.Equal(t, "Notion", list[0].ServiceName)
+	})
+
+	t.Run("Success - summary grouped by category", func(t *testing.T) {
+		sum := getSummary(t, "group_by=category")
+
+		assert.Equal(t, 1100, sum.TotalPrice)
+		assert.Equal(t, []SummaryGroup{
+			{Key: "", TotalPrice: 100},
+			{Key: "productivity", TotalPrice: 300},
+			{Key: "streaming", TotalPrice: 700},
+		}, sum.Groups)
+	})
+
+	t.Run("Success - summary grouped by tag", func(t *testing.T) {
+		sum := getSummary(t, "group_by=tag&from=01-2020&to=02-2020")
+
+		assert.Equal(t, 2200, sum.TotalPrice)
+		assert.Equal(t, []SummaryGroup{
+			{Key: "", TotalPrice: 200},
+			{Key: "family", TotalPrice: 1400},
+			{Key: "music", TotalPrice: 400},
+			{Key: "video", TotalPrice: 1000},
+			{Key: "work", TotalPrice: 600},
+		}, sum.Groups)
+	})
+
+	t.Run("Success - summary filtered by tag", func(t *testing.T) {
+		assert.Equal(t, 700, getSummary(t, "tags=family").TotalPrice)
+	})
+
+	t.Run("Success - rename tag", func(t *testing.T) {
+		resp := postTags(t, "rename", map[string]string{"from": "video", "to": "movies"})
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var tag Tag
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&tag))
+		assert.Equal(t, Tag{Name: "movies", Subs: 1}, tag)
+
+		assert.Len(t, listSubs(t, "tags=movies"), 1)
+		assert.Empty(t, listSubs(t, "tags=video"))
+	})
+
+	t.Run("Success - merge tags", func(t *testing.T) {
+		resp := postTags(t, "merge", map[string]any{"sources": []string{"movies", "music"}, "target": "media"})
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var tag Tag
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&tag))
+		assert.Equal(t, Tag{Name: "media", Subs: 2}, tag)
+
+		resp, err := http.Get(tagsURL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var tags []Tag
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&tags))
+		assert.Equal(t, []Tag{{Name: "family", Subs: 2}, {Name: "media", Subs: 2}, {Name: "work", Subs: 1}}, tags)
+	})
+
+	t.Run("Failure - 409 Conflict (rename to existing tag)", func(t *testing.T) {
+		resp := postTags(t, "rename", map[string]string{"from": "work", "to": "family"})
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("Failure - 404 Not Found (unknown tag)", func(t *testing.T) {
+		resp := postTags(t, "rename", map[string]string{"from": "unknown", "to": "other"})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp = postTags(t, "merge", map[string]any{"sources": []string{"unknown"}, "target": "work"})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("Failure - 400 Bad Request (invalid filters)", func(t *testing.T) {
+		for _, query := range []string{"tags_match=some", "group_by=price", "tags=a,,b"} {
+			resp, err := http.Get(fmt.Sprintf("%s/subs/summary?user_id=%s&%s", apiBaseURL, userID, query))
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantsAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+	userID := uuid.New().String()
+	tenantA := "tenant-a-" + uuid.NewString()[:8]
+	tenantB := "tenant-b-" + uuid.NewString()[:8]
+
+	doRequest := func(t *testing.T, tenant, method, url string, body any) *http.Response {
+		var reader io.Reader
+		if body != nil {
+			data, _ := json.Marshal(body)
+			reader = bytes.NewBuffer(data)
+		}
+
+		req, _ := http.NewRequest(method, url, reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Tenant-ID", tenant)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	listSubs := func(t *testing.T, tenant string) []Sub {
+		resp := doRequest(t, tenant, http.MethodGet, fmt.Sprintf("%s/subs?user_id=%s", apiBaseURL, userID), nil)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var list ListSubsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+
+		return list.Subs
+	}
+
+	// The same user id is used in both tenants.
+	var subA Sub
+
+	resp := doRequest(t, tenantA, http.MethodPost, apiBaseURL+"/subs",
+		Sub{UserID: userID, ServiceName: "Netflix", Price: 500, StartDate: "01-2020", Tags: []string{"video"}})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&subA))
+	resp.Body.Close()
+
+	resp = doRequest(t, tenantB, http.MethodPost, apiBaseURL+"/subs",
+		Sub{UserID: userID, ServiceName: "Spotify", Price: 200, StartDate: "01-2020", Tags: []string{"music"}})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	t.Run("Success - lists are isolated", func(t *testing.T) {
+		listA := listSubs(t, tenantA)
+		require.Len(t, listA, 1)
+		assert.Equal(t, "Netflix", listA[0].ServiceName)
+
+		listB := listSubs(t, tenantB)
+		require.Len(t, listB, 1)
+		assert.Equal(t, "Spotify", listB[0].ServiceName)
+	})
+
+	t.Run("Success - summaries and tags are isolated", func(t *testing.T) {
+		resp := doRequest(t, tenantB, http.MethodGet, fmt.Sprintf("%s/subs/summary?user_id=%s", apiBaseURL, userID), nil)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var sum Summary
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&sum))
+		assert.Equal(t, 200, sum.TotalPrice)
+
+		resp = doRequest(t, tenantB, http.MethodGet, fmt.Sprintf("%s/users/%s/tags", apiBaseURL, userID), nil)
+		defer resp.Body.Close()
+
+		var tags []Tag
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&tags))
+		assert.Equal(t, []Tag{{Name: "music", Subs: 1}}, tags)
+	})
+
+	t.Run("Failure - 404 Not Found (subscription of another tenant)", func(t *testing.T) {
+		url := fmt.Sprintf("%s/subs/%s", apiBaseURL, subA.ID)
+
+		resp := doRequest(t, tenantB, http.MethodGet, url, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp = doRequest(t, tenantB, http.MethodDelete, url, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp = doRequest(t, tenantA, http.MethodGet, url, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Failure - 400 Bad Request (invalid tenant)", func(t *testing.T) {
+		resp := doRequest(t, "bad tenant!", http.MethodGet, fmt.Sprintf("%s/subs?user_id=%s", apiBaseURL, userID), nil)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,218 @@
+package tests
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"subs-service/pkg/http/server"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+// newTestCert issues the certificate by the parent or, if it is nil, the self-signed CA certificate.
+func newTestCert(t *testing.T, name string, parent *testCert, usage x509.ExtKeyUsage) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{usage}
+		template.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1)}
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (c *testCert) keyPEM(t *testing.T) []byte {
+	der, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func (c *testCert) tlsCert(t *testing.T) tls.Certificate {
+	cert, err := tls.X509KeyPair(c.pem, c.keyPEM(t))
+	require.NoError(t, err)
+
+	return cert
+}
+
+func TestTLSServer(t *testing.T) {
+	ca := newTestCert(t, "Test CA", nil, 0)
+	client := newTestCert(t, "client", ca, x509.ExtKeyUsageClientAuth)
+
+	dir := t.TempDir()
+	certFile, keyFile, caFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt")
+
+	// Modification times are moved forward on each write, so that the change is noticed regardless of their precision.
+	writeServerCert := func(t *testing.T, name string, modTime time.Time) {
+		cert := newTestCert(t, name, ca, x509.ExtKeyUsageServerAuth)
+
+		require.NoError(t, os.WriteFile(certFile, cert.pem, 0o600))
+		require.NoError(t, os.WriteFile(keyFile, cert.keyPEM(t), 0o600))
+		require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+		require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+	}
+
+	writeServerCert(t, "first", time.Now())
+	require.NoError(t, os.WriteFile(caFile, ca.pem, 0o600))
+
+	startServer := func(t *testing.T, cfg server.HTTPConfig) string {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		cfg.Address = listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		cfg.ReadTimeout, cfg.WriteTimeout, cfg.IdleTimeout = 5*time.Second, 5*time.Second, 5*time.Second
+		cfg.ShutdownTimeout = time.Second
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() {
+			done <- server.CreateServer(ctx, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(r.Proto))
+			}), cfg)
+		}()
+
+		t.Cleanup(func() {
+			cancel()
+			assert.NoError(t, <-done)
+		})
+
+		require.Eventually(t, func() bool {
+			conn, dialErr := net.Dial("tcp", cfg.Address)
+			if dialErr == nil {
+				conn.Close()
+			}
+
+			return dialErr == nil
+		}, 5*time.Second, 20*time.Millisecond)
+
+		return cfg.Address
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	get := func(clientCfg *tls.Config, address string) (*http.Response, error) {
+		var protocols http.Protocols
+		protocols.SetHTTP1(true)
+		protocols.SetHTTP2(true)
+
+		httpClient := &http.Client{Transport: &http.Transport{
+			TLSClientConfig:   clientCfg,
+			Protocols:         &protocols,
+			DisableKeepAlives: true,
+		}}
+
+		resp, err := httpClient.Get("https://" + address)
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		return resp, err
+	}
+
+	t.Run("Success - HTTP/2 is negotiated and certificates are reloaded", func(t *testing.T) {
+		address := startServer(t, server.HTTPConfig{TLS: server.TLSConfig{
+			Enabled:        true,
+			CertFile:       certFile,
+			KeyFile:        keyFile,
+			MinVersion:     "1.2",
+			ReloadInterval: 100 * time.Millisecond,
+		}})
+
+		resp, err := get(&tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}, address)
+		require.NoError(t, err)
+
+		assert.Equal(t, "HTTP/2.0", resp.Proto)
+		assert.Equal(t, "first", resp.TLS.PeerCertificates[0].Subject.CommonName)
+
+		writeServerCert(t, "second", time.Now().Add(time.Minute))
+		time.Sleep(200 * time.Millisecond)
+
+		resp, err = get(&tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}, address)
+		require.NoError(t, err)
+
+		assert.Equal(t, "second", resp.TLS.PeerCertificates[0].Subject.CommonName)
+	})
+
+	t.Run("Failure - mTLS rejects clients without certificate", func(t *testing.T) {
+		address := startServer(t, server.HTTPConfig{TLS: server.TLSConfig{
+			Enabled:        true,
+			CertFile:       certFile,
+			KeyFile:        keyFile,
+			MinVersion:     "1.2",
+			ClientCAFile:   caFile,
+			ReloadInterval: time.Minute,
+		}})
+
+		_, err := get(&tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}, address)
+		require.Error(t, err)
+
+		resp, err := get(&tls.Config{
+			RootCAs:      roots,
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{client.tlsCert(t)},
+		}, address)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Success - h2c serves HTTP/2 with prior knowledge", func(t *testing.T) {
+		address := startServer(t, server.HTTPConfig{H2C: true})
+
+		var protocols http.Protocols
+		protocols.SetUnencryptedHTTP2(true)
+
+		httpClient := &http.Client{Transport: &http.Transport{Protocols: &protocols}}
+
+		resp, err := httpClient.Get("http://" + address)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, "HTTP/2.0", resp.Proto)
+	})
+}
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrialAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+	userID := uuid.New().String()
+
+	postSub := func(t *testing.T, sub Sub) *http.Response {
+		body, _ := json.Marshal(sub)
+		resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewBuffer(body))
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	t.Run("POST /subs - Create Sub With Trial", func(t *testing.T) {
+		t.Run("Success - 201 Created", func(t *testing.T) {
+			resp := postSub(t, Sub{
+				UserID: userID, ServiceName: "Wink", Price: 300, StartDate: "01-2025",
+				TrialMonths: 2, PromoPrice: 50,
+			})
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+			var sub Sub
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&sub))
+			assert.Equal(t, "03-2025", sub.TrialEnd)
+		})
+
+		t.Run("Failure - 400 Bad Request (promo price without trial)", func(t *testing.T) {
+			resp := postSub(t, Sub{
+				UserID: userID, ServiceName: "Wink", Price: 300, StartDate: "01-2025", PromoPrice: 50,
+			})
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	})
+
+	t.Run("GET /subs/summary - Charge Trial By Promo Price", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/subs/summary?user_id=%s&from=01-2025&to=04-2025", apiBaseURL, userID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var summary Summary
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&summary))
+		assert.Equal(t, 50+50+300+300, summary.TotalPrice)
+	})
+
+	t.Run("GET /subs/summary - Charge Future Sub Without Trial By Price", func(t *testing.T) {
+		otherUserID := uuid.New().String()
+
+		resp := postSub(t, Sub{
+			UserID: otherUserID, ServiceName: "Future", Price: 400, StartDate: time.Now().AddDate(0, 2, 0).Format("01-2006"),
+		})
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		resp, err := http.Get(fmt.Sprintf("%s/subs/summary?user_id=%s", apiBaseURL, otherUserID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var summary Summary
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&summary))
+		assert.Equal(t, 400, summary.TotalPrice)
+	})
+
+	t.Run("GET /subs - Filter By Trial End", func(t *testing.T) {
+		// Trial started this month ends at the beginning of the next one.
+		resp := postSub(t, Sub{
+			UserID: userID, ServiceName: "Start", Price: 300, StartDate: time.Now().Format("01-2006"), TrialMonths: 1,
+		})
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		resp, err := http.Get(fmt.Sprintf("%s/subs?user_id=%s&trial_ends_within=31", apiBaseURL, userID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var list ListSubsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+		require.Len(t, list.Subs, 1)
+		assert.Equal(t, "Start", list.Subs[0].ServiceName)
+	})
+}
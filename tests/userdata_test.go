@@ -0,0 +1,176 @@
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type DataExport struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+func TestUserDataAPI(t *testing.T) {
+	baseURL := fmt.Sprintf("http://%s", os.Getenv("HTTP_ADDRESS"))
+	apiBaseURL := baseURL + "/api/v1"
+	userID := uuid.New().String()
+	userURL := fmt.Sprintf("%s/users/%s", apiBaseURL, userID)
+
+	post := func(t *testing.T, url string, body any) *http.Response {
+		data, _ := json.Marshal(body)
+		resp, err := http.Post(url, "application/json", bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	resp := post(t, apiBaseURL+"/subs",
+		Sub{UserID: userID, ServiceName: "Netflix", Price: 500, StartDate: "01-2020", Tags: []string{"video"}})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var sub Sub
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&sub))
+	resp.Body.Close()
+
+	resp = post(t, apiBaseURL+"/budgets", Budget{UserID: userID, Amount: 1000})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	var export DataExport
+
+	t.Run("Success - export is requested and completed", func(t *testing.T) {
+		resp := post(t, userURL+"/data-export", nil)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&export))
+		assert.Equal(t, userID, export.UserID)
+		assert.Equal(t, fmt.Sprintf("/api/v1/users/%s/data-export/%s", userID, export.ID), resp.Header.Get("Location"))
+
+		require.Eventually(t, func() bool {
+			statusResp, err := http.Get(baseURL + resp.Header.Get("Location"))
+			if err != nil {
+				return false
+			}
+			defer statusResp.Body.Close()
+
+			if statusResp.StatusCode != http.StatusOK || json.NewDecoder(statusResp.Body).Decode(&export) != nil {
+				return false
+			}
+
+			return export.Status == "completed" || export.Status == "failed"
+		}, 30*time.Second, 500*time.Millisecond)
+
+		assert.Equal(t, "completed", export.Status, export.Error)
+	})
+
+	t.Run("Success - archive contains user's data in JSON and CSV", func(t *testing.T) {
+		resp, err := http.Get(userURL + "/data-export")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/zip", resp.Header.Get("Content-Type"))
+
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+
+		files := map[string]*zip.File{}
+		for _, file := range archive.File {
+			files[file.Name] = file
+		}
+
+		for _, name := range []string{"subs", "history", "budgets", "notifications"} {
+			assert.Contains(t, files, name+".json")
+			assert.Contains(t, files, name+".csv")
+		}
+
+		require.Contains(t, files, "subs.json")
+
+		subsFile, err := files["subs.json"].Open()
+		require.NoError(t, err)
+		defer subsFile.Close()
+
+		var subs []Sub
+		require.NoError(t, json.NewDecoder(subsFile).Decode(&subs))
+		require.Len(t, subs, 1)
+		assert.Equal(t, sub.ID, subs[0].ID)
+		assert.Equal(t, []string{"video"}, subs[0].Tags)
+	})
+
+	t.Run("Failure - 404 Not Found (export of another user)", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/users/%s/data-export/%s", apiBaseURL, uuid.NewString(), export.ID))
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, err = http.Get(fmt.Sprintf("%s/users/%s/data-export", apiBaseURL, uuid.NewString()))
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("Success - user's data are erased", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, userURL, nil)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp, err = http.Get(fmt.Sprintf("%s/subs/%s?include_deleted=true", apiBaseURL, sub.ID))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, err = http.Get(fmt.Sprintf("%s/budgets?user_id=%s", apiBaseURL, userID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var budgets []Budget
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&budgets))
+		assert.Empty(t, budgets)
+
+		resp, err = http.Get(userURL + "/data-export")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("Success - audit entries are anonymized", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/audit?user_id=%s", apiBaseURL, userID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var listResp ListAuditResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&listResp))
+		require.NotEmpty(t, listResp.Entries)
+
+		for _, entry := range listResp.Entries {
+			assert.Empty(t, entry.Before)
+			assert.Empty(t, entry.After)
+		}
+
+		assert.Equal(t, "erase", listResp.Entries[len(listResp.Entries)-1].Operation)
+	})
+}
@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Webhook struct {
+	ID     string   `json:"id,omitempty"`
+	URL    string   `json:"url"`
+	Secret string   `json:"secret,omitempty"`
+	Events []string `json:"events"`
+}
+
+type WebhookDelivery struct {
+	ID     int64  `json:"id"`
+	Event  string `json:"event"`
+	Status string `json:"status"`
+}
+
+type ListDeliveriesResponse struct {
+	Deliveries    []WebhookDelivery `json:"deliveries"`
+	NextPageToken string            `json:"next_page_token"`
+}
+
+func TestWebhooksAPI(t *testing.T) {
+	apiBaseURL := fmt.Sprintf("http://%s/api/v1", os.Getenv("HTTP_ADDRESS"))
+
+	// Nothing listens there, so deliveries stay undelivered.
+	newWebhook := Webhook{URL: "http://127.0.0.1:9/hook", Secret: "secret", Events: []string{"sub.deleted"}}
+
+	body, _ := json.Marshal(newWebhook)
+	resp, err := http.Post(apiBaseURL+"/webhooks", "application/json", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var createdWebhook Webhook
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&createdWebhook))
+	resp.Body.Close()
+
+	assert.Empty(t, createdWebhook.Secret)
+
+	webhookURL := fmt.Sprintf("%s/webhooks/%s", apiBaseURL, createdWebhook.ID)
+
+	t.Run("POST /webhooks - Register Webhook", func(t *testing.T) {
+		t.Run("Failure - 400 Bad Request (unknown event)", func(t *testing.T) {
+			body, _ := json.Marshal(Webhook{URL: "http://127.0.0.1:9/hook", Secret: "secret", Events: []string{"sub.unknown"}})
+			resp, err := http.Post(apiBaseURL+"/webhooks", "application/json", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+
+		t.Run("Failure - 400 Bad Request (no secret)", func(t *testing.T) {
+			body, _ := json.Marshal(Webhook{URL: "http://127.0.0.1:9/hook"})
+			resp, err := http.Post(apiBaseURL+"/webhooks", "application/json", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	})
+
+	t.Run("GET /webhooks/{id}/deliveries - List Deliveries", func(t *testing.T) {
+		sub := Sub{UserID: uuid.New().String(), ServiceName: "Ivi", Price: 200, StartDate: time.Now().Format(TimeLayout)}
+
+		body, _ := json.Marshal(sub)
+		resp, err := http.Post(apiBaseURL+"/subs", "application/json", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&sub))
+		resp.Body.Close()
+
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/subs/%s", apiBaseURL, sub.ID), nil)
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, err = http.Get(webhookURL + "/deliveries")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var list ListDeliveriesResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+		require.NotEmpty(t, list.Deliveries)
+
+		for _, delivery := range list.Deliveries {
+			assert.Equal(t, "sub.deleted", delivery.Event)
+			assert.NotEqual(t, "delivered", delivery.Status)
+		}
+
+		t.Run("Redeliver - 200 OK", func(t *testing.T) {
+			url := fmt.Sprintf("%s/deliveries/%d/redeliver", webhookURL, list.Deliveries[0].ID)
+			resp, err := http.Post(url, "application/json", nil)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var delivery WebhookDelivery
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&delivery))
+			assert.Equal(t, "pending", delivery.Status)
+		})
+	})
+
+	t.Run("DELETE /webhooks/{id} - Delete Webhook", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, webhookURL, nil)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, err = http.Get(webhookURL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}